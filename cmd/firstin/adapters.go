@@ -0,0 +1,80 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/amishk599/firstin/internal/adapter"
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// fetcherDeps are the shared dependencies every adapter constructor gets.
+// jobFilter may be nil (audit mode fetches everything).
+type fetcherDeps struct {
+	httpClient *http.Client
+	jobFilter  model.JobFilter
+	logger     *slog.Logger
+}
+
+// fetcherConstructor builds the adapter for one company on its ATS.
+type fetcherConstructor func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher
+
+// fetcherTypes maps a company's ats to its adapter constructor. Adapters add
+// themselves from init via registerFetcher.
+var fetcherTypes = map[string]fetcherConstructor{}
+
+// registerFetcher makes companies on ats build with newFn. Registering an
+// ATS twice is a programming error and panics.
+func registerFetcher(ats string, newFn fetcherConstructor) {
+	if _, dup := fetcherTypes[ats]; dup {
+		panic("adapter registered twice: " + ats)
+	}
+	fetcherTypes[ats] = newFn
+}
+
+func init() {
+	registerFetcher("greenhouse", func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher {
+		a := adapter.NewGreenhouseAdapter(company.BoardToken, company.Name, deps.httpClient)
+		a.SetDepartment(company.DepartmentID)
+		return a
+	})
+	registerFetcher("ashby", func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher {
+		return adapter.NewAshbyAdapter(company.BoardToken, company.Name, deps.httpClient)
+	})
+	registerFetcher("lever", func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher {
+		return adapter.NewLeverAdapter(company.BoardToken, company.Name, deps.httpClient)
+	})
+	registerFetcher("gem", func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher {
+		return adapter.NewGemAdapter(company.BoardToken, company.Name, deps.httpClient)
+	})
+	registerFetcher("workday", func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher {
+		search := adapter.WorkdaySearch{Text: company.WorkdaySearchText, Facets: company.WorkdayFacets}
+		return adapter.NewWorkdayAdapter(company.WorkdayURL, company.Name, search, deps.httpClient, deps.jobFilter, deps.logger)
+	})
+	registerFetcher("microsoft", func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher {
+		return adapter.NewMicrosoftAdapter(company.Name, searchConfig(company), deps.httpClient)
+	})
+	registerFetcher("amazon", func(company config.CompanyConfig, deps fetcherDeps) model.JobFetcher {
+		return adapter.NewAmazonAdapter(company.Name, searchConfig(company), deps.httpClient)
+	})
+}
+
+// createFetcher builds the adapter for company's ATS. It reports false, and
+// logs a warning, for an ATS with no registered adapter.
+func createFetcher(company config.CompanyConfig, httpClient *http.Client, jobFilter model.JobFilter, logger *slog.Logger) (model.JobFetcher, bool) {
+	newFn, ok := fetcherTypes[company.ATS]
+	if !ok {
+		logger.Warn("unsupported ATS, skipping", "company", company.Name, "ats", company.ATS)
+		return nil, false
+	}
+	return newFn(company, fetcherDeps{httpClient: httpClient, jobFilter: jobFilter, logger: logger}), true
+}
+
+// searchConfig extracts the search-API query/location for a company.
+func searchConfig(company config.CompanyConfig) adapter.SearchConfig {
+	return adapter.SearchConfig{
+		Query:    company.SearchQuery,
+		Location: company.SearchLocation,
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/config"
+)
+
+// TestFetcherTypes_MatchBuiltInATSNames checks every built-in ATS resolves
+// to a constructor and the config schema lists every registered adapter.
+func TestFetcherTypes_MatchBuiltInATSNames(t *testing.T) {
+	for _, ats := range config.ATSNames {
+		if _, ok := fetcherTypes[ats]; !ok {
+			t.Errorf("ats %q has no registered adapter", ats)
+		}
+	}
+	for ats := range fetcherTypes {
+		if !slices.Contains(config.ATSNames, ats) {
+			t.Errorf("adapter registered for %q, which config.ATSNames doesn't list", ats)
+		}
+	}
+}
+
+func TestCreateFetcher_UnknownATS(t *testing.T) {
+	company := config.CompanyConfig{Name: "acme", ATS: "taleo"}
+	if f, ok := createFetcher(company, &http.Client{}, nil, slog.New(slog.NewTextHandler(io.Discard, nil))); ok || f != nil {
+		t.Errorf("createFetcher(taleo) = %v, %v, want nil, false", f, ok)
+	}
+}
@@ -8,12 +8,13 @@ import (
 	"os"
 	"time"
 
-	"github.com/amishk599/firstin/internal/adapter"
 	"github.com/amishk599/firstin/internal/audit"
 	"github.com/amishk599/firstin/internal/config"
 	"github.com/amishk599/firstin/internal/filter"
+	"github.com/amishk599/firstin/internal/httpclient"
 	"github.com/amishk599/firstin/internal/model"
 	"github.com/amishk599/firstin/internal/poller"
+	"github.com/amishk599/firstin/internal/store"
 	"github.com/spf13/cobra"
 )
 
@@ -24,24 +25,32 @@ var auditCmd = &cobra.Command{
 	RunE:  runAuditCmd,
 }
 
+// auditMaxAge, when set, limits the matched pane to jobs posted within it.
+var auditMaxAge time.Duration
+
 func init() {
+	auditCmd.Flags().DurationVar(&auditMaxAge, "max-age", 0, "only show matched jobs posted within this duration (e.g. 36h; 0 = no limit)")
 	rootCmd.AddCommand(auditCmd)
 }
 
 func runAuditCmd(cmd *cobra.Command, args []string) error {
 	logger := setupLogger(debug)
 
-	cfg, err := loadConfig(cfgPath)
+	cfg, err := loadConfig(cfgPaths)
 	if err != nil {
 		logger.Error("failed to load config", "error", err)
 		os.Exit(1)
 	}
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+	httpClient, err := httpclient.New(cfg.HTTP, version)
+	if err != nil {
+		logger.Error("failed to build http client", "error", err)
+		os.Exit(1)
+	}
 	// Use a discard logger for setupAnalyzer — audit mode runs a TUI and any
 	// log output before the alt-screen starts corrupts the display.
 	silentLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	analyzer := setupAnalyzer(cfg, silentLogger)
+	analyzer, _ := setupAnalyzer(cfg, silentLogger)
 	runAudit(cfg, httpClient, analyzer, logger)
 	return nil
 }
@@ -52,6 +61,25 @@ func runAudit(cfg *config.Config, httpClient *http.Client, analyzer poller.JobAn
 		return
 	}
 
+	bookmarks, err := audit.LoadBookmarks(audit.DefaultBookmarksPath)
+	if err != nil {
+		fmt.Printf("Bookmarks unavailable: %v\n", err)
+		bookmarks = nil
+	}
+
+	// Tag jobs NEW/SEEN against the daemon's store when there is one; don't
+	// create an empty jobs.db just to browse.
+	var jobStore model.JobStore
+	if _, err := os.Stat("jobs.db"); err == nil {
+		sqlStore, err := store.NewSQLiteStore("jobs.db")
+		if err != nil {
+			fmt.Printf("Store unavailable, NEW/SEEN tags disabled: %v\n", err)
+		} else {
+			defer sqlStore.Close()
+			jobStore = sqlStore
+		}
+	}
+
 	for {
 		choice, err := audit.RunCompanyPicker(cfg.Companies)
 		if err != nil {
@@ -70,11 +98,11 @@ func runAudit(cfg *config.Config, httpClient *http.Client, analyzer poller.JobAn
 		}
 		// In audit mode, adapters that support it should return all listings
 		// (not just fresh ones) so the full job board is visible.
-		if wa, ok := fetcher.(*adapter.WorkdayAdapter); ok {
-			wa.SetAuditMode(true)
+		if am, ok := fetcher.(model.AuditModeSetter); ok {
+			am.SetAuditMode(true)
 		}
-		if ma, ok := fetcher.(*adapter.MicrosoftAdapter); ok {
-			ma.SetAuditMode(true)
+		if pc, ok := fetcher.(model.AuditPageCapSetter); ok {
+			pc.SetAuditMaxPages(cfg.Audit.MaxPages)
 		}
 
 		jobs, err := audit.RunLoader(company.Name, fetcher.FetchJobs)
@@ -83,15 +111,18 @@ func runAudit(cfg *config.Config, httpClient *http.Client, analyzer poller.JobAn
 			continue
 		}
 
-		jobFilter := filter.NewTitleAndLocationFilter(
-			cfg.Filters.TitleKeywords,
-			cfg.Filters.TitleExcludeKeywords,
-			cfg.Filters.Locations,
-			cfg.Filters.ExcludeLocations,
-		)
+		// Tag the whole board, not just matches, since the TUI lists both.
+		if jobTagger := newTagger(cfg); jobTagger != nil {
+			for i := range jobs {
+				jobs[i].Tags = jobTagger.Tag(jobs[i])
+			}
+		}
+
+		jobFilter := newJobFilter(cfg)
+		ageFilter := filter.NewMaxAgeFilter(auditMaxAge)
 		var matched []model.Job
 		for _, j := range jobs {
-			if jobFilter.Match(j) {
+			if poller.KeepsPostingStatus(j, cfg.Filters.PostingStatuses) && jobFilter.Match(j) && ageFilter.Match(j) {
 				matched = append(matched, j)
 			}
 		}
@@ -101,7 +132,7 @@ func runAudit(cfg *config.Config, httpClient *http.Client, analyzer poller.JobAn
 			detailFetcher = df
 		}
 
-		wantQuit, err := audit.RunAuditTUI(jobs, matched, cfg.Filters, detailFetcher, analyzer)
+		wantQuit, err := audit.RunAuditTUI(jobs, matched, cfg.Filters, cfg.Display, detailFetcher, analyzer, bookmarks, jobStore)
 		if err != nil {
 			fmt.Printf("TUI error: %v\n", err)
 		}
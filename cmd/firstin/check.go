@@ -2,13 +2,11 @@ package main
 
 import (
 	"context"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
-	"github.com/amishk599/firstin/internal/filter"
+	"github.com/amishk599/firstin/internal/httpclient"
 	"github.com/amishk599/firstin/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -16,18 +14,21 @@ import (
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Poll once, print matches, exit",
-	Long:  "One-shot poll: fetches one company per ATS, prints matched jobs, exits. Does not write to the store.",
-	RunE:  runCheck,
+	Long: "One-shot poll: fetches one company per ATS, prints matched jobs, exits. Does not write to the store. " +
+		"With --replay, polls the replayed companies instead, so a recorded board reproduces a filter or poller bug.",
+	Example: "  firstin check --replay Acme=acme-board.json",
+	RunE:    runCheck,
 }
 
 func init() {
+	checkCmd.Flags().StringToStringVar(&replayFiles, "replay", nil, replayUsage)
 	rootCmd.AddCommand(checkCmd)
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
 	logger := setupLogger(debug)
 
-	cfg, err := loadConfig(cfgPath)
+	cfg, err := loadConfig(cfgPaths)
 	if err != nil {
 		logger.Error("failed to load config", "error", err)
 		os.Exit(1)
@@ -35,15 +36,14 @@ func runCheck(cmd *cobra.Command, args []string) error {
 
 	logger.Info("check mode: no jobs will be marked as seen")
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	jobFilter := filter.NewTitleAndLocationFilter(
-		cfg.Filters.TitleKeywords,
-		cfg.Filters.TitleExcludeKeywords,
-		cfg.Filters.Locations,
-		cfg.Filters.ExcludeLocations,
-	)
+	httpClient, err := httpclient.New(cfg.HTTP, version)
+	if err != nil {
+		logger.Error("failed to build http client", "error", err)
+		os.Exit(1)
+	}
+	jobFilter := newJobFilter(cfg)
 	n := setupNotifier(cfg, httpClient, logger)
-	analyzer := setupAnalyzer(cfg, logger)
+	analyzer, _ := setupAnalyzer(cfg, logger)
 	nopStore := store.NewNopStore()
 
 	pollers := buildPollers(cfg, jobFilter, nopStore, n, analyzer, httpClient, logger)
@@ -55,10 +55,14 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	// Poll only one company per ATS type
+	// Poll only one company per ATS type, or only the replayed companies
 	seen := make(map[string]bool)
 	for _, p := range pollers {
-		if seen[p.ATS] {
+		if len(replayFiles) > 0 {
+			if _, replay := replayPath(p.Name); !replay {
+				continue
+			}
+		} else if seen[p.ATS] {
 			logger.Info("skipping (ATS already tested)", "company", p.Name, "ats", p.ATS)
 			continue
 		}
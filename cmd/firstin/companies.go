@@ -2,45 +2,102 @@ package main
 
 import (
 	"fmt"
-	"os"
+	"sort"
 	"strings"
 
+	"github.com/amishk599/firstin/internal/config"
 	"github.com/spf13/cobra"
 )
 
 var companiesCmd = &cobra.Command{
 	Use:   "companies",
 	Short: "List all configured companies",
-	Long:  "Reads the config and prints a table of all configured companies.",
+	Long:  "Reads the config and prints a table of all configured companies, sorted by name like the audit picker.",
 	RunE:  runCompanies,
 }
 
+var (
+	companiesATS    string
+	companiesStatus string
+)
+
 func init() {
+	companiesCmd.Flags().StringVar(&companiesATS, "ats", "", "only list companies on this ATS (e.g. greenhouse, workday)")
+	companiesCmd.Flags().StringVar(&companiesStatus, "status", "", "only list companies with this status: enabled, muted or disabled")
 	rootCmd.AddCommand(companiesCmd)
 }
 
 func runCompanies(cmd *cobra.Command, args []string) error {
-	cfg, err := loadConfig(cfgPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
-		os.Exit(1)
+	switch companiesStatus {
+	case "", "enabled", "muted", "disabled":
+	default:
+		return fmt.Errorf("unknown status %q (valid: enabled, muted, disabled)", companiesStatus)
 	}
 
-	fmt.Printf("%-25s %-15s %s\n", "Company", "ATS", "Status")
-	fmt.Println(strings.Repeat("─", 47))
+	cfg, err := loadConfig(cfgPaths)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
 
-	enabled, disabled := 0, 0
+	companies := make([]config.CompanyConfig, 0, len(cfg.Companies))
 	for _, c := range cfg.Companies {
-		status := "enabled"
-		if !c.Enabled {
-			status = "disabled"
-			disabled++
-		} else {
+		if companiesATS != "" && !strings.EqualFold(c.ATS, companiesATS) {
+			continue
+		}
+		if companiesStatus != "" && companyStatus(c) != companiesStatus {
+			continue
+		}
+		companies = append(companies, c)
+	}
+	sort.Slice(companies, func(i, j int) bool {
+		return strings.ToLower(companies[i].Name) < strings.ToLower(companies[j].Name)
+	})
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "%-25s %-15s %-10s %s\n", "Company", "ATS", "Status", "Board")
+	fmt.Fprintln(w, strings.Repeat("─", 62))
+
+	enabled, disabled, muted := 0, 0, 0
+	for _, c := range companies {
+		status := companyStatus(c)
+		switch status {
+		case "enabled":
 			enabled++
+		case "muted":
+			muted++
+		default:
+			disabled++
 		}
-		fmt.Printf("%-25s %-15s %s\n", c.Name, c.ATS, status)
+		fmt.Fprintf(w, "%-25s %-15s %-10s %s\n", c.Name, c.ATS, status, boardSource(c))
 	}
 
-	fmt.Printf("\nTotal: %d companies (%d enabled, %d disabled)\n", len(cfg.Companies), enabled, disabled)
+	fmt.Fprintf(w, "\nTotal: %d companies (%d enabled, %d muted, %d disabled)\n", len(companies), enabled, muted, disabled)
 	return nil
 }
+
+// companyStatus reports how the daemon treats c: disabled wins over muted.
+func companyStatus(c config.CompanyConfig) string {
+	switch {
+	case !c.Enabled:
+		return "disabled"
+	case c.Muted:
+		return "muted"
+	default:
+		return "enabled"
+	}
+}
+
+// boardSource describes which board locator c sets. Search-API adapters
+// (microsoft, amazon) need none.
+func boardSource(c config.CompanyConfig) string {
+	switch {
+	case c.WorkdayURL != "":
+		return "workday_url"
+	case c.BoardToken != "":
+		return "board_token"
+	case c.ATS == "microsoft" || c.ATS == "amazon":
+		return "search api"
+	default:
+		return "missing"
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const companiesTestConfig = `
+polling_interval: 5m
+companies:
+  - name: zeta
+    ats: greenhouse
+    board_token: "zeta"
+    enabled: true
+  - name: Acme
+    ats: workday
+    workday_url: "https://acme.wd1.myworkdayjobs.com/wday/cxs/acme/careers"
+    enabled: true
+    muted: true
+  - name: microsoft
+    ats: microsoft
+    enabled: false
+`
+
+func runCompaniesWith(t *testing.T, ats, status string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(companiesTestConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prevPaths, prevATS, prevStatus := cfgPaths, companiesATS, companiesStatus
+	t.Cleanup(func() { cfgPaths, companiesATS, companiesStatus = prevPaths, prevATS, prevStatus })
+	cfgPaths, companiesATS, companiesStatus = []string{path}, ats, status
+
+	var out bytes.Buffer
+	companiesCmd.SetOut(&out)
+	t.Cleanup(func() { companiesCmd.SetOut(nil) })
+	if err := companiesCmd.RunE(companiesCmd, nil); err != nil {
+		t.Fatalf("runCompanies: %v", err)
+	}
+	return out.String()
+}
+
+func TestRunCompanies_ListsAllSortedWithState(t *testing.T) {
+	out := runCompaniesWith(t, "", "")
+
+	acme := strings.Index(out, "Acme")
+	ms := strings.Index(out, "microsoft")
+	zeta := strings.Index(out, "zeta")
+	if acme < 0 || ms < 0 || zeta < 0 || !(acme < ms && ms < zeta) {
+		t.Fatalf("want Acme, microsoft, zeta in name order, got:\n%s", out)
+	}
+	for _, want := range []string{
+		"muted      workday_url",
+		"disabled   search api",
+		"enabled    board_token",
+		"Total: 3 companies (1 enabled, 1 muted, 1 disabled)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunCompanies_Filters(t *testing.T) {
+	tests := []struct {
+		name, ats, status string
+		want, notWant     string
+	}{
+		{"by ats", "workday", "", "Acme", "zeta"},
+		{"by status", "", "enabled", "zeta", "Acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runCompaniesWith(t, tt.ats, tt.status)
+			if !strings.Contains(out, tt.want) || strings.Contains(out, tt.notWant) {
+				t.Errorf("want %q and not %q in:\n%s", tt.want, tt.notWant, out)
+			}
+			if !strings.Contains(out, "Total: 1 companies") {
+				t.Errorf("expected one company listed:\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "config-schema",
+	Short: "Print a JSON Schema for the config file",
+	Long: `Prints a JSON Schema describing config.yaml: every key, its type, the allowed
+notification types and ATS names, and the duration format. Point an editor's
+YAML language server at it for completion and validation, e.g.
+
+  firstin config-schema > firstin.schema.json
+  # yaml-language-server: $schema=./firstin.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := json.MarshalIndent(config.JSONSchema(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding schema: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configSchemaCmd)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export notified matches to CSV or JSON",
+	Long:  "Reads the matches recorded by the daemon from jobs.db and writes them as CSV or JSON, most recent first.",
+	RunE:  runExport,
+}
+
+var (
+	exportFormat string
+	exportOut    string
+)
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "output format: csv or json")
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "output file (default stdout)")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	var write func(io.Writer, []model.Job) error
+	switch exportFormat {
+	case "csv":
+		write = writeMatchesCSV
+	case "json":
+		write = writeMatchesJSON
+	default:
+		return fmt.Errorf("unknown format %q (valid: csv, json)", exportFormat)
+	}
+
+	sqlStore, err := store.NewSQLiteStore("jobs.db")
+	if err != nil {
+		return fmt.Errorf("opening store: %w", err)
+	}
+	defer sqlStore.Close()
+
+	jobs, err := sqlStore.Matches()
+	if err != nil {
+		return err
+	}
+	return exportMatches(exportOut, jobs, write)
+}
+
+// exportMatches writes jobs to path (stdout when empty) using write.
+func exportMatches(path string, jobs []model.Job, write func(io.Writer, []model.Job) error) error {
+	if path == "" {
+		return write(os.Stdout, jobs)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	if err := write(f, jobs); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+var exportColumns = []string{"company", "title", "location", "url", "posted_at", "first_seen"}
+
+// writeMatchesCSV writes a header row followed by one row per job. Times are
+// RFC 3339 in UTC; a missing posted_at is left blank.
+func writeMatchesCSV(w io.Writer, jobs []model.Job) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+	for _, j := range jobs {
+		postedAt := ""
+		if j.PostedAt != nil {
+			postedAt = j.PostedAt.UTC().Format(time.RFC3339)
+		}
+		row := []string{j.Company, j.Title, j.Location, j.URL, postedAt, j.FirstSeen.UTC().Format(time.RFC3339)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportedJob is the JSON shape of an exported match, mirroring the CSV columns.
+type exportedJob struct {
+	Company   string     `json:"company"`
+	Title     string     `json:"title"`
+	Location  string     `json:"location"`
+	URL       string     `json:"url"`
+	PostedAt  *time.Time `json:"posted_at"`
+	FirstSeen time.Time  `json:"first_seen"`
+}
+
+// writeMatchesJSON writes jobs as an indented JSON array.
+func writeMatchesJSON(w io.Writer, jobs []model.Job) error {
+	out := make([]exportedJob, len(jobs))
+	for i, j := range jobs {
+		out[i] = exportedJob{
+			Company:   j.Company,
+			Title:     j.Title,
+			Location:  j.Location,
+			URL:       j.URL,
+			PostedAt:  j.PostedAt,
+			FirstSeen: j.FirstSeen.UTC(),
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func exportFixture() []model.Job {
+	posted := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	seen := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return []model.Job{
+		{Company: "acme", Title: "Backend Engineer, Payments", Location: "Remote, US", URL: "https://acme.test/1", PostedAt: &posted, FirstSeen: seen},
+		{Company: "globex", Title: "SRE", Location: "NYC", URL: "https://globex.test/2", FirstSeen: seen},
+	}
+}
+
+func TestExportMatches_CSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.csv")
+	if err := exportMatches(path, exportFixture(), writeMatchesCSV); err != nil {
+		t.Fatalf("exportMatches: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("reading csv: %v", err)
+	}
+
+	if got := strings.Join(records[0], ","); got != "company,title,location,url,posted_at,first_seen" {
+		t.Errorf("header = %q", got)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d rows, want header + 2", len(records))
+	}
+	if records[1][1] != "Backend Engineer, Payments" {
+		t.Errorf("title = %q, want comma preserved", records[1][1])
+	}
+	if records[1][4] != "2026-03-01T09:30:00Z" {
+		t.Errorf("posted_at = %q", records[1][4])
+	}
+	if records[2][4] != "" {
+		t.Errorf("missing posted_at = %q, want blank", records[2][4])
+	}
+}
+
+func TestExportMatches_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	if err := exportMatches(path, exportFixture(), writeMatchesJSON); err != nil {
+		t.Fatalf("exportMatches: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []exportedJob
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(got))
+	}
+	if got[0].Company != "acme" || got[1].PostedAt != nil {
+		t.Errorf("unexpected export: %+v", got)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"text/template"
+
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/filter"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/notifier"
+)
+
+// notifierDeps are the shared dependencies every notifier constructor gets.
+type notifierDeps struct {
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// notifierConstructor builds the notifier for one notification config of
+// its type. Config validation has already checked the type's settings.
+type notifierConstructor func(nc config.NotificationConfig, deps notifierDeps) model.Notifier
+
+// notifierTypes maps notification.type to its constructor. Types add
+// themselves from init via registerNotifier.
+var notifierTypes = map[string]notifierConstructor{}
+
+// registerNotifier makes notification type typ build with newFn. Registering
+// a type twice is a programming error and panics.
+func registerNotifier(typ string, newFn notifierConstructor) {
+	if _, dup := notifierTypes[typ]; dup {
+		panic("notifier type registered twice: " + typ)
+	}
+	notifierTypes[typ] = newFn
+}
+
+func init() {
+	registerNotifier("log", newLogNotifier)
+	registerNotifier("slack", newSlackNotifier)
+	registerNotifier("matrix", newMatrixNotifier)
+	registerNotifier("ntfy", newNtfyNotifier)
+	registerNotifier("pagerduty", newPagerDutyNotifier)
+}
+
+// newNotifier builds the notifier for one notification config. An
+// unregistered type (including unset) logs jobs instead.
+func newNotifier(nc config.NotificationConfig, httpClient *http.Client, logger *slog.Logger) model.Notifier {
+	newFn, ok := notifierTypes[nc.Type]
+	if !ok {
+		newFn = newLogNotifier
+	}
+	return newFn(nc, notifierDeps{httpClient: httpClient, logger: logger})
+}
+
+func newLogNotifier(_ config.NotificationConfig, deps notifierDeps) model.Notifier {
+	return notifier.NewLogNotifier(deps.logger)
+}
+
+func newSlackNotifier(nc config.NotificationConfig, deps notifierDeps) model.Notifier {
+	logger := deps.logger
+	var tmpl *template.Template
+	if nc.SlackTemplate != "" {
+		t, err := notifier.LoadSlackTemplate(nc.SlackTemplate)
+		if err != nil {
+			logger.Warn("slack template unavailable, using built-in layout", "path", nc.SlackTemplate, "error", err)
+		} else {
+			tmpl = t
+		}
+	}
+	if nc.SlackThreaded {
+		logger.Info("using threaded slack notifier", "channel", nc.Channel)
+		n := notifier.NewSlackThreadedNotifier(nc.BotToken, nc.Channel, deps.httpClient, logger)
+		n.SetTemplate(tmpl)
+		n.SetInsightsFirst(nc.InsightsFirst)
+		if nc.MessageDelay != nil {
+			n.SetMessageDelay(*nc.MessageDelay)
+		}
+		return n
+	}
+	logger.Info("using slack notifier", "template", nc.SlackTemplate)
+	n := notifier.NewSlackNotifier(nc.WebhookURL, deps.httpClient, logger)
+	n.SetTemplate(tmpl)
+	n.SetInsightsFirst(nc.InsightsFirst)
+	if nc.MessageDelay != nil {
+		n.SetMessageDelay(*nc.MessageDelay)
+	}
+	return n
+}
+
+func newMatrixNotifier(nc config.NotificationConfig, deps notifierDeps) model.Notifier {
+	deps.logger.Info("using matrix notifier", "room_id", nc.RoomID)
+	return notifier.NewMatrixNotifier(nc.HomeserverURL, nc.RoomID, nc.AccessToken, deps.httpClient, deps.logger)
+}
+
+func newNtfyNotifier(nc config.NotificationConfig, deps notifierDeps) model.Notifier {
+	deps.logger.Info("using ntfy notifier")
+	return notifier.NewNtfyNotifier(nc.TopicURL, deps.httpClient, deps.logger)
+}
+
+func newPagerDutyNotifier(nc config.NotificationConfig, deps notifierDeps) model.Notifier {
+	deps.logger.Info("using pagerduty notifier", "priority_keywords", nc.PriorityKeywords)
+	priority := filter.NewTitleAndLocationFilter(nc.PriorityKeywords, nil, nil, nil)
+	n := notifier.NewPagerDutyNotifier(nc.RoutingKey, priority, deps.httpClient, deps.logger)
+	n.SetSeverity(nc.Severity)
+	return n
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/notifier"
+)
+
+type fakeNotifier struct {
+	nc config.NotificationConfig
+}
+
+func (f *fakeNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	return make([]bool, len(jobs)), nil
+}
+
+func TestSetupNotifier_UsesRegisteredType(t *testing.T) {
+	registerNotifier("fake", func(nc config.NotificationConfig, _ notifierDeps) model.Notifier {
+		return &fakeNotifier{nc: nc}
+	})
+	t.Cleanup(func() { delete(notifierTypes, "fake") })
+
+	cfg := &config.Config{Notification: config.NotificationConfig{Type: "fake", TopicURL: "https://example.com/t"}}
+	n := setupNotifier(cfg, &http.Client{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	fake, ok := n.(*fakeNotifier)
+	if !ok {
+		t.Fatalf("setupNotifier() = %T, want *fakeNotifier", n)
+	}
+	if fake.nc.TopicURL != "https://example.com/t" {
+		t.Errorf("constructor got config %+v, want the notification config", fake.nc)
+	}
+}
+
+func TestNewNotifier_UnknownTypeLogs(t *testing.T) {
+	n := newNotifier(config.NotificationConfig{Type: "carrier-pigeon"}, &http.Client{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if _, ok := n.(*notifier.LogNotifier); !ok {
+		t.Errorf("newNotifier() = %T, want *notifier.LogNotifier", n)
+	}
+}
+
+// TestNotifierTypes_SupportsSchemaTypes keeps the config schema's
+// notification.type enum in step with the registered notifiers.
+func TestNotifierTypes_SupportsSchemaTypes(t *testing.T) {
+	for _, typ := range config.NotificationTypes {
+		if _, ok := notifierTypes[typ]; !ok {
+			t.Errorf("notification type %q not registered, but the schema lists it", typ)
+		}
+	}
+}
@@ -1,11 +1,9 @@
 package main
 
 import (
-	"net/http"
 	"os"
-	"time"
 
-	"github.com/amishk599/firstin/internal/notifier"
+	"github.com/amishk599/firstin/internal/httpclient"
 	"github.com/spf13/cobra"
 )
 
@@ -21,27 +19,39 @@ var notifyTestCmd = &cobra.Command{
 	RunE:  runNotifyTest,
 }
 
+// testNotifyCmd is the top-level spelling of "notify test".
+var testNotifyCmd = &cobra.Command{
+	Use:   "test-notify",
+	Short: "Send a test notification through the configured notifier",
+	Long:  "Builds whichever notifier the config specifies (slack, matrix, ntfy, pagerduty, log) and sends a dummy job through it, reporting success or failure.",
+	RunE:  runNotifyTest,
+}
+
 func init() {
 	rootCmd.AddCommand(notifyCmd)
 	notifyCmd.AddCommand(notifyTestCmd)
+	rootCmd.AddCommand(testNotifyCmd)
 }
 
 func runNotifyTest(cmd *cobra.Command, args []string) error {
 	logger := setupLogger(debug)
 
-	cfg, err := loadConfig(cfgPath)
+	cfg, err := loadConfig(cfgPaths)
 	if err != nil {
 		logger.Error("failed to load config", "error", err)
 		os.Exit(1)
 	}
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+	httpClient, err := httpclient.New(cfg.HTTP, version)
+	if err != nil {
+		logger.Error("failed to build http client", "error", err)
+		os.Exit(1)
+	}
 	n := setupNotifier(cfg, httpClient, logger)
 
-	if err := notifier.SendTestMessage(n); err != nil {
-		logger.Error("test notification failed", "error", err)
+	logger.Info("sending test notification", "notifier", cfg.Notification.Type)
+	if err := checkNotifier(n, cmd.OutOrStdout()); err != nil {
 		os.Exit(1)
 	}
-	logger.Info("test notification sent successfully")
 	return nil
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// recordingNotifier records every job it is asked to deliver.
+type recordingNotifier struct {
+	jobs []model.Job
+	err  error
+}
+
+func (n *recordingNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	if n.err != nil {
+		return make([]bool, len(jobs)), n.err
+	}
+	n.jobs = append(n.jobs, jobs...)
+	delivered := make([]bool, len(jobs))
+	for i := range delivered {
+		delivered[i] = true
+	}
+	return delivered, nil
+}
+
+func TestCheckNotifier_DeliversDummyJob(t *testing.T) {
+	n := &recordingNotifier{}
+
+	var out bytes.Buffer
+	if err := checkNotifier(n, &out); err != nil {
+		t.Fatalf("checkNotifier: %v", err)
+	}
+	if len(n.jobs) != 1 || n.jobs[0].ID != "test-001" || n.jobs[0].Source != "test" {
+		t.Fatalf("delivered = %+v, want the single test-001 dummy job", n.jobs)
+	}
+	if !strings.Contains(out.String(), "notifier check ok") {
+		t.Errorf("output = %q, want success reported", out.String())
+	}
+}
+
+func TestCheckNotifier_ReportsFailure(t *testing.T) {
+	n := &recordingNotifier{err: errors.New("all 1 notifications failed")}
+
+	var out bytes.Buffer
+	if err := checkNotifier(n, &out); err == nil {
+		t.Fatal("expected an error when delivery fails")
+	}
+	if !strings.Contains(out.String(), "notifier check failed: all 1 notifications failed") {
+		t.Errorf("output = %q, want failure reported", out.String())
+	}
+}
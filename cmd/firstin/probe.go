@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/httpclient"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var probeCmd = &cobra.Command{
+	Use:   "probe",
+	Short: "Fetch one board once and report what it returns",
+	Long: "Fetches a board once, without filters or the store, and prints the job count, a sample job and what the adapter supports. " +
+		"Describe the board with --ats and --token (or --workday-url), or name a configured company with --company.",
+	Example: "  firstin probe --ats greenhouse --token acme\n  firstin probe --company Acme",
+	RunE:    runProbe,
+}
+
+var (
+	probeATS        string
+	probeToken      string
+	probeWorkdayURL string
+	probeCompany    string
+)
+
+func init() {
+	probeCmd.Flags().StringVar(&probeATS, "ats", "", "ATS of the board (e.g. greenhouse, workday)")
+	probeCmd.Flags().StringVar(&probeToken, "token", "", "board token (greenhouse, ashby, lever, gem)")
+	probeCmd.Flags().StringVar(&probeWorkdayURL, "workday-url", "", "workday board URL")
+	probeCmd.Flags().StringVar(&probeCompany, "company", "", "probe this configured company instead (case-insensitive)")
+	rootCmd.AddCommand(probeCmd)
+}
+
+func runProbe(cmd *cobra.Command, args []string) error {
+	logger := setupLogger(debug)
+
+	var company config.CompanyConfig
+	httpCfg := config.HTTPConfig{Timeout: 30 * time.Second, MaxResponseBytes: config.DefaultMaxResponseBytes}
+	if probeCompany != "" {
+		cfg, err := loadConfig(cfgPaths)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		found := false
+		for _, c := range cfg.Companies {
+			if strings.EqualFold(c.Name, probeCompany) {
+				company, found = c, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no company named %q in the config", probeCompany)
+		}
+		httpCfg = cfg.HTTP
+	} else {
+		if probeATS == "" {
+			return fmt.Errorf("--ats (with --token or --workday-url) or --company is required")
+		}
+		company = config.CompanyConfig{Name: probeToken, ATS: strings.ToLower(probeATS), BoardToken: probeToken, WorkdayURL: probeWorkdayURL}
+		if company.Name == "" {
+			company.Name = company.ATS
+		}
+	}
+
+	httpClient, err := httpclient.New(httpCfg, version)
+	if err != nil {
+		return fmt.Errorf("failed to build http client: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return probe(ctx, cmd.OutOrStdout(), company, httpClient, logger)
+}
+
+// probe runs company's adapter once and writes the job count, the first job
+// and the optional interfaces the adapter implements to w.
+func probe(ctx context.Context, w io.Writer, company config.CompanyConfig, httpClient *http.Client, logger *slog.Logger) error {
+	fetcher, ok := createFetcher(company, httpclient.WithRequestLogging(httpClient, logger), nil, logger)
+	if !ok {
+		return fmt.Errorf("unsupported ATS %q (valid: %s)", company.ATS, strings.Join(config.ATSNames, ", "))
+	}
+
+	start := time.Now()
+	jobs, err := fetcher.FetchJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching %s (%s): %w", company.Name, company.ATS, err)
+	}
+
+	fmt.Fprintf(w, "%s (%s): %d jobs in %s\n", company.Name, company.ATS, len(jobs), time.Since(start).Round(time.Millisecond))
+	if len(jobs) > 0 {
+		j := jobs[0]
+		posted := ""
+		if j.PostedAt != nil {
+			posted = j.PostedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintln(w, "\nSample job:")
+		for _, f := range [][2]string{
+			{"ID", j.ID},
+			{"Title", j.Title},
+			{"Location", j.Location},
+			{"URL", j.URL},
+			{"Posted", posted},
+		} {
+			if f[1] == "" {
+				f[1] = "(not provided)"
+			}
+			fmt.Fprintf(w, "  %-10s %s\n", f[0]+":", f[1])
+		}
+	}
+
+	_, detail := fetcher.(model.JobDetailFetcher)
+	_, audit := fetcher.(model.AuditModeSetter)
+	_, freshness := fetcher.(model.FreshnessCutoffSetter)
+	fmt.Fprintln(w, "\nCapabilities:")
+	fmt.Fprintf(w, "  %-18s %s\n", "detail fetch:", yesNo(detail))
+	fmt.Fprintf(w, "  %-18s %s\n", "audit mode:", yesNo(audit))
+	fmt.Fprintf(w, "  %-18s %s\n", "freshness cutoff:", yesNo(freshness))
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/config"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// redirectClient sends every request to srv, whatever host the adapter uses.
+func redirectClient(srv *httptest.Server) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme = "http"
+		req.URL.Host = srv.Listener.Addr().String()
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+}
+
+func TestProbe_ReportsCountSampleAndCapabilities(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/acme/jobs") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jobs": [
+			{"id": 1, "title": "Software Engineer", "location": {"name": "Remote"},
+			 "absolute_url": "https://boards.greenhouse.io/acme/jobs/1", "first_published": "2026-02-10T09:00:00Z"},
+			{"id": 2, "title": "Designer", "location": {"name": ""},
+			 "absolute_url": "https://boards.greenhouse.io/acme/jobs/2"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	company := config.CompanyConfig{Name: "acme", ATS: "greenhouse", BoardToken: "acme"}
+	if err := probe(context.Background(), &out, company, redirectClient(srv), slog.New(slog.NewTextHandler(io.Discard, nil))); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	for _, want := range []string{
+		"acme (greenhouse): 2 jobs",
+		"Title:     Software Engineer",
+		"Posted:    2026-02-10T09:00:00Z",
+		"detail fetch:      yes",
+		"audit mode:        no",
+		"freshness cutoff:  yes",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestProbe_FetchErrorAndUnknownATS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	err := probe(context.Background(), io.Discard, config.CompanyConfig{Name: "typo", ATS: "greenhouse", BoardToken: "typo"}, redirectClient(srv), logger)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("probe(404 board) error = %v, want the HTTP status", err)
+	}
+	err = probe(context.Background(), io.Discard, config.CompanyConfig{Name: "x", ATS: "taleo"}, redirectClient(srv), logger)
+	if err == nil || !strings.Contains(err.Error(), "unsupported ATS") {
+		t.Errorf("probe(taleo) error = %v, want unsupported ATS", err)
+	}
+}
@@ -4,23 +4,34 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/amishk599/firstin/internal/adapter"
 	"github.com/amishk599/firstin/internal/ai"
 	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/filter"
+	"github.com/amishk599/firstin/internal/httpclient"
 	"github.com/amishk599/firstin/internal/model"
 	"github.com/amishk599/firstin/internal/notifier"
 	"github.com/amishk599/firstin/internal/poller"
 	"github.com/amishk599/firstin/internal/retry"
+	"github.com/amishk599/firstin/internal/tagger"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfgPath string
-	debug   bool
+	cfgPaths []string
+	debug    bool
+	// replayFiles maps company names to recorded boards that buildPollers
+	// reads instead of the network (--replay on start and check).
+	replayFiles map[string]string
 )
 
+// replayUsage is the help text of the --replay flag.
+const replayUsage = "company=path.json pairs; fetch these companies from a recorded JSON array of jobs instead of their ATS"
+
 var rootCmd = &cobra.Command{
 	Use:   "firstin",
 	Short: "Job radar — be first in the door",
@@ -31,21 +42,21 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&cfgPath, "config", "c", "", "path to config file (default: FIRSTIN_CONFIG env var or ./config.yaml)")
+	rootCmd.PersistentFlags().StringSliceVarP(&cfgPaths, "config", "c", nil, "config file or directory; repeat or comma-separate to merge several in order (default: FIRSTIN_CONFIG env var or ./config.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
 }
 
-// loadConfig resolves the config path and parses it.
-// Priority: explicit path arg > FIRSTIN_CONFIG env var > "./config.yaml"
-func loadConfig(path string) (*config.Config, error) {
-	if path == "" {
+// loadConfig resolves the config paths and parses them, merged in order.
+// Priority: explicit paths > FIRSTIN_CONFIG env var (comma-separated) > "./config.yaml"
+func loadConfig(paths []string) (*config.Config, error) {
+	if len(paths) == 0 {
 		if env := os.Getenv("FIRSTIN_CONFIG"); env != "" {
-			path = env
+			paths = strings.Split(env, ",")
 		} else {
-			path = "config.yaml"
+			paths = []string{"config.yaml"}
 		}
 	}
-	return config.Load(path)
+	return config.Load(paths...)
 }
 
 func setupLogger(dbg bool) *slog.Logger {
@@ -56,53 +67,181 @@ func setupLogger(dbg bool) *slog.Logger {
 	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
 }
 
+// newJobFilter builds the match filter from cfg.Filters: title and location
+// keywords, plus the structured country and remote-only filters when configured.
+func newJobFilter(cfg *config.Config) model.JobFilter {
+	titleAndLocation := filter.NewTitleAndLocationFilter(
+		cfg.Filters.TitleKeywords,
+		cfg.Filters.TitleExcludeKeywords,
+		cfg.Filters.Locations,
+		cfg.Filters.ExcludeLocations,
+	)
+	titleAndLocation.SetTitleMatchAll(cfg.Filters.TitleMatchAll)
+	filters := filter.AllFilter{titleAndLocation}
+	if len(cfg.Filters.Countries) > 0 || len(cfg.Filters.ExcludeCountries) > 0 {
+		filters = append(filters, filter.NewCountryFilter(cfg.Filters.Countries, cfg.Filters.ExcludeCountries))
+	}
+	if cfg.Filters.RemoteOnly {
+		filters = append(filters, filter.RemoteFilter{})
+	}
+	if len(filters) == 1 {
+		return titleAndLocation
+	}
+	return filters
+}
+
+// newTagger builds the job tagger from cfg.Tags, or nil when no rules are
+// configured.
+func newTagger(cfg *config.Config) *tagger.Tagger {
+	if len(cfg.Tags) == 0 {
+		return nil
+	}
+	rules := make([]tagger.Rule, len(cfg.Tags))
+	for i, r := range cfg.Tags {
+		rules[i] = tagger.Rule{Tag: r.Tag, Keywords: r.Keywords}
+	}
+	return tagger.New(rules)
+}
+
+// setupNotifier builds the configured notifier, wrapped in a RoleRouter when
+// notification.role_routes sends some AI role types elsewhere.
 func setupNotifier(cfg *config.Config, httpClient *http.Client, logger *slog.Logger) model.Notifier {
-	switch cfg.Notification.Type {
-	case "slack":
-		logger.Info("using slack notifier")
-		return notifier.NewSlackNotifier(cfg.Notification.WebhookURL, httpClient, logger)
-	default:
-		return notifier.NewLogNotifier(logger)
+	n := newNotifier(cfg.Notification, httpClient, logger)
+	if len(cfg.Notification.RoleRoutes) == 0 {
+		return n
+	}
+	routes := make(map[string]model.Notifier, len(cfg.Notification.RoleRoutes))
+	for role, route := range cfg.Notification.RoleRoutes {
+		logger.Info("routing role type to its own notifier", "role_type", role, "type", route.Type)
+		routes[role] = newNotifier(route, httpClient, logger)
+	}
+	return notifier.NewRoleRouter(n, routes)
+}
+
+// withQuietHours wraps n to hold notifications during the configured quiet
+// hours. Only the daemon uses it: one-shot commands would exit with jobs
+// still held.
+func withQuietHours(cfg *config.Config, n model.Notifier, logger *slog.Logger) model.Notifier {
+	if !cfg.Notification.QuietHours.Enabled() {
+		return n
 	}
+	start, end, loc, err := cfg.Notification.QuietHours.Window()
+	if err != nil {
+		logger.Warn("quiet hours invalid, notifying immediately", "error", err)
+		return n
+	}
+	qc := cfg.Notification.QuietHours
+	q, err := notifier.NewQuietHoursNotifier(n, notifier.QuietHours{Start: start, End: end, Location: loc}, qc.BufferPath(), logger)
+	if err != nil {
+		logger.Warn("quiet hours unavailable, notifying immediately", "path", qc.BufferPath(), "error", err)
+		return n
+	}
+	if cfg.Notification.DeadLetterPath != "" {
+		q.SetDeadLetter(poller.NewDeadLetter(cfg.Notification.DeadLetterPath))
+	}
+	logger.Info("quiet hours enabled", "start", qc.Start, "end", qc.End, "timezone", loc.String(), "path", qc.BufferPath())
+	return q
 }
 
-func createFetcher(company config.CompanyConfig, httpClient *http.Client, jobFilter model.JobFilter, logger *slog.Logger) (model.JobFetcher, bool) {
-	switch company.ATS {
-	case "greenhouse":
-		return adapter.NewGreenhouseAdapter(company.BoardToken, company.Name, httpClient), true
-	case "ashby":
-		return adapter.NewAshbyAdapter(company.BoardToken, company.Name, httpClient), true
-	case "lever":
-		return adapter.NewLeverAdapter(company.BoardToken, company.Name, httpClient), true
-	case "gem":
-		return adapter.NewGemAdapter(company.BoardToken, company.Name, httpClient), true
-	case "workday":
-		return adapter.NewWorkdayAdapter(company.WorkdayURL, company.Name, httpClient, jobFilter, logger), true
-	case "microsoft":
-		return adapter.NewMicrosoftAdapter(company.Name, httpClient), true
-	default:
-		logger.Warn("unsupported ATS, skipping", "company", company.Name, "ats", company.ATS)
-		return nil, false
+// withDigest wraps n to collect notifications into the configured daily
+// digest. Like withQuietHours it is daemon-only. Wrapped around quiet hours,
+// a digest due inside them is held until they end.
+func withDigest(cfg *config.Config, n model.Notifier, logger *slog.Logger) model.Notifier {
+	dc := cfg.Notification.Digest
+	if !dc.Enabled() {
+		return n
+	}
+	days, at, loc, err := dc.Schedule()
+	if err != nil {
+		logger.Warn("digest invalid, notifying immediately", "error", err)
+		return n
 	}
+	d, err := notifier.NewDigestNotifier(n, notifier.DigestSchedule{At: at, Days: days, Location: loc}, dc.BufferPath(), logger)
+	if err != nil {
+		logger.Warn("digest unavailable, notifying immediately", "path", dc.BufferPath(), "error", err)
+		return n
+	}
+	logger.Info("digest enabled", "at", dc.At, "days", dc.Days, "timezone", loc.String(), "path", dc.BufferPath())
+	return d
+}
+
+// newRetryFetcher wraps fetcher with the retry policy configured for its ATS.
+func newRetryFetcher(fetcher model.JobFetcher, ats string, retryCfg config.RetryConfig, logger *slog.Logger) *retry.RetryFetcher {
+	policy := retryCfg.For(ats)
+	rf := retry.NewRetryFetcher(fetcher, policy.MaxRetries, policy.BaseDelay, logger)
+	rf.SetMaxRetryAfter(retryCfg.MaxRetryAfter)
+	return rf
 }
 
-func setupAnalyzer(cfg *config.Config, logger *slog.Logger) poller.JobAnalyzer {
+// setupAnalyzer returns the job analyzer and, when AI is enabled, the underlying
+// provider so callers can report its token usage. When AI is disabled the
+// analyzer is an *ai.NopJobAnalyzer, which reports itself disabled, and the
+// provider is nil.
+func setupAnalyzer(cfg *config.Config, logger *slog.Logger) (poller.JobAnalyzer, *ai.OpenAIProvider) {
 	if !cfg.AI.Enabled {
 		logger.Info("ai enrichment disabled")
-		return ai.NewNopJobAnalyzer()
+		return ai.NewNopJobAnalyzer(), nil
+	}
+	tmpl, err := ai.LoadPromptTemplate(cfg.AI.PromptTemplate)
+	if err != nil {
+		logger.Warn("custom prompt template unavailable, using built-in", "path", cfg.AI.PromptTemplate, "error", err)
+		tmpl = ai.JobAnalysisTemplate
 	}
 	client := &http.Client{Timeout: cfg.AI.Timeout}
-	provider := ai.NewOpenAIProvider(cfg.AI.BaseURL, cfg.AI.APIKey, cfg.AI.Model, client)
-	logger.Info("ai enrichment enabled", "model", cfg.AI.Model, "base_url", cfg.AI.BaseURL)
-	return ai.NewLLMJobAnalyzer(provider, ai.JobAnalysisTemplate, logger)
+	provider := ai.NewOpenAIProvider(cfg.AI.BaseURL, cfg.AI.APIKey, cfg.AI.Model, client, logger)
+	logger.Info("ai enrichment enabled", "model", cfg.AI.Model, "base_url", cfg.AI.BaseURL, "prompt_template", cfg.AI.PromptTemplate)
+	retrying := ai.NewRetryProvider(provider, cfg.AI.MaxRetries, 2*time.Second, logger)
+	analyzer := ai.NewLLMJobAnalyzer(retrying, tmpl, logger)
+	analyzer.SetMaxDescriptionChars(cfg.AI.MaxDescriptionChars)
+	return analyzer, provider
 }
 
 func buildPollers(cfg *config.Config, jobFilter model.JobFilter, jobStore model.JobStore, n model.Notifier, analyzer poller.JobAnalyzer, httpClient *http.Client, logger *slog.Logger) []*poller.CompanyPoller {
 	logger.Info("scheduler min_delay", "min_delay", cfg.RateLimit.MinDelay.String())
 
+	// Board requests are logged at debug level; the notifier's are not.
+	httpClient = httpclient.WithRequestLogging(httpClient, logger)
+
+	// One semaphore shared by every poller caps fetches across ATS groups.
+	var fetchSem chan struct{}
+	if cfg.RateLimit.MaxConcurrentFetches > 0 {
+		fetchSem = make(chan struct{}, cfg.RateLimit.MaxConcurrentFetches)
+	}
+
+	var deadLetter *poller.DeadLetter
+	if cfg.Notification.DeadLetterPath != "" {
+		deadLetter = poller.NewDeadLetter(cfg.Notification.DeadLetterPath)
+	}
+
+	// One dedup shared by every poller; a pass is one polling interval.
+	var crossDedup *poller.CrossCompanyDedup
+	if cfg.Filters.CrossCompanyDedup {
+		crossDedup = poller.NewCrossCompanyDedup(cfg.PollingInterval)
+		if cfg.Filters.CrossCompanyDedupPersist {
+			if keys, ok := jobStore.(model.DedupKeyStore); ok {
+				crossDedup.SetStore(keys)
+			} else {
+				logger.Warn("filters.cross_company_dedup_persist: store cannot keep dedup keys, deduplicating within a pass only")
+			}
+		}
+	}
+
+	if _, ok := jobStore.(model.DescriptionHashStore); cfg.Notification.NotifyOnChange && !ok {
+		logger.Warn("notification.notify_on_change needs a store that keeps description hashes; changes will not be detected")
+	}
+	if _, ok := jobStore.(model.OpenJobStore); cfg.Notification.NotifyOnClose && !ok {
+		logger.Warn("notification.notify_on_close needs a store that tracks open jobs; closed roles will not be detected")
+	}
+
+	freshnessBasis := poller.FreshnessBasis(cfg.Filters.FreshnessBasis)
+	jobTagger := newTagger(cfg)
+
 	var pollers []*poller.CompanyPoller
 	for _, company := range cfg.Companies {
-		if !company.Enabled {
+		if !company.Active() {
+			if company.Enabled && company.Muted {
+				logger.Info("company muted, skipping", "name", company.Name, "ats", company.ATS)
+			}
 			continue
 		}
 
@@ -110,11 +249,82 @@ func buildPollers(cfg *config.Config, jobFilter model.JobFilter, jobStore model.
 		if !ok {
 			continue
 		}
+		if path, replay := replayPath(company.Name); replay {
+			logger.Info("replaying recorded board", "name", company.Name, "path", path)
+			fetcher = adapter.NewFileFetcher(path, company.Name)
+		}
 
-		fetcher = retry.NewRetryFetcher(fetcher, 2, 5*time.Second, logger)
-		p := poller.NewCompanyPoller(company.Name, company.ATS, fetcher, jobFilter, jobStore, n, analyzer, cfg.Filters.MaxAge, logger)
+		// Closed roles are found by their absence from the board, which
+		// takes the whole board: adapters that stop paging at stale listings
+		// can't provide it, and the others must not drop stale jobs.
+		_, partialBoard := fetcher.(model.AuditModeSetter)
+		notifyOnClose := cfg.Notification.NotifyOnClose && !partialBoard
+		if cfg.Notification.NotifyOnClose && partialBoard {
+			logger.Info("notify_on_close not supported for this ats, skipping", "name", company.Name, "ats", company.ATS)
+		}
+
+		// Adapters that can drop stale jobs while mapping skip the work for
+		// listings the poller's freshness check would discard anyway. They
+		// go by posting date, so not when freshness counts from first seen.
+		maxAge := company.MaxAgeOr(cfg.Filters.MaxAge)
+		if fs, ok := fetcher.(model.FreshnessCutoffSetter); ok && freshnessBasis == poller.FreshnessPosted && !notifyOnClose {
+			fs.SetFreshnessCutoff(maxAge)
+		}
+
+		detailFetcher, _ := fetcher.(model.JobDetailFetcher)
+		fetcher = newRetryFetcher(fetcher, company.ATS, cfg.Retry, logger)
+		p := poller.NewCompanyPoller(company.Name, company.ATS, fetcher, jobFilter, jobStore, n, analyzer, maxAge, logger)
+		p.SetFreshnessBasis(freshnessBasis)
+		p.SetPostingStatuses(cfg.Filters.PostingStatuses)
+		if deadLetter != nil {
+			p.SetDeadLetter(deadLetter)
+		}
+		if crossDedup != nil {
+			p.SetCrossCompanyDedup(crossDedup)
+		}
+		if cfg.Notification.Enrich && detailFetcher != nil {
+			p.SetDetailFetcher(detailFetcher)
+		}
+		if cfg.Notification.NotifyOnChange {
+			p.SetNotifyOnChange(true, detailFetcher)
+			p.SetChangeChecks(cfg.Notification.ChangeChecksPerPoll)
+		}
+		if notifyOnClose {
+			p.SetNotifyOnClose(true)
+		}
+		if fetchSem != nil {
+			p.SetFetchSemaphore(fetchSem)
+		}
+		if jobTagger != nil {
+			p.SetTagger(jobTagger)
+		}
+		if len(cfg.Filters.AllowJobIDs) > 0 || len(cfg.Filters.BlockJobIDs) > 0 {
+			p.SetJobIDLists(cfg.Filters.AllowJobIDs, cfg.Filters.BlockJobIDs)
+		}
+		if cfg.Filters.MaxNewPerPoll > 0 {
+			p.SetMaxNewPerPoll(cfg.Filters.MaxNewPerPoll)
+		}
+		if len(cfg.Filters.Seniority) > 0 {
+			p.SetInsightsFilter(filter.NewSeniorityFilter(cfg.Filters.Seniority))
+		}
 		pollers = append(pollers, p)
 		logger.Info("registered company", "name", company.Name, "ats", company.ATS)
 	}
+	for name := range replayFiles {
+		if !slices.ContainsFunc(pollers, func(p *poller.CompanyPoller) bool { return strings.EqualFold(p.Name, name) }) {
+			logger.Warn("--replay: no active company with this name, ignored", "name", name)
+		}
+	}
 	return pollers
 }
+
+// replayPath returns the --replay file for the named company
+// (case-insensitive), if one was given.
+func replayPath(company string) (string, bool) {
+	for name, path := range replayFiles {
+		if strings.EqualFold(name, company) {
+			return path, true
+		}
+	}
+	return "", false
+}
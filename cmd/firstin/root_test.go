@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/ai"
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/filter"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/notifier"
+	"github.com/amishk599/firstin/internal/store"
+)
+
+func TestBuildPollers_SkipsMutedCompanies(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.Config{
+		Companies: []config.CompanyConfig{
+			{Name: "acme", ATS: "greenhouse", BoardToken: "acme", Enabled: true},
+			{Name: "noisy", ATS: "lever", BoardToken: "noisy", Enabled: true, Muted: true},
+			{Name: "off", ATS: "ashby", BoardToken: "off", Enabled: false},
+		},
+		Filters: config.FilterConfig{MaxAge: time.Hour},
+	}
+
+	pollers := buildPollers(cfg, filter.NewTitleAndLocationFilter(nil, nil, nil, nil), store.NewNopStore(),
+		notifier.NewLogNotifier(logger), ai.NewNopJobAnalyzer(), &http.Client{}, logger)
+
+	if len(pollers) != 1 || pollers[0].Name != "acme" {
+		var names []string
+		for _, p := range pollers {
+			names = append(names, p.Name)
+		}
+		t.Fatalf("pollers = %v, want [acme]", names)
+	}
+	if len(cfg.Companies) != 3 {
+		t.Errorf("config companies = %d, want 3 (muted company stays listed)", len(cfg.Companies))
+	}
+}
+
+func TestBuildPollers_ReplaySubstitutesFetcher(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	path := filepath.Join(t.TempDir(), "acme.json")
+	if err := os.WriteFile(path, []byte(`[{"ID": "1", "Title": "Engineer"}, {"ID": "2", "Title": "Designer"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	replayFiles = map[string]string{"ACME": path}
+	t.Cleanup(func() { replayFiles = nil })
+
+	cfg := &config.Config{
+		// An unreachable board: a poll only succeeds if the replay is used.
+		Companies: []config.CompanyConfig{{Name: "acme", ATS: "greenhouse", BoardToken: "acme", Enabled: true}},
+		Filters:   config.FilterConfig{MaxAge: time.Hour},
+	}
+	pollers := buildPollers(cfg, filter.NewTitleAndLocationFilter(nil, nil, nil, nil), store.NewNopStore(),
+		notifier.NewLogNotifier(logger), ai.NewNopJobAnalyzer(), &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			t.Error("replayed company made an HTTP request")
+			return nil, http.ErrServerClosed
+		})}, logger)
+	if len(pollers) != 1 {
+		t.Fatalf("got %d pollers, want 1", len(pollers))
+	}
+
+	if err := pollers[0].Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if got := pollers[0].LastFetchedCount(); got != 2 {
+		t.Errorf("fetched %d jobs, want the 2 replayed", got)
+	}
+}
+
+type failingFetcher struct{ calls int }
+
+func (f *failingFetcher) FetchJobs(_ context.Context) ([]model.Job, error) {
+	f.calls++
+	return nil, &model.HTTPError{StatusCode: 503}
+}
+
+func TestNewRetryFetcher_UsesPerATSPolicy(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	retryCfg := config.RetryConfig{
+		RetryPolicy: config.RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond},
+		ATSOverrides: map[string]config.RetryPolicy{
+			"workday": {MaxRetries: 4, BaseDelay: time.Millisecond},
+		},
+	}
+
+	tests := []struct {
+		ats       string
+		wantCalls int
+	}{
+		{"workday", 5},    // 1 + 4 retries
+		{"greenhouse", 2}, // 1 + default 1 retry
+	}
+	for _, tt := range tests {
+		f := &failingFetcher{}
+		newRetryFetcher(f, tt.ats, retryCfg, logger).FetchJobs(context.Background())
+		if f.calls != tt.wantCalls {
+			t.Errorf("%s: fetch calls = %d, want %d", tt.ats, f.calls, tt.wantCalls)
+		}
+	}
+}
+
+// TestCreateFetcher_SupportsSchemaATSNames keeps the config schema's ats enum
+// in step with the adapters createFetcher builds.
+func TestCreateFetcher_SupportsSchemaATSNames(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, ats := range config.ATSNames {
+		company := config.CompanyConfig{Name: "acme", ATS: ats, BoardToken: "acme", WorkdayURL: "https://acme.wd1.myworkdayjobs.com/External"}
+		if _, ok := createFetcher(company, &http.Client{}, filter.NewTitleAndLocationFilter(nil, nil, nil, nil), logger); !ok {
+			t.Errorf("createFetcher(%q) unsupported, but the schema lists it", ats)
+		}
+	}
+}
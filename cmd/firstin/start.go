@@ -2,15 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/amishk599/firstin/internal/filter"
+	"github.com/amishk599/firstin/internal/ai"
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/httpclient"
+	"github.com/amishk599/firstin/internal/metrics"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/notifier"
 	"github.com/amishk599/firstin/internal/scheduler"
+	"github.com/amishk599/firstin/internal/server"
 	"github.com/amishk599/firstin/internal/store"
+	"github.com/amishk599/firstin/internal/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -21,19 +31,33 @@ var startCmd = &cobra.Command{
 	RunE:  runStart,
 }
 
+var (
+	// muteCompanies lists companies to pause for this run without editing config.
+	muteCompanies []string
+	// noSeed disables first-run seeding so an empty store notifies immediately.
+	noSeed bool
+)
+
 func init() {
+	startCmd.Flags().StringSliceVar(&muteCompanies, "mute", nil, "comma-separated company names to skip polling this run (e.g. --mute stripe,airbnb)")
+	startCmd.Flags().BoolVar(&noSeed, "no-seed", false, "on an empty store, notify for fresh matches instead of silently seeding them")
+	startCmd.Flags().StringToStringVar(&replayFiles, "replay", nil, replayUsage)
 	rootCmd.AddCommand(startCmd)
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
 	logger := setupLogger(debug)
 
-	cfg, err := loadConfig(cfgPath)
+	cfg, err := loadConfig(cfgPaths)
 	if err != nil {
 		logger.Error("failed to load config", "error", err)
 		os.Exit(1)
 	}
 
+	if unknown := cfg.Mute(muteCompanies); len(unknown) > 0 {
+		logger.Warn("--mute: unknown companies ignored", "names", unknown)
+	}
+
 	logger.Info("config loaded",
 		"interval", cfg.PollingInterval.String(),
 		"companies", len(cfg.Companies),
@@ -42,38 +66,191 @@ func runStart(cmd *cobra.Command, args []string) error {
 		"max_age", cfg.Filters.MaxAge.String(),
 	)
 
-	sqlStore, err := store.NewSQLiteStore("jobs.db")
+	jobStore, closeStore, err := openStore(cfg.Store)
 	if err != nil {
 		logger.Error("failed to open store", "error", err)
 		os.Exit(1)
 	}
-	defer sqlStore.Close()
+	defer closeStore()
+	if cfg.Store.Type == "memory" {
+		logger.Info("using in-memory store; seen jobs are forgotten on exit")
+	}
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	jobFilter := filter.NewTitleAndLocationFilter(
-		cfg.Filters.TitleKeywords,
-		cfg.Filters.TitleExcludeKeywords,
-		cfg.Filters.Locations,
-		cfg.Filters.ExcludeLocations,
-	)
+	httpClient, err := httpclient.New(cfg.HTTP, version)
+	if err != nil {
+		logger.Error("failed to build http client", "error", err)
+		os.Exit(1)
+	}
+	jobFilter := newJobFilter(cfg)
 	n := setupNotifier(cfg, httpClient, logger)
-	analyzer := setupAnalyzer(cfg, logger)
+	// Tested before quiet hours or the digest could hold the test message.
+	if err := testNotifierOnStart(cfg.Notification, n, logger); err != nil {
+		logger.Error("startup test notification failed, exiting", "error", err)
+		os.Exit(1)
+	}
+	n = withDigest(cfg, withQuietHours(cfg, n, logger), logger)
+	analyzer, aiProvider := setupAnalyzer(cfg, logger)
 
-	pollers := buildPollers(cfg, jobFilter, sqlStore, n, analyzer, httpClient, logger)
+	pollers := buildPollers(cfg, jobFilter, jobStore, n, analyzer, httpClient, logger)
 	if len(pollers) == 0 {
 		logger.Error("no companies to poll")
 		os.Exit(1)
 	}
+	alertLag := metrics.NewHistogram("firstin_alert_lag_seconds", "Seconds from a job's posting to its alert.", metrics.AlertLagBuckets)
+	for _, p := range pollers {
+		p.SetAlertLagObserver(alertLag)
+	}
+	if noSeed {
+		logger.Info("first-run seeding disabled (--no-seed)")
+		for _, p := range pollers {
+			p.SetSeedOnFirstRun(false)
+		}
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if aiProvider != nil {
+		go logAIUsage(ctx, aiProvider, cfg.AI, logger)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, version)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	if cfg.Tracing.Enabled {
+		logger.Info("tracing enabled", "endpoint", cfg.Tracing.Endpoint)
+	}
+	defer func() {
+		// ctx is already cancelled on exit; give the exporter its own deadline to flush.
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Warn("tracing shutdown failed", "error", err)
+		}
+	}()
+
 	sched := scheduler.NewScheduler(pollers, cfg.PollingInterval, cfg.RateLimit.MinDelay, cfg.RateLimit.ATSOverrides, logger)
+	sched.SetDrainTimeout(cfg.ShutdownTimeout)
+	sched.SetPollTimeout(cfg.PollTimeout)
+	sched.SetIntervalOverrides(cfg.RateLimit.IntervalOverrides)
+	sched.SetStartupStagger(cfg.RateLimit.StartupStagger)
+	sched.SetMaxPerATSConcurrency(cfg.RateLimit.MaxPerATSConcurrency)
+	sched.SetActiveWindows(activeWindows(cfg, logger))
+	for _, p := range pollers {
+		p.SetRequestPacer(sched)
+	}
+	for _, ats := range cfg.DetailPacingConflicts() {
+		logger.Warn("poll_timeout does not exceed the ATS min_delay; enrich and notify_on_change detail requests will be skipped",
+			"ats", ats,
+			"min_delay", cfg.RateLimit.MinDelayFor(ats).String(),
+			"poll_timeout", cfg.PollTimeout.String(),
+		)
+	}
+
+	if cfg.Server.Addr != "" {
+		deleter, _ := jobStore.(model.CompanyDeleter)
+		srv := server.NewServer(cfg.Server.Addr, sched, deleter, alertLag, buildInfo(), logger)
+		go func() {
+			if err := srv.Run(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("control server error", "error", err)
+			}
+		}()
+	}
+
 	if err := sched.Run(ctx); err != nil {
 		logger.Error("scheduler error", "error", err)
 		os.Exit(1)
 	}
 
+	if aiProvider != nil {
+		reportAIUsage(aiProvider, cfg.AI, logger)
+	}
 	logger.Info("goodbye")
 	return nil
 }
+
+// testNotifierOnStart sends a test message through n when nc.TestOnStart is
+// set. A failed delivery is logged as an error, and returned only with
+// TestOnStartFailFast.
+func testNotifierOnStart(nc config.NotificationConfig, n model.Notifier, logger *slog.Logger) error {
+	if !nc.TestOnStart {
+		return nil
+	}
+	if err := notifier.SendTestMessage(n); err != nil {
+		if nc.TestOnStartFailFast {
+			return err
+		}
+		logger.Error("startup test notification failed; alerts may not be delivered", "notifier", nc.Type, "error", err)
+		return nil
+	}
+	logger.Info("startup test notification sent", "notifier", nc.Type)
+	return nil
+}
+
+// aiUsageLogInterval is how often the daemon logs cumulative AI token usage.
+const aiUsageLogInterval = time.Hour
+
+// logAIUsage logs the provider's cumulative token usage every aiUsageLogInterval
+// until ctx is cancelled.
+func logAIUsage(ctx context.Context, provider *ai.OpenAIProvider, aiCfg config.AIConfig, logger *slog.Logger) {
+	ticker := time.NewTicker(aiUsageLogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reportAIUsage(provider, aiCfg, logger)
+		}
+	}
+}
+
+// reportAIUsage logs cumulative token usage, plus an estimated cost when
+// ai.input_cost_per_1m / ai.output_cost_per_1m are configured.
+func reportAIUsage(provider *ai.OpenAIProvider, aiCfg config.AIConfig, logger *slog.Logger) {
+	u := provider.Usage()
+	args := []any{
+		"calls", u.Calls,
+		"prompt_tokens", u.PromptTokens,
+		"completion_tokens", u.CompletionTokens,
+		"total_tokens", u.TotalTokens,
+	}
+	if aiCfg.InputCostPer1M > 0 || aiCfg.OutputCostPer1M > 0 {
+		args = append(args, "estimated_cost_usd", fmt.Sprintf("%.4f", u.EstimatedCost(aiCfg.InputCostPer1M, aiCfg.OutputCostPer1M)))
+	}
+	logger.Info("ai usage total", args...)
+}
+
+// openStore opens the job store selected by store.type. The returned func
+// releases it.
+func openStore(cfg config.StoreConfig) (model.JobStore, func() error, error) {
+	if cfg.Type == "memory" {
+		return store.NewInMemoryStore(), func() error { return nil }, nil
+	}
+	sqlStore, err := store.NewSQLiteStore("jobs.db")
+	if err != nil {
+		return nil, nil, err
+	}
+	return sqlStore, sqlStore.Close, nil
+}
+
+// activeWindows returns the scheduler windows of companies that configure
+// active_window, keyed by name.
+func activeWindows(cfg *config.Config, logger *slog.Logger) map[string]scheduler.ActiveWindow {
+	windows := make(map[string]scheduler.ActiveWindow)
+	for _, c := range cfg.Companies {
+		if !c.Active() || !c.ActiveWindow.Enabled() {
+			continue
+		}
+		days, start, end, loc, err := c.ActiveWindow.Window()
+		if err != nil {
+			logger.Warn("active window invalid, polling around the clock", "company", c.Name, "error", err)
+			continue
+		}
+		windows[c.Name] = scheduler.ActiveWindow{Days: days, Start: start, End: end, Location: loc}
+		logger.Info("active window set", "company", c.Name, "days", c.ActiveWindow.Days, "start", c.ActiveWindow.Start, "end", c.ActiveWindow.End, "timezone", loc.String())
+	}
+	return windows
+}
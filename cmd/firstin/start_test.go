@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/config"
+)
+
+func TestTestNotifierOnStart(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		name      string
+		nc        config.NotificationConfig
+		notifyErr error
+		wantSent  int
+		wantErr   bool
+	}{
+		{name: "disabled", nc: config.NotificationConfig{}, wantSent: 0},
+		{name: "enabled", nc: config.NotificationConfig{TestOnStart: true}, wantSent: 1},
+		{name: "failure logged", nc: config.NotificationConfig{TestOnStart: true}, notifyErr: errors.New("webhook 404")},
+		{name: "failure fails fast", nc: config.NotificationConfig{TestOnStart: true, TestOnStartFailFast: true}, notifyErr: errors.New("webhook 404"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &recordingNotifier{err: tt.notifyErr}
+			err := testNotifierOnStart(tt.nc, n, logger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(n.jobs) != tt.wantSent {
+				t.Fatalf("sent %d jobs, want %d", len(n.jobs), tt.wantSent)
+			}
+			if tt.wantSent > 0 && n.jobs[0].ID != "test-001" {
+				t.Errorf("sent %+v, want the test-001 dummy job", n.jobs[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amishk599/firstin/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the seen-jobs store",
+	Long:  "Reads jobs.db and prints how many jobs have been seen, broken down by source, with the oldest and newest first_seen.",
+	RunE:  runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	sqlStore, err := openExistingStore("jobs.db")
+	if err != nil {
+		return err
+	}
+	defer sqlStore.Close()
+
+	return printStats(os.Stdout, sqlStore)
+}
+
+// openExistingStore opens the SQLite store at path, reporting "no store
+// found" rather than creating an empty one when the file doesn't exist.
+func openExistingStore(path string) (*store.SQLiteStore, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no store found at %s; run firstin start to create one", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("opening store: %w", err)
+	}
+	sqlStore, err := store.NewSQLiteStore(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening store: %w", err)
+	}
+	return sqlStore, nil
+}
+
+// printStats writes the store summary to w. Sources are listed by count,
+// largest first; rows seen before sources were tracked show as "unknown".
+func printStats(w io.Writer, s *store.SQLiteStore) error {
+	total, err := s.TotalSeen()
+	if err != nil {
+		return err
+	}
+	bySource, err := s.CountBySource()
+	if err != nil {
+		return err
+	}
+	oldest, err := s.OldestSeen()
+	if err != nil {
+		return err
+	}
+	newest, err := s.NewestSeen()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Seen jobs: %d\n", total)
+	if total == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "Oldest:    %s\n", oldest.Local().Format(time.DateTime))
+	fmt.Fprintf(w, "Newest:    %s\n\n", newest.Local().Format(time.DateTime))
+
+	sources := make([]string, 0, len(bySource))
+	for src := range bySource {
+		sources = append(sources, src)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if bySource[sources[i]] != bySource[sources[j]] {
+			return bySource[sources[i]] > bySource[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+
+	fmt.Fprintf(w, "%-15s %s\n", "Source", "Seen")
+	fmt.Fprintln(w, strings.Repeat("─", 22))
+	for _, src := range sources {
+		name := src
+		if name == "" {
+			name = "unknown"
+		}
+		fmt.Fprintf(w, "%-15s %d\n", name, bySource[src])
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/store"
+)
+
+func TestOpenExistingStore(t *testing.T) {
+	dir := t.TempDir()
+
+	missing := filepath.Join(dir, "missing.db")
+	if _, err := openExistingStore(missing); err == nil || !strings.Contains(err.Error(), "no store found") {
+		t.Errorf("openExistingStore(missing) error = %v, want no store found", err)
+	}
+	if _, err := os.Stat(missing); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stat after open = %v, want the store left uncreated", err)
+	}
+
+	path := filepath.Join(dir, "jobs.db")
+	created, err := store.NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	created.Close()
+	s, err := openExistingStore(path)
+	if err != nil {
+		t.Fatalf("openExistingStore(existing) = %v, want nil", err)
+	}
+	s.Close()
+}
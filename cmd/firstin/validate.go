@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/amishk599/firstin/internal/httpclient"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/notifier"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the config file",
+	Long: "Loads and validates the config without polling. With --check-notifier, also sends a " +
+		"test notification so a revoked webhook or bad token is caught before the first real alert.",
+	RunE: runValidate,
+}
+
+// validateCheckNotifier enables the network check of the configured notifier.
+var validateCheckNotifier bool
+
+func init() {
+	validateCmd.Flags().BoolVar(&validateCheckNotifier, "check-notifier", false, "send a test notification through the configured notifier")
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(cfgPaths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config invalid: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("config ok: %d companies, notifier %q\n", len(cfg.Companies), cfg.Notification.Type)
+
+	if !validateCheckNotifier {
+		return nil
+	}
+	httpClient, err := httpclient.New(cfg.HTTP, version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config invalid: %v\n", err)
+		os.Exit(1)
+	}
+	n := setupNotifier(cfg, httpClient, setupLogger(debug))
+	if err := checkNotifier(n, os.Stdout); err != nil {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// checkNotifier sends a test message through n and reports the outcome to w.
+func checkNotifier(n model.Notifier, w io.Writer) error {
+	if err := notifier.SendTestMessage(n); err != nil {
+		fmt.Fprintf(w, "notifier check failed: %v\n", err)
+		return err
+	}
+	fmt.Fprintln(w, "notifier check ok: test message delivered")
+	return nil
+}
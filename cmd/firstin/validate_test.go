@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/notifier"
+)
+
+func TestCheckNotifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+		wantOut string
+	}{
+		{"webhook ok", http.StatusOK, false, "notifier check ok"},
+		{"webhook revoked", http.StatusInternalServerError, true, "notifier check failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			n := notifier.NewSlackNotifier(srv.URL, srv.Client(), logger)
+
+			var out bytes.Buffer
+			err := checkNotifier(n, &out)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkNotifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("output = %q, want it to contain %q", out.String(), tt.wantOut)
+			}
+		})
+	}
+}
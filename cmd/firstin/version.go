@@ -2,20 +2,45 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"strings"
 
+	"github.com/amishk599/firstin/internal/server"
 	"github.com/spf13/cobra"
 )
 
-var version = "dev"
+// Build metadata, injected at build time:
+//
+//	go build -ldflags "-X main.version=v1.2.3 -X main.commit=$(git rev-parse HEAD) -X main.date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version info",
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("firstin %s\n", version)
+		printVersion(cmd.OutOrStdout(), buildInfo())
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
+	// --version on the root prints the same block as the version command.
+	var b strings.Builder
+	printVersion(&b, buildInfo())
+	rootCmd.Version = version
+	rootCmd.SetVersionTemplate(b.String())
+}
+
+// buildInfo returns the build metadata reported by "version" and the control
+// server's /version route.
+func buildInfo() server.BuildInfo {
+	return server.BuildInfo{Version: version, Commit: commit, Date: date}
+}
+
+func printVersion(w io.Writer, info server.BuildInfo) {
+	fmt.Fprintf(w, "firstin %s\n  commit: %s\n  built:  %s\n", info.Version, info.Commit, info.Date)
 }
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestVersionCommand_PrintsBuildInfo(t *testing.T) {
+	oldVersion, oldCommit, oldDate := version, commit, date
+	t.Cleanup(func() { version, commit, date = oldVersion, oldCommit, oldDate })
+	version, commit, date = "v1.2.3", "abc123", "2026-03-01T09:30:00Z"
+
+	var out bytes.Buffer
+	versionCmd.SetOut(&out)
+	t.Cleanup(func() { versionCmd.SetOut(nil) })
+	versionCmd.Run(versionCmd, nil)
+
+	for _, want := range []string{"firstin v1.2.3", "commit: abc123", "built:  2026-03-01T09:30:00Z"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+	"github.com/amishk599/firstin/internal/retry"
+)
+
+const (
+	amazonBaseURL       = "https://www.amazon.jobs"
+	amazonPageSize      = 10
+	amazonCutoffDefault = 24 * time.Hour
+	amazonAuditMaxPages = 20 // default audit mode cap: 200 jobs (20 pages × 10)
+	amazonDateLayout    = "January 2, 2006"
+)
+
+// amazonJob represents a single job in the Amazon search API response.
+type amazonJob struct {
+	IDIcims            string `json:"id_icims"`
+	Title              string `json:"title"`
+	NormalizedLocation string `json:"normalized_location"`
+	CountryCode        string `json:"country_code"` // ISO 3166 alpha-3, e.g. "USA"
+	PostedDate         string `json:"posted_date"`  // e.g. "March 5, 2026"
+	JobPath            string `json:"job_path"`
+	Description        string `json:"description"`
+}
+
+// amazonSearchResponse is the top-level Amazon search API response.
+type amazonSearchResponse struct {
+	Hits int         `json:"hits"`
+	Jobs []amazonJob `json:"jobs"`
+}
+
+// AmazonAdapter fetches jobs from the Amazon jobs search API.
+type AmazonAdapter struct {
+	companyName string
+	search      SearchConfig
+	client      *http.Client
+	cutoff      time.Duration    // freshness window; pagination stops past it
+	auditMode   bool             // when true: return all listings regardless of freshness
+	auditPages  int              // page cap in audit mode
+	now         func() time.Time // clock for the freshness cutoff
+}
+
+// NewAmazonAdapter creates a new adapter for Amazon jobs. Empty search fields
+// default to "software engineer" in "United States".
+func NewAmazonAdapter(companyName string, search SearchConfig, client *http.Client) *AmazonAdapter {
+	return &AmazonAdapter{
+		companyName: companyName,
+		search:      search.withDefaults(),
+		client:      client,
+		cutoff:      amazonCutoffDefault,
+		auditPages:  amazonAuditMaxPages,
+		now:         time.Now,
+	}
+}
+
+// SetFreshnessCutoff sets the freshness window to the poller's max_age so
+// pagination stops as soon as a page holds nothing the poller would keep.
+// Zero restores the 24h default.
+func (a *AmazonAdapter) SetFreshnessCutoff(maxAge time.Duration) {
+	if maxAge <= 0 {
+		maxAge = amazonCutoffDefault
+	}
+	a.cutoff = maxAge
+}
+
+// SetAuditMode enables audit mode: all listings are returned regardless of freshness.
+func (a *AmazonAdapter) SetAuditMode(enabled bool) {
+	a.auditMode = enabled
+}
+
+// SetAuditMaxPages sets how many pages audit mode fetches. Zero or less
+// restores the default of 20 pages.
+func (a *AmazonAdapter) SetAuditMaxPages(maxPages int) {
+	if maxPages <= 0 {
+		maxPages = amazonAuditMaxPages
+	}
+	a.auditPages = maxPages
+}
+
+// FetchJobs retrieves jobs from Amazon jobs and normalizes them into the
+// unified Job model. In normal mode only jobs posted within the freshness
+// window (24h unless set via SetFreshnessCutoff) are returned. In audit mode
+// all listings are returned regardless of freshness.
+func (a *AmazonAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
+	results, err := a.fetchAllJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := a.now().UTC().Add(-a.cutoff)
+	jobs := make([]model.Job, 0, len(results))
+	for _, r := range results {
+		postedAt := parseAmazonPostedDate(r.PostedDate)
+		if postedAt == nil {
+			continue
+		}
+		if !a.auditMode && !amazonIsFresh(*postedAt, cutoff) {
+			continue
+		}
+		jobs = append(jobs, a.jobFromResult(r, *postedAt))
+	}
+
+	return jobs, nil
+}
+
+// fetchAllJobs paginates the Amazon search API, stopping early once a full
+// page contains no jobs posted within the freshness window.
+func (a *AmazonAdapter) fetchAllJobs(ctx context.Context) ([]amazonJob, error) {
+	cutoff := a.now().UTC().Add(-a.cutoff)
+	pager := searchPaginator[amazonJob]{
+		pageSize:      amazonPageSize,
+		auditMaxPages: a.auditPages,
+		auditMode:     a.auditMode,
+		fetchPage: func(ctx context.Context, offset int) ([]amazonJob, int, int, error) {
+			results, hits, err := a.fetchPage(ctx, offset)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			fresh := 0
+			for _, r := range results {
+				if t := parseAmazonPostedDate(r.PostedDate); t != nil && amazonIsFresh(*t, cutoff) {
+					fresh++
+				}
+			}
+			return results, hits, fresh, nil
+		},
+	}
+	return pager.fetchAll(ctx)
+}
+
+// fetchPage fetches a single page of search results at the given offset.
+func (a *AmazonAdapter) fetchPage(ctx context.Context, offset int) ([]amazonJob, int, error) {
+	u, _ := url.Parse(amazonBaseURL + "/en/search.json")
+	q := u.Query()
+	q.Set("base_query", a.search.Query)
+	q.Set("loc_query", a.search.Location)
+	q.Set("result_limit", fmt.Sprintf("%d", amazonPageSize))
+	q.Set("offset", fmt.Sprintf("%d", offset))
+	q.Set("sort", "recent")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("amazon fetch page (offset=%d): %w", offset, err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("amazon fetch page (offset=%d): %w", offset, &model.NetworkError{Err: err})
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, &model.HTTPError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
+			Err:        fmt.Errorf("amazon fetch page (offset=%d): unexpected status %d", offset, resp.StatusCode),
+		}
+	}
+
+	var azResp amazonSearchResponse
+	if err := decodeJSON(resp.Body, &azResp); err != nil {
+		return nil, 0, fmt.Errorf("amazon fetch page (offset=%d): %w", offset, err)
+	}
+
+	return azResp.Jobs, azResp.Hits, nil
+}
+
+// jobFromResult normalizes an amazonJob into the unified Job model. The search
+// response already carries the description, so Detail is populated up front.
+func (a *AmazonAdapter) jobFromResult(r amazonJob, postedAt time.Time) model.Job {
+	detail := &model.JobDetail{PublishedAt: &postedAt}
+	if r.Description != "" {
+		detail.Description = extractText(r.Description)
+	}
+
+	return model.Job{
+		ID:                 r.IDIcims,
+		Company:            a.companyName,
+		Title:              r.Title,
+		Location:           r.NormalizedLocation,
+		NormalizedLocation: normalize.NormalizeLocation(r.NormalizedLocation),
+		URL:                amazonBaseURL + r.JobPath,
+		PostedAt:           &postedAt,
+		Country:            normalize.NormalizeCountry(r.CountryCode),
+		IsRemote:           normalize.IsRemote(r.NormalizedLocation),
+		Source:             "amazon",
+		Detail:             detail,
+	}
+}
+
+// parseAmazonPostedDate parses Amazon's day-precision posted_date ("March 5, 2026")
+// as midnight UTC. Returns nil if the value is empty or unparseable.
+func parseAmazonPostedDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(amazonDateLayout, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// amazonIsFresh reports whether a day-precision posting date may fall within
+// the cutoff. The whole posting day is considered, so a job dated yesterday is
+// kept until the end of that day plus the cutoff window.
+func amazonIsFresh(postedDay, cutoff time.Time) bool {
+	return postedDay.Add(24 * time.Hour).After(cutoff)
+}
@@ -0,0 +1,221 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// freshAmazonDate returns today's date in Amazon's posted_date format.
+func freshAmazonDate() string {
+	return time.Now().UTC().Format(amazonDateLayout)
+}
+
+// staleAmazonDate returns a date a week ago in Amazon's posted_date format.
+func staleAmazonDate() string {
+	return time.Now().UTC().Add(-7 * 24 * time.Hour).Format(amazonDateLayout)
+}
+
+func TestAmazonAdapter_FetchJobs_Success(t *testing.T) {
+	searchPayload := map[string]any{
+		"hits": 3,
+		"jobs": []map[string]any{
+			{
+				"id_icims":            "2901234",
+				"title":               "Software Development Engineer II",
+				"normalized_location": "Seattle, WA, USA",
+				"posted_date":         freshAmazonDate(),
+				"job_path":            "/en/jobs/2901234/software-development-engineer-ii",
+				"description":         "<p>Build distributed systems.</p>",
+			},
+			{
+				"id_icims":            "2901235",
+				"title":               "Backend Engineer",
+				"normalized_location": "Austin, TX, USA",
+				"posted_date":         freshAmazonDate(),
+				"job_path":            "/en/jobs/2901235/backend-engineer",
+			},
+			{
+				"id_icims":            "1000001",
+				"title":               "Old Role",
+				"normalized_location": "Seattle, WA, USA",
+				"posted_date":         staleAmazonDate(),
+				"job_path":            "/en/jobs/1000001/old-role",
+			},
+		},
+	}
+
+	srv := newAmazonTestServer(t, searchPayload)
+	defer srv.Close()
+
+	a := newAmazonTestAdapter(srv, "Amazon")
+	jobs, err := a.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 fresh jobs, got %d", len(jobs))
+	}
+
+	j := jobs[0]
+	if j.ID != "2901234" {
+		t.Errorf("expected ID 2901234, got %s", j.ID)
+	}
+	if j.Company != "Amazon" {
+		t.Errorf("expected company Amazon, got %s", j.Company)
+	}
+	if j.Title != "Software Development Engineer II" {
+		t.Errorf("unexpected title: %s", j.Title)
+	}
+	if j.Location != "Seattle, WA, USA" {
+		t.Errorf("unexpected location: %s", j.Location)
+	}
+	if j.URL != amazonBaseURL+"/en/jobs/2901234/software-development-engineer-ii" {
+		t.Errorf("unexpected URL: %s", j.URL)
+	}
+	if j.Source != "amazon" {
+		t.Errorf("expected source 'amazon', got %s", j.Source)
+	}
+	if j.PostedAt == nil {
+		t.Fatal("expected PostedAt to be set")
+	}
+	if j.Detail == nil || j.Detail.Description != "Build distributed systems." {
+		t.Errorf("expected description from search result, got %+v", j.Detail)
+	}
+}
+
+func TestAmazonAdapter_FetchJobs_PaginationEarlyExit(t *testing.T) {
+	pageRequests := 0
+
+	// All jobs are stale — adapter should stop after the first page.
+	searchPayload := map[string]any{
+		"hits": 50, // signals more pages exist
+		"jobs": []map[string]any{
+			{
+				"id_icims":            "111",
+				"title":               "Old Engineer",
+				"normalized_location": "Seattle, WA, USA",
+				"posted_date":         staleAmazonDate(),
+				"job_path":            "/en/jobs/111/old-engineer",
+			},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/en/search.json" {
+			pageRequests++
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(searchPayload)
+		}
+	}))
+	defer srv.Close()
+
+	a := newAmazonTestAdapter(srv, "Amazon")
+	jobs, err := a.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("expected 0 jobs (all stale), got %d", len(jobs))
+	}
+	if pageRequests != 1 {
+		t.Errorf("expected exactly 1 page request (early exit), got %d", pageRequests)
+	}
+}
+
+// TestAmazonAdapter_FreshnessAtMidnight pins the day-precision cutoff just
+// after midnight UTC, when a job posted "yesterday" may still be within the
+// window.
+func TestAmazonAdapter_FreshnessAtMidnight(t *testing.T) {
+	now := time.Date(2025, 3, 11, 0, 30, 0, 0, time.UTC)
+	day := func(d int) string { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC).Format(amazonDateLayout) }
+	searchPayload := map[string]any{
+		"hits": 3,
+		"jobs": []map[string]any{
+			{"id_icims": "today", "title": "Engineer", "posted_date": day(11), "job_path": "/en/jobs/today"},
+			{"id_icims": "yesterday", "title": "Engineer", "posted_date": day(10), "job_path": "/en/jobs/yesterday"},
+			{"id_icims": "older", "title": "Engineer", "posted_date": day(9), "job_path": "/en/jobs/older"},
+		},
+	}
+	srv := newAmazonTestServer(t, searchPayload)
+	defer srv.Close()
+
+	a := newAmazonTestAdapter(srv, "Amazon")
+	a.SetFreshnessCutoff(time.Hour)
+	a.now = func() time.Time { return now }
+	jobs, err := a.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []string
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	if len(ids) != 2 || ids[0] != "today" || ids[1] != "yesterday" {
+		t.Errorf("jobs = %v, want [today yesterday] (yesterday's day ends 30m ago, inside 1h)", ids)
+	}
+}
+
+func TestAmazonAdapter_FetchJobs_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	a := newAmazonTestAdapter(srv, "Amazon")
+	_, err := a.FetchJobs(context.Background())
+	if err == nil {
+		t.Fatal("expected error for HTTP 500, got nil")
+	}
+}
+
+// --- helpers ---
+
+// newAmazonTestServer creates a test server that serves payload for the search endpoint.
+func newAmazonTestServer(t *testing.T, payload any) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/en/search.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(payload)
+	}))
+}
+
+// newAmazonTestAdapter creates an AmazonAdapter wired to a test server.
+func newAmazonTestAdapter(srv *httptest.Server, company string) *AmazonAdapter {
+	a := NewAmazonAdapter(company, SearchConfig{}, srv.Client())
+	a.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			req.URL.Scheme = "http"
+			req.URL.Host = srv.Listener.Addr().String()
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+	return a
+}
+
+func TestAmazonAdapter_SearchConfigInRequest(t *testing.T) {
+	var gotQuery, gotLocation string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("base_query")
+		gotLocation = r.URL.Query().Get("loc_query")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hits":0,"jobs":[]}`))
+	}))
+	defer srv.Close()
+
+	a := newAmazonTestAdapter(srv, "Amazon")
+	a.search = SearchConfig{Query: "applied scientist", Location: "Berlin, Germany"}
+	if _, err := a.FetchJobs(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "applied scientist" || gotLocation != "Berlin, Germany" {
+		t.Errorf("base_query/loc_query = %q/%q, want applied scientist/Berlin, Germany", gotQuery, gotLocation)
+	}
+}
@@ -2,12 +2,14 @@ package adapter
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+	"github.com/amishk599/firstin/internal/retry"
 )
 
 const ashbyBaseURL = "https://api.ashbyhq.com/posting-api/job-board"
@@ -21,6 +23,8 @@ type ashbyJob struct {
 	IsListed         bool   `json:"isListed"`
 	DescriptionPlain string `json:"descriptionPlain"`
 	DescriptionHtml  string `json:"descriptionHtml"`
+	IsRemote         bool   `json:"isRemote"`
+	WorkplaceType    string `json:"workplaceType"` // "OnSite", "Remote" or "Hybrid"
 }
 
 // ashbyResponse is the top-level Ashby job board API response.
@@ -56,36 +60,38 @@ func (a *AshbyAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("ashby fetch for %s: %w", a.boardToken, err)
+		return nil, fmt.Errorf("ashby fetch for %s: %w", a.boardToken, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("ashby fetch for %s: unexpected status %d", a.boardToken, resp.StatusCode),
 		}
 	}
 
 	var ashbyResp ashbyResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ashbyResp); err != nil {
+	if err := decodeJSON(resp.Body, &ashbyResp); err != nil {
 		return nil, fmt.Errorf("ashby fetch for %s: %w", a.boardToken, err)
 	}
 
 	jobs := make([]model.Job, 0, len(ashbyResp.Jobs))
 	for _, aj := range ashbyResp.Jobs {
-		if !aj.IsListed {
-			continue
-		}
-
 		job := model.Job{
-			ID:      aj.JobUrl,
-			Company: a.companyName,
-			Title:   aj.Title,
-			Location: aj.Location,
-			URL:     aj.JobUrl,
-			Source:  "ashby",
+			ID:                 aj.JobUrl,
+			Company:            a.companyName,
+			Title:              aj.Title,
+			Location:           aj.Location,
+			NormalizedLocation: normalize.NormalizeLocation(aj.Location),
+			URL:                aj.JobUrl,
+			IsRemote:           aj.IsRemote || strings.EqualFold(aj.WorkplaceType, "remote") || normalize.IsRemote(aj.Location),
+			PostingStatus:      model.PostingOpen,
+			Source:             "ashby",
+		}
+		if !aj.IsListed {
+			job.PostingStatus = model.PostingUnlisted
 		}
 
 		if aj.PublishedAt != "" {
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
 )
 
 func TestAshbyFetchJobs_Success(t *testing.T) {
@@ -48,8 +50,13 @@ func TestAshbyFetchJobs_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(jobs) != 2 {
-		t.Fatalf("expected 2 jobs (unlisted filtered), got %d", len(jobs))
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+	for i, want := range []string{model.PostingOpen, model.PostingOpen, model.PostingUnlisted} {
+		if jobs[i].PostingStatus != want {
+			t.Errorf("jobs[%d].PostingStatus = %q, want %q", i, jobs[i].PostingStatus, want)
+		}
 	}
 
 	// Verify first job
@@ -89,6 +96,30 @@ func TestAshbyFetchJobs_Success(t *testing.T) {
 	}
 }
 
+func TestAshbyFetchJobs_RemoteSignal(t *testing.T) {
+	payload := `{"jobs": [
+		{"title": "A", "location": "New York, NY", "jobUrl": "a", "isListed": true, "isRemote": true},
+		{"title": "B", "location": "New York, NY", "jobUrl": "b", "isListed": true, "workplaceType": "Remote"},
+		{"title": "C", "location": "New York, NY", "jobUrl": "c", "isListed": true, "workplaceType": "OnSite"},
+		{"title": "D", "location": "Distributed", "jobUrl": "d", "isListed": true}
+	]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	jobs, err := newAshbyTestAdapter(srv, "acme", "Acme").FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]bool{"a": true, "b": true, "c": false, "d": true}
+	for _, j := range jobs {
+		if j.IsRemote != want[j.ID] {
+			t.Errorf("job %s IsRemote = %v, want %v", j.ID, j.IsRemote, want[j.ID])
+		}
+	}
+}
+
 func TestAshbyFetchJobs_EmptyBoard(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,36 @@
+package adapter
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// decodeJSON decodes r into v and classifies a failure for retry logic: a
+// malformed or mistyped document is a *model.DecodeError, while a body that
+// could not be read to the end is a *model.NetworkError. An oversized body
+// is returned as model.ErrResponseTooLarge unchanged.
+func decodeJSON(r io.Reader, v any) error {
+	err := json.NewDecoder(r).Decode(v)
+	if err == nil {
+		return nil
+	}
+	return classifyDecodeError(err)
+}
+
+func classifyDecodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, model.ErrResponseTooLarge):
+		return err
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr), errors.Is(err, io.EOF):
+		// io.EOF means an empty body: the server answered, with nothing.
+		return &model.DecodeError{Err: err}
+	default:
+		// Read errors, including io.ErrUnexpectedEOF from a body cut short.
+		return &model.NetworkError{Err: err}
+	}
+}
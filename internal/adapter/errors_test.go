@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// failingReader returns its data, then err instead of io.EOF.
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+	return n, err
+}
+
+func TestDecodeJSON_ClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        io.Reader
+		wantDecode  bool
+		wantNetwork bool
+		wantTooBig  bool
+	}{
+		{name: "valid", body: strings.NewReader(`{"jobs":[]}`)},
+		{name: "syntax error", body: strings.NewReader(`<html>oops</html>`), wantDecode: true},
+		{name: "type mismatch", body: strings.NewReader(`{"jobs":"none"}`), wantDecode: true},
+		{name: "empty body", body: strings.NewReader(``), wantDecode: true},
+		{name: "truncated body", body: strings.NewReader(`{"jobs":[`), wantNetwork: true},
+		{name: "connection reset", body: &failingReader{strings.NewReader(`{"jobs":`), errors.New("connection reset by peer")}, wantNetwork: true},
+		{name: "oversized body", body: &failingReader{strings.NewReader(`{"jobs":`), model.ErrResponseTooLarge}, wantTooBig: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v struct {
+				Jobs []string `json:"jobs"`
+			}
+			err := decodeJSON(tt.body, &v)
+			var decodeErr *model.DecodeError
+			var netErr *model.NetworkError
+			if got := errors.As(err, &decodeErr); got != tt.wantDecode {
+				t.Errorf("DecodeError = %v, want %v (err %v)", got, tt.wantDecode, err)
+			}
+			if got := errors.As(err, &netErr); got != tt.wantNetwork {
+				t.Errorf("NetworkError = %v, want %v (err %v)", got, tt.wantNetwork, err)
+			}
+			if got := errors.Is(err, model.ErrResponseTooLarge); got != tt.wantTooBig {
+				t.Errorf("ErrResponseTooLarge = %v, want %v (err %v)", got, tt.wantTooBig, err)
+			}
+			if !tt.wantDecode && !tt.wantNetwork && !tt.wantTooBig && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFetchJobs_UnreachableIsNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	adapter := newLeverTestAdapter(srv, "gone-co", "Gone Co")
+	srv.Close()
+
+	_, err := adapter.FetchJobs(context.Background())
+	var netErr *model.NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("expected *model.NetworkError for a closed server, got %v", err)
+	}
+}
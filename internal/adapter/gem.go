@@ -2,12 +2,13 @@ package adapter
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+	"github.com/amishk599/firstin/internal/retry"
 )
 
 const gemBaseURL = "https://api.gem.com/job_board/v0"
@@ -55,32 +56,34 @@ func (a *GemAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("gem fetch for %s: %w", a.boardToken, err)
+		return nil, fmt.Errorf("gem fetch for %s: %w", a.boardToken, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("gem fetch for %s: unexpected status %d", a.boardToken, resp.StatusCode),
 		}
 	}
 
 	var gemJobs []gemJob
-	if err := json.NewDecoder(resp.Body).Decode(&gemJobs); err != nil {
+	if err := decodeJSON(resp.Body, &gemJobs); err != nil {
 		return nil, fmt.Errorf("gem fetch for %s: %w", a.boardToken, err)
 	}
 
 	jobs := make([]model.Job, 0, len(gemJobs))
 	for _, gj := range gemJobs {
 		job := model.Job{
-			ID:       gj.ID,
-			Company:  a.companyName,
-			Title:    gj.Title,
-			Location: gj.Location.Name,
-			URL:      gj.AbsoluteURL,
-			Source:   "gem",
+			ID:                 gj.ID,
+			Company:            a.companyName,
+			Title:              gj.Title,
+			Location:           gj.Location.Name,
+			NormalizedLocation: normalize.NormalizeLocation(gj.Location.Name),
+			URL:                gj.AbsoluteURL,
+			IsRemote:           normalize.IsRemote(gj.Location.Name),
+			Source:             "gem",
 		}
 
 		if gj.FirstPublished != "" {
@@ -2,12 +2,13 @@ package adapter
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+	"github.com/amishk599/firstin/internal/retry"
 )
 
 const greenhouseBaseURL = "https://boards-api.greenhouse.io/v1/boards"
@@ -31,18 +32,31 @@ type greenhouseResponse struct {
 	Jobs []greenhouseJob `json:"jobs"`
 }
 
+// greenhouseDepartmentsResponse is the Greenhouse departments API response:
+// every department on the board, each with its own jobs nested inside.
+type greenhouseDepartmentsResponse struct {
+	Departments []greenhouseDepartment `json:"departments"`
+}
+
+type greenhouseDepartment struct {
+	ID       int64           `json:"id"`
+	Name     string          `json:"name"`
+	ChildIDs []int64         `json:"child_ids"`
+	Jobs     []greenhouseJob `json:"jobs"`
+}
+
 // greenhouseJobDetail is the response from the Greenhouse job detail endpoint.
 type greenhouseJobDetail struct {
-	ID              int64                `json:"id"`
-	Title           string               `json:"title"`
-	UpdatedAt       string               `json:"updated_at"`
-	FirstPublished  string               `json:"first_published"`
-	RequisitionID   string               `json:"requisition_id"`
-	Location        greenhouseLocation   `json:"location"`
-	Content         string               `json:"content"`
-	AbsoluteURL     string               `json:"absolute_url"`
-	InternalJobID   int64                `json:"internal_job_id"`
-	PayInputRanges  []greenhousePayRange `json:"pay_input_ranges"`
+	ID             int64                `json:"id"`
+	Title          string               `json:"title"`
+	UpdatedAt      string               `json:"updated_at"`
+	FirstPublished string               `json:"first_published"`
+	RequisitionID  string               `json:"requisition_id"`
+	Location       greenhouseLocation   `json:"location"`
+	Content        string               `json:"content"`
+	AbsoluteURL    string               `json:"absolute_url"`
+	InternalJobID  int64                `json:"internal_job_id"`
+	PayInputRanges []greenhousePayRange `json:"pay_input_ranges"`
 }
 
 type greenhousePayRange struct {
@@ -55,9 +69,12 @@ type greenhousePayRange struct {
 
 // GreenhouseAdapter fetches jobs from the Greenhouse public boards API.
 type GreenhouseAdapter struct {
-	boardToken  string
-	companyName string
-	client      *http.Client
+	boardToken   string
+	companyName  string
+	client       *http.Client
+	maxAge       time.Duration    // freshness cutoff; zero returns every listing
+	departmentID int64            // when set: fetch only this department's jobs
+	now          func() time.Time // clock for the freshness cutoff
 }
 
 // NewGreenhouseAdapter creates a new adapter for a Greenhouse board.
@@ -66,66 +83,145 @@ func NewGreenhouseAdapter(boardToken string, companyName string, client *http.Cl
 		boardToken:  boardToken,
 		companyName: companyName,
 		client:      client,
+		now:         time.Now,
 	}
 }
 
-// FetchJobs retrieves all jobs from the Greenhouse board and normalizes them
-// into the unified Job model.
+// SetFreshnessCutoff makes FetchJobs skip jobs first published more than maxAge
+// ago, so large boards don't materialize listings the poller would drop anyway.
+func (a *GreenhouseAdapter) SetFreshnessCutoff(maxAge time.Duration) {
+	a.maxAge = maxAge
+}
+
+// SetDepartment restricts FetchJobs to one department (and its
+// sub-departments) via the departments endpoint, for boards too large to
+// pull whole when only e.g. Engineering matters. Zero fetches the whole board.
+func (a *GreenhouseAdapter) SetDepartment(id int64) {
+	a.departmentID = id
+}
+
+// FetchJobs retrieves all jobs from the Greenhouse board, or from the
+// configured department, and normalizes them into the unified Job model. When
+// a freshness cutoff is set, stale jobs are skipped.
 func (a *GreenhouseAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
-	url := fmt.Sprintf("%s/%s/jobs", greenhouseBaseURL, a.boardToken)
+	var listed []greenhouseJob
+	if a.departmentID != 0 {
+		var depResp greenhouseDepartmentsResponse
+		if err := a.getList(ctx, fmt.Sprintf("%s/%s/departments", greenhouseBaseURL, a.boardToken), &depResp); err != nil {
+			return nil, err
+		}
+		var err error
+		if listed, err = departmentJobs(depResp.Departments, a.departmentID); err != nil {
+			return nil, fmt.Errorf("greenhouse fetch for %s: %w", a.boardToken, err)
+		}
+	} else {
+		var ghResp greenhouseResponse
+		if err := a.getList(ctx, fmt.Sprintf("%s/%s/jobs", greenhouseBaseURL, a.boardToken), &ghResp); err != nil {
+			return nil, err
+		}
+		listed = ghResp.Jobs
+	}
+
+	var cutoff time.Time
+	if a.maxAge > 0 {
+		cutoff = a.now().UTC().Add(-a.maxAge)
+	}
+
+	jobs := make([]model.Job, 0, len(listed))
+	for _, gj := range listed {
+		// Use first_published (not updated_at) as the freshness signal.
+		// updated_at changes on any record mutation (bulk edits, compliance
+		// updates, syncs) and must not be treated as a publication timestamp.
+		var postedAt *time.Time
+		if gj.FirstPublished != "" {
+			if t, err := time.Parse(time.RFC3339, gj.FirstPublished); err == nil {
+				postedAt = &t
+			}
+		}
+		if postedAt != nil && !cutoff.IsZero() && postedAt.Before(cutoff) {
+			continue
+		}
+
+		job := model.Job{
+			ID:                 fmt.Sprintf("%d", gj.ID),
+			Company:            a.companyName,
+			Title:              gj.Title,
+			Location:           gj.Location.Name,
+			NormalizedLocation: normalize.NormalizeLocation(gj.Location.Name),
+			URL:                gj.AbsoluteURL,
+			PostedAt:           postedAt,
+			IsRemote:           normalize.IsRemote(gj.Location.Name),
+			Source:             "greenhouse",
+		}
+		if gj.UpdatedAt != "" {
+			if t, err := time.Parse(time.RFC3339, gj.UpdatedAt); err == nil {
+				job.Detail = &model.JobDetail{UpdatedAt: &t}
+			}
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
 
+// getList GETs a board listing endpoint and decodes the JSON response into v.
+func (a *GreenhouseAdapter) getList(ctx context.Context, url string, v any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("greenhouse fetch for %s: %w", a.boardToken, err)
+		return fmt.Errorf("greenhouse fetch for %s: %w", a.boardToken, err)
 	}
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("greenhouse fetch for %s: %w", a.boardToken, err)
+		return fmt.Errorf("greenhouse fetch for %s: %w", a.boardToken, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &model.HTTPError{
+		return &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("greenhouse fetch for %s: unexpected status %d", a.boardToken, resp.StatusCode),
 		}
 	}
 
-	var ghResp greenhouseResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ghResp); err != nil {
-		return nil, fmt.Errorf("greenhouse fetch for %s: %w", a.boardToken, err)
+	if err := decodeJSON(resp.Body, v); err != nil {
+		return fmt.Errorf("greenhouse fetch for %s: %w", a.boardToken, err)
 	}
+	return nil
+}
 
-	jobs := make([]model.Job, 0, len(ghResp.Jobs))
-	for _, gj := range ghResp.Jobs {
-		job := model.Job{
-			ID:       fmt.Sprintf("%d", gj.ID),
-			Company:  a.companyName,
-			Title:    gj.Title,
-			Location: gj.Location.Name,
-			URL:      gj.AbsoluteURL,
-			Source:   "greenhouse",
-		}
+// departmentJobs returns the jobs of department id and of its sub-departments,
+// each job once.
+func departmentJobs(departments []greenhouseDepartment, id int64) ([]greenhouseJob, error) {
+	byID := make(map[int64]greenhouseDepartment, len(departments))
+	for _, d := range departments {
+		byID[d.ID] = d
+	}
+	if _, ok := byID[id]; !ok {
+		return nil, fmt.Errorf("department %d not found", id)
+	}
 
-		// Use first_published (not updated_at) as the freshness signal.
-		// updated_at changes on any record mutation (bulk edits, compliance
-		// updates, syncs) and must not be treated as a publication timestamp.
-		if gj.FirstPublished != "" {
-			if t, err := time.Parse(time.RFC3339, gj.FirstPublished); err == nil {
-				job.PostedAt = &t
-			}
+	var jobs []greenhouseJob
+	seenJob := make(map[int64]bool)
+	visited := make(map[int64]bool)
+	queue := []int64{id}
+	for len(queue) > 0 {
+		d, ok := byID[queue[0]]
+		queue = queue[1:]
+		if !ok || visited[d.ID] {
+			continue
 		}
-		if gj.UpdatedAt != "" {
-			if t, err := time.Parse(time.RFC3339, gj.UpdatedAt); err == nil {
-				job.Detail = &model.JobDetail{UpdatedAt: &t}
+		visited[d.ID] = true
+		for _, j := range d.Jobs {
+			if !seenJob[j.ID] {
+				seenJob[j.ID] = true
+				jobs = append(jobs, j)
 			}
 		}
-
-		jobs = append(jobs, job)
+		queue = append(queue, d.ChildIDs...)
 	}
-
 	return jobs, nil
 }
 
@@ -140,21 +236,21 @@ func (a *GreenhouseAdapter) fetchDetail(ctx context.Context, jobID int64) (green
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return greenhouseJobDetail{}, fmt.Errorf("greenhouse detail fetch for %s job %d: %w", a.companyName, jobID, err)
+		return greenhouseJobDetail{}, fmt.Errorf("greenhouse detail fetch for %s job %d: %w", a.companyName, jobID, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return greenhouseJobDetail{}, &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("greenhouse detail fetch for %s job %d: unexpected status %d", a.companyName, jobID, resp.StatusCode),
 		}
 	}
 
 	var detail greenhouseJobDetail
-	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
-		return greenhouseJobDetail{}, fmt.Errorf("greenhouse detail decode for %s job %d: %w", a.companyName, jobID, err)
+	if err := decodeJSON(resp.Body, &detail); err != nil {
+		return greenhouseJobDetail{}, fmt.Errorf("greenhouse detail fetch for %s job %d: %w", a.companyName, jobID, err)
 	}
 
 	return detail, nil
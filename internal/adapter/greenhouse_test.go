@@ -2,9 +2,12 @@ package adapter
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/amishk599/firstin/internal/model"
 )
@@ -209,6 +212,26 @@ func TestExtractText(t *testing.T) {
 			input: "&lt;p&gt;We are hiring.&lt;/p&gt;\n&lt;ul&gt;\n  &lt;li&gt;Write code&lt;/li&gt;\n  &lt;li&gt;Review PRs&lt;/li&gt;\n&lt;/ul&gt;",
 			want:  "We are hiring. Write code Review PRs",
 		},
+		{
+			name:  "double-encoded HTML with double-encoded entity in text",
+			input: "&lt;p&gt;Research &amp;amp; Development&lt;/p&gt;",
+			want:  "Research & Development",
+		},
+		{
+			name:  "single-encoded HTML with entities",
+			input: "<p>Research &amp; Development</p>\n<ul><li>Go&nbsp;&amp; Rust</li></ul>",
+			want:  "Research & Development Go & Rust",
+		},
+		{
+			name:  "single-encoded HTML with escaped angle bracket in text",
+			input: "<p>Latency &lt; 10ms &amp; uptime &gt; 99.9%</p>",
+			want:  "Latency < 10ms & uptime > 99.9%",
+		},
+		{
+			name:  "mixed real and encoded tags",
+			input: "<div>&lt;p&gt;Build &amp;amp; ship&lt;/p&gt;</div>",
+			want:  "Build & ship",
+		},
 		{
 			name:  "plain text with no HTML",
 			input: "No tags here.",
@@ -231,6 +254,56 @@ func TestExtractText(t *testing.T) {
 	}
 }
 
+func TestFetchJobs_Department(t *testing.T) {
+	payload := `{
+		"departments": [
+			{
+				"id": 10, "name": "Engineering", "child_ids": [11],
+				"jobs": [{"id": 1, "title": "Software Engineer", "location": {"name": "Remote, US"}, "absolute_url": "https://boards.greenhouse.io/acme/jobs/1", "first_published": "2026-02-10T09:00:00Z"}]
+			},
+			{
+				"id": 11, "name": "Infrastructure", "parent_id": 10, "child_ids": [],
+				"jobs": [{"id": 2, "title": "SRE", "location": {"name": "Seattle, WA"}, "absolute_url": "https://boards.greenhouse.io/acme/jobs/2", "first_published": "2026-02-11T09:00:00Z"}]
+			},
+			{
+				"id": 20, "name": "Sales", "child_ids": [],
+				"jobs": [{"id": 3, "title": "Account Executive", "location": {"name": "New York, NY"}, "absolute_url": "https://boards.greenhouse.io/acme/jobs/3", "first_published": "2026-02-12T09:00:00Z"}]
+			}
+		]
+	}`
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	a := newTestAdapter(srv, "acme", "Acme Corp")
+	a.SetDepartment(10)
+	jobs, err := a.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/v1/boards/acme/departments" {
+		t.Errorf("requested %s, want the departments endpoint", gotPath)
+	}
+	var ids []string
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+		if j.Company != "Acme Corp" || j.Source != "greenhouse" || j.PostedAt == nil {
+			t.Errorf("job %s not mapped like the jobs endpoint: %+v", j.ID, j)
+		}
+	}
+	if strings.Join(ids, ",") != "1,2" {
+		t.Errorf("job IDs = %v, want Engineering and its sub-department only [1 2]", ids)
+	}
+
+	a.SetDepartment(99)
+	if _, err := a.FetchJobs(context.Background()); err == nil {
+		t.Error("expected error for unknown department")
+	}
+}
+
 // --- helpers ---
 
 // roundTripFunc adapts a function into an http.RoundTripper.
@@ -252,3 +325,50 @@ func newTestAdapter(srv *httptest.Server, token, company string) *GreenhouseAdap
 	}
 	return a
 }
+
+func TestFetchJobs_FreshnessCutoff(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	var b strings.Builder
+	b.WriteString(`{"jobs": [`)
+	const total = 5000
+	for i := 0; i < total; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		// Every 1000th job is fresh; the rest are weeks old.
+		published := now.Add(-time.Duration(i+1) * 24 * time.Hour)
+		if i%1000 == 0 {
+			published = now.Add(-30 * time.Minute)
+		}
+		fmt.Fprintf(&b, `{"id": %d, "title": "Engineer %d", "location": {"name": "Remote"}, "absolute_url": "https://example.com/%d", "first_published": %q}`,
+			i+1, i, i, published.Format(time.RFC3339))
+	}
+	// A job without a timestamp is kept: the cutoff can't judge it.
+	b.WriteString(`,{"id": 999999, "title": "Undated", "location": {"name": "Remote"}, "absolute_url": "https://example.com/undated"}`)
+	b.WriteString(`]}`)
+	payload := b.String()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	a := newTestAdapter(srv, "acme", "Acme Corp")
+	a.SetFreshnessCutoff(time.Hour)
+	a.now = func() time.Time { return now }
+
+	jobs, err := a.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != total/1000+1 {
+		t.Fatalf("expected %d jobs, got %d", total/1000+1, len(jobs))
+	}
+	cutoff := now.Add(-time.Hour)
+	for _, j := range jobs {
+		if j.PostedAt != nil && j.PostedAt.Before(cutoff) {
+			t.Errorf("job %s posted at %v is older than the cutoff", j.ID, j.PostedAt)
+		}
+	}
+}
@@ -2,13 +2,14 @@ package adapter
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+	"github.com/amishk599/firstin/internal/retry"
 )
 
 const leverBaseURL = "https://api.lever.co/v0/postings"
@@ -31,6 +32,8 @@ type leverJob struct {
 	Categories       leverCategories `json:"categories"`
 	CreatedAt        int64           `json:"createdAt"`
 	WorkplaceType    string          `json:"workplaceType"`
+	Country          string          `json:"country"` // ISO 3166 alpha-2, e.g. "US"
+	State            string          `json:"state"`   // "published", "internal", "closed", ...; absent on most boards
 	HostedURL        string          `json:"hostedUrl"`
 	ApplyURL         string          `json:"applyUrl"`
 }
@@ -63,20 +66,20 @@ func (a *LeverAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("lever fetch for %s: %w", a.companySlug, err)
+		return nil, fmt.Errorf("lever fetch for %s: %w", a.companySlug, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("lever fetch for %s: unexpected status %d", a.companySlug, resp.StatusCode),
 		}
 	}
 
 	var leverJobs []leverJob
-	if err := json.NewDecoder(resp.Body).Decode(&leverJobs); err != nil {
+	if err := decodeJSON(resp.Body, &leverJobs); err != nil {
 		return nil, fmt.Errorf("lever fetch for %s: %w", a.companySlug, err)
 	}
 
@@ -95,19 +98,34 @@ func (a *LeverAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
 			postedAt = &t
 		}
 
+		country := normalize.NormalizeCountry(lj.Country)
+		city, state := normalize.SplitPlace(location, country)
+
 		job := model.Job{
-			ID:       lj.ID,
-			Company:  a.companyName,
-			Title:    lj.Text,
-			Location: location,
-			URL:      lj.HostedURL,
-			PostedAt: postedAt,
-			Source:   "lever",
+			ID:                 lj.ID,
+			Company:            a.companyName,
+			Title:              lj.Text,
+			Location:           location,
+			NormalizedLocation: normalize.NormalizeLocation(location),
+			URL:                lj.HostedURL,
+			PostedAt:           postedAt,
+			Country:            country,
+			City:               city,
+			State:              state,
+			IsRemote:           normalize.IsRemote(location) || strings.EqualFold(lj.WorkplaceType, "remote"),
+			Source:             "lever",
+			PostingStatus:      leverPostingStatus(lj.State),
 			Detail: &model.JobDetail{
 				PublishedAt: postedAt,
 				ApplyURL:    lj.ApplyURL,
+				Locations:   lj.Categories.AllLocations,
+				Team:        lj.Categories.Team,
+				Commitment:  lj.Categories.Commitment,
 			},
 		}
+		if len(job.Detail.Locations) == 0 && lj.Categories.Location != "" {
+			job.Detail.Locations = []string{lj.Categories.Location}
+		}
 
 		desc := lj.DescriptionPlain
 		if desc == "" && lj.Description != "" {
@@ -122,3 +140,19 @@ func (a *LeverAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
 
 	return jobs, nil
 }
+
+// leverPostingStatus maps a Lever posting state to a model.Posting* value.
+// An empty or unknown state is left empty, which counts as open.
+func leverPostingStatus(state string) string {
+	switch strings.ToLower(state) {
+	case "published":
+		return model.PostingOpen
+	case "internal":
+		return model.PostingUnlisted
+	case "closed", "rejected":
+		return model.PostingClosed
+	case "draft", "pending":
+		return model.PostingDraft
+	}
+	return ""
+}
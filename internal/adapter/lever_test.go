@@ -2,10 +2,14 @@ package adapter
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/amishk599/firstin/internal/model"
 )
 
 func TestLeverAdapter_FetchJobs_Success(t *testing.T) {
@@ -143,8 +147,9 @@ func TestLeverAdapter_FetchJobs_MalformedJSON(t *testing.T) {
 	adapter := newLeverTestAdapter(srv, "bad-co", "Bad Co")
 
 	_, err := adapter.FetchJobs(context.Background())
-	if err == nil {
-		t.Fatal("expected error for malformed JSON, got nil")
+	var decodeErr *model.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *model.DecodeError for malformed JSON, got %v", err)
 	}
 }
 
@@ -206,6 +211,103 @@ func TestLeverAdapter_FetchJobs_LocationFallback(t *testing.T) {
 	}
 }
 
+func TestLeverAdapter_FetchJobs_RemoteSignal(t *testing.T) {
+	payload := `[
+		{"id": "a", "text": "Eng", "categories": {"location": "San Francisco, CA"}, "workplaceType": "remote"},
+		{"id": "b", "text": "Eng", "categories": {"location": "San Francisco, CA"}, "workplaceType": "onsite"},
+		{"id": "c", "text": "Eng", "categories": {"location": "Anywhere"}, "workplaceType": "unspecified"}
+	]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	jobs, err := newLeverTestAdapter(srv, "acme", "Acme Corp").FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]bool{"a": true, "b": false, "c": true}
+	for _, j := range jobs {
+		if j.IsRemote != want[j.ID] {
+			t.Errorf("job %s IsRemote = %v, want %v", j.ID, j.IsRemote, want[j.ID])
+		}
+	}
+}
+
+func TestLeverAdapter_FetchJobs_StructuredPlace(t *testing.T) {
+	payload := `[
+		{"id": "a", "text": "Eng", "categories": {"location": "Indianapolis, IN"}, "country": "US"},
+		{"id": "b", "text": "Eng", "categories": {"location": "Indianapolis, IN"}}
+	]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	jobs, err := newLeverTestAdapter(srv, "acme", "Acme Corp").FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+	if jobs[0].City != "Indianapolis" || jobs[0].State != "IN" {
+		t.Errorf("expected Indianapolis/IN with a structured country, got %q/%q", jobs[0].City, jobs[0].State)
+	}
+	if jobs[1].City != "" || jobs[1].State != "" {
+		t.Errorf("expected no city/state without a country, got %q/%q", jobs[1].City, jobs[1].State)
+	}
+}
+
+func TestLeverAdapter_FetchJobs_StructuredCategories(t *testing.T) {
+	payload := `[
+		{"id": "a", "text": "Eng", "state": "published", "categories": {
+			"team": "Platform", "commitment": "Full-time", "location": "New York, NY",
+			"allLocations": ["New York, NY", "Toronto, ON", "Remote"]}},
+		{"id": "b", "text": "Eng", "state": "internal", "categories": {"location": "Austin, TX"}},
+		{"id": "c", "text": "Eng", "categories": {}}
+	]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	jobs, err := newLeverTestAdapter(srv, "acme", "Acme Corp").FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+
+	a := jobs[0]
+	if a.Location != "New York, NY, Toronto, ON, Remote" {
+		t.Errorf("joined Location = %q", a.Location)
+	}
+	if want := []string{"New York, NY", "Toronto, ON", "Remote"}; !slices.Equal(a.Detail.Locations, want) {
+		t.Errorf("Locations = %v, want %v", a.Detail.Locations, want)
+	}
+	if a.Detail.Team != "Platform" || a.Detail.Commitment != "Full-time" {
+		t.Errorf("Team/Commitment = %q/%q, want Platform/Full-time", a.Detail.Team, a.Detail.Commitment)
+	}
+	if a.PostingStatus != model.PostingOpen {
+		t.Errorf("PostingStatus = %q, want open", a.PostingStatus)
+	}
+
+	b := jobs[1]
+	if want := []string{"Austin, TX"}; !slices.Equal(b.Detail.Locations, want) {
+		t.Errorf("Locations = %v, want the single location %v", b.Detail.Locations, want)
+	}
+	if b.PostingStatus != model.PostingUnlisted {
+		t.Errorf("PostingStatus = %q, want unlisted", b.PostingStatus)
+	}
+
+	c := jobs[2]
+	if c.Detail.Locations != nil || c.PostingStatus != "" {
+		t.Errorf("job without categories: Locations = %v, PostingStatus = %q, want neither", c.Detail.Locations, c.PostingStatus)
+	}
+}
+
 // --- helpers ---
 
 // newLeverTestAdapter creates a LeverAdapter wired to a test server.
@@ -2,20 +2,22 @@ package adapter
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+	"github.com/amishk599/firstin/internal/retry"
 )
 
 const (
-	microsoftBaseURL      = "https://apply.careers.microsoft.com"
-	microsoftPageSize     = 10
-	microsoftCutoff       = 24 * time.Hour
-	microsoftAuditMaxPages = 20 // caps audit mode at 200 jobs (20 pages × 10)
+	microsoftBaseURL       = "https://apply.careers.microsoft.com"
+	microsoftPageSize      = 10
+	microsoftCutoffDefault = 24 * time.Hour
+	microsoftAuditMaxPages = 20 // default audit mode cap: 200 jobs (20 pages × 10)
 )
 
 // microsoftPosition represents a single position in the Microsoft search API response.
@@ -25,6 +27,8 @@ type microsoftPosition struct {
 	Locations   []string `json:"locations"`
 	PostedTs    int64    `json:"postedTs"`
 	PositionURL string   `json:"positionUrl"`
+
+	WorkLocationOption string `json:"workLocationOption"` // "onsite", "remote" or "hybrid"
 }
 
 // microsoftSearchResponse is the top-level Microsoft search API response.
@@ -49,16 +53,35 @@ type microsoftDetailResponse struct {
 // MicrosoftAdapter fetches jobs from the Microsoft careers API.
 type MicrosoftAdapter struct {
 	companyName string
+	search      SearchConfig
 	client      *http.Client
-	auditMode   bool // when true: return all listings regardless of freshness
+	cutoff      time.Duration    // freshness window; pagination stops past it
+	auditMode   bool             // when true: return all listings regardless of freshness
+	auditPages  int              // page cap in audit mode
+	now         func() time.Time // clock for the freshness cutoff
 }
 
-// NewMicrosoftAdapter creates a new adapter for Microsoft careers.
-func NewMicrosoftAdapter(companyName string, client *http.Client) *MicrosoftAdapter {
+// NewMicrosoftAdapter creates a new adapter for Microsoft careers. Empty search
+// fields default to "software engineer" in "United States".
+func NewMicrosoftAdapter(companyName string, search SearchConfig, client *http.Client) *MicrosoftAdapter {
 	return &MicrosoftAdapter{
 		companyName: companyName,
+		search:      search.withDefaults(),
 		client:      client,
+		cutoff:      microsoftCutoffDefault,
+		auditPages:  microsoftAuditMaxPages,
+		now:         time.Now,
+	}
+}
+
+// SetFreshnessCutoff sets the freshness window to the poller's max_age so
+// pagination stops as soon as a page holds nothing the poller would keep.
+// Zero restores the 24h default.
+func (a *MicrosoftAdapter) SetFreshnessCutoff(maxAge time.Duration) {
+	if maxAge <= 0 {
+		maxAge = microsoftCutoffDefault
 	}
+	a.cutoff = maxAge
 }
 
 // SetAuditMode enables audit mode: all listings are returned regardless of freshness.
@@ -66,16 +89,26 @@ func (a *MicrosoftAdapter) SetAuditMode(enabled bool) {
 	a.auditMode = enabled
 }
 
+// SetAuditMaxPages sets how many pages audit mode fetches. Zero or less
+// restores the default of 20 pages.
+func (a *MicrosoftAdapter) SetAuditMaxPages(maxPages int) {
+	if maxPages <= 0 {
+		maxPages = microsoftAuditMaxPages
+	}
+	a.auditPages = maxPages
+}
+
 // FetchJobs retrieves jobs from Microsoft careers and normalizes them into the
-// unified Job model. In normal mode only jobs posted within the last 24 hours
-// are returned. In audit mode all listings are returned regardless of freshness.
+// unified Job model. In normal mode only jobs posted within the freshness
+// window (24h unless set via SetFreshnessCutoff) are returned. In audit mode
+// all listings are returned regardless of freshness.
 func (a *MicrosoftAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
 	positions, err := a.fetchAllPositions(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	cutoff := time.Now().UTC().Add(-microsoftCutoff)
+	cutoff := a.now().UTC().Add(-a.cutoff)
 	jobs := make([]model.Job, 0, len(positions))
 	for _, p := range positions {
 		if p.PostedTs == 0 {
@@ -92,46 +125,28 @@ func (a *MicrosoftAdapter) FetchJobs(ctx context.Context) ([]model.Job, error) {
 }
 
 // fetchAllPositions paginates the Microsoft search API, stopping early once a
-// full page contains no positions posted within the last 24 hours.
+// full page contains no positions posted within the freshness window.
 func (a *MicrosoftAdapter) fetchAllPositions(ctx context.Context) ([]microsoftPosition, error) {
-	cutoff := time.Now().UTC().Add(-microsoftCutoff)
-	var all []microsoftPosition
-	start := 0
-
-	for {
-		positions, count, err := a.fetchPage(ctx, start)
-		if err != nil {
-			return nil, err
-		}
-
-		all = append(all, positions...)
-
-		// Early exit: if no position on this page was posted within the cutoff,
-		// older pages will only get more stale — stop paginating.
-		// Skipped in audit mode since we want all listings.
-		if !a.auditMode {
-			hasAnyFresh := false
+	cutoff := a.now().UTC().Add(-a.cutoff)
+	pager := searchPaginator[microsoftPosition]{
+		pageSize:      microsoftPageSize,
+		auditMaxPages: a.auditPages,
+		auditMode:     a.auditMode,
+		fetchPage: func(ctx context.Context, start int) ([]microsoftPosition, int, int, error) {
+			positions, count, err := a.fetchPage(ctx, start)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			fresh := 0
 			for _, p := range positions {
 				if p.PostedTs > 0 && time.Unix(p.PostedTs, 0).UTC().After(cutoff) {
-					hasAnyFresh = true
-					break
+					fresh++
 				}
 			}
-			if !hasAnyFresh {
-				break
-			}
-		}
-
-		start += microsoftPageSize
-		if start >= count {
-			break
-		}
-		if a.auditMode && start >= microsoftAuditMaxPages*microsoftPageSize {
-			break
-		}
+			return positions, count, fresh, nil
+		},
 	}
-
-	return all, nil
+	return pager.fetchAll(ctx)
 }
 
 // fetchPage fetches a single page of search results at the given start offset.
@@ -139,8 +154,8 @@ func (a *MicrosoftAdapter) fetchPage(ctx context.Context, start int) ([]microsof
 	u, _ := url.Parse(microsoftBaseURL + "/api/pcsx/search")
 	q := u.Query()
 	q.Set("domain", "microsoft.com")
-	q.Set("query", "software engineer")
-	q.Set("location", "United States")
+	q.Set("query", a.search.Query)
+	q.Set("location", a.search.Location)
 	q.Set("start", fmt.Sprintf("%d", start))
 	q.Set("sort_by", "timestamp")
 	q.Set("filter_include_remote", "1")
@@ -153,21 +168,21 @@ func (a *MicrosoftAdapter) fetchPage(ctx context.Context, start int) ([]microsof
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("microsoft fetch page (start=%d): %w", start, err)
+		return nil, 0, fmt.Errorf("microsoft fetch page (start=%d): %w", start, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, 0, &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("microsoft fetch page (start=%d): unexpected status %d", start, resp.StatusCode),
 		}
 	}
 
 	var msResp microsoftSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&msResp); err != nil {
-		return nil, 0, fmt.Errorf("microsoft fetch page (start=%d) decode: %w", start, err)
+	if err := decodeJSON(resp.Body, &msResp); err != nil {
+		return nil, 0, fmt.Errorf("microsoft fetch page (start=%d): %w", start, err)
 	}
 
 	return msResp.Data.Positions, msResp.Data.Count, nil
@@ -183,14 +198,16 @@ func (a *MicrosoftAdapter) jobFromPosition(p microsoftPosition, postedAt time.Ti
 	jobURL := microsoftBaseURL + p.PositionURL
 
 	return model.Job{
-		ID:       fmt.Sprintf("%d", p.ID),
-		Company:  a.companyName,
-		Title:    p.Name,
-		Location: location,
-		URL:      jobURL,
-		PostedAt: &postedAt,
-		Source:   "microsoft",
-		Detail:   &model.JobDetail{PublishedAt: &postedAt},
+		ID:                 fmt.Sprintf("%d", p.ID),
+		Company:            a.companyName,
+		Title:              p.Name,
+		Location:           location,
+		NormalizedLocation: normalize.NormalizeLocation(location),
+		URL:                jobURL,
+		PostedAt:           &postedAt,
+		IsRemote:           strings.EqualFold(p.WorkLocationOption, "remote") || normalize.IsRemote(strings.Join(p.Locations, "; ")),
+		Source:             "microsoft",
+		Detail:             &model.JobDetail{PublishedAt: &postedAt},
 	}
 }
 
@@ -206,7 +223,7 @@ func (a *MicrosoftAdapter) FetchJobDetail(ctx context.Context, job model.Job) (m
 	q.Set("position_id", job.ID)
 	q.Set("domain", "microsoft.com")
 	q.Set("hl", "en")
-	q.Set("queried_location", "United States")
+	q.Set("queried_location", a.search.Location)
 	u.RawQuery = q.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
@@ -216,21 +233,21 @@ func (a *MicrosoftAdapter) FetchJobDetail(ctx context.Context, job model.Job) (m
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return job, fmt.Errorf("microsoft detail fetch for job %s: %w", job.ID, err)
+		return job, fmt.Errorf("microsoft detail fetch for job %s: %w", job.ID, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return job, &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("microsoft detail fetch for job %s: unexpected status %d", job.ID, resp.StatusCode),
 		}
 	}
 
 	var detail microsoftDetailResponse
-	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
-		return job, fmt.Errorf("microsoft detail decode for job %s: %w", job.ID, err)
+	if err := decodeJSON(resp.Body, &detail); err != nil {
+		return job, fmt.Errorf("microsoft detail fetch for job %s: %w", job.ID, err)
 	}
 
 	if job.Detail == nil {
@@ -140,6 +140,52 @@ func TestMicrosoftAdapter_FetchJobs_PaginationEarlyExit(t *testing.T) {
 	}
 }
 
+func TestMicrosoftAdapter_AuditMaxPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxPages  int
+		wantPages int
+	}{
+		{"default", 0, microsoftAuditMaxPages},
+		{"lowered", 3, 3},
+		{"raised", 50, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pageRequests := 0
+			// Stale positions on a board far longer than any cap: only the
+			// cap stops audit pagination.
+			searchPayload := map[string]any{
+				"status": 200,
+				"data": map[string]any{
+					"positions": []map[string]any{
+						{"id": int64(111), "name": "Old Engineer", "postedTs": staleMsTs(), "positionUrl": "/careers/job/111"},
+					},
+					"count": 100000,
+				},
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/pcsx/search" {
+					pageRequests++
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(searchPayload)
+				}
+			}))
+			defer srv.Close()
+
+			a := newMicrosoftTestAdapter(srv, "Microsoft")
+			a.SetAuditMode(true)
+			a.SetAuditMaxPages(tt.maxPages)
+			if _, err := a.FetchJobs(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pageRequests != tt.wantPages {
+				t.Errorf("page requests = %d, want %d", pageRequests, tt.wantPages)
+			}
+		})
+	}
+}
+
 func TestMicrosoftAdapter_FetchJobs_HTTPError(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -251,7 +297,7 @@ func newMicrosoftTestServer(t *testing.T, searchPayload, detailPayload any) *htt
 
 // newMicrosoftTestAdapter creates a MicrosoftAdapter wired to a test server.
 func newMicrosoftTestAdapter(srv *httptest.Server, company string) *MicrosoftAdapter {
-	a := NewMicrosoftAdapter(company, srv.Client())
+	a := NewMicrosoftAdapter(company, SearchConfig{}, srv.Client())
 	a.client = &http.Client{
 		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
 			req.URL.Scheme = "http"
@@ -266,3 +312,137 @@ func newMicrosoftTestAdapter(srv *httptest.Server, company string) *MicrosoftAda
 func jobFromPositionHelper(a *MicrosoftAdapter, p microsoftPosition, postedAt time.Time) model.Job {
 	return a.jobFromPosition(p, postedAt)
 }
+
+func TestMicrosoftAdapter_SearchConfigInRequest(t *testing.T) {
+	var gotQuery, gotLocation string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("query")
+		gotLocation = r.URL.Query().Get("location")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"positions":[],"count":0}}`))
+	}))
+	defer srv.Close()
+
+	a := newMicrosoftTestAdapter(srv, "Microsoft")
+	a.search = SearchConfig{Query: "data scientist", Location: "Germany"}.withDefaults()
+	if _, err := a.FetchJobs(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "data scientist" {
+		t.Errorf("query = %q, want %q", gotQuery, "data scientist")
+	}
+	if gotLocation != "Germany" {
+		t.Errorf("location = %q, want %q", gotLocation, "Germany")
+	}
+}
+
+func TestSearchConfig_Defaults(t *testing.T) {
+	got := SearchConfig{Location: "Canada"}.withDefaults()
+	if got.Query != defaultSearchQuery {
+		t.Errorf("Query = %q, want default %q", got.Query, defaultSearchQuery)
+	}
+	if got.Location != "Canada" {
+		t.Errorf("Location = %q, want Canada", got.Location)
+	}
+}
+
+func TestMicrosoftAdapter_FreshnessCutoffShortensPagination(t *testing.T) {
+	// Page 0 has one job from 30m ago; every later page only has 2h-old jobs,
+	// which are fresh under the default 24h cutoff but stale under 1h.
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	newServer := func(pages *int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*pages++
+			ts := now.Add(-2 * time.Hour).Unix()
+			if r.URL.Query().Get("start") == "0" {
+				ts = now.Add(-30 * time.Minute).Unix()
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"positions": []map[string]any{
+						{"id": int64(*pages), "name": "Engineer", "postedTs": ts, "positionUrl": "/careers/job/1"},
+					},
+					"count": 3 * microsoftPageSize,
+				},
+			})
+		}))
+	}
+
+	var defaultPages int
+	srv := newServer(&defaultPages)
+	defer srv.Close()
+	a := newMicrosoftTestAdapter(srv, "Microsoft")
+	a.now = func() time.Time { return now }
+	if _, err := a.FetchJobs(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var shortPages int
+	srvShort := newServer(&shortPages)
+	defer srvShort.Close()
+	short := newMicrosoftTestAdapter(srvShort, "Microsoft")
+	short.SetFreshnessCutoff(time.Hour)
+	short.now = func() time.Time { return now }
+	jobs, err := short.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if defaultPages != 3 {
+		t.Errorf("default cutoff fetched %d pages, want 3", defaultPages)
+	}
+	if shortPages != 2 {
+		t.Errorf("1h cutoff fetched %d pages, want 2 (early exit on first all-stale page)", shortPages)
+	}
+	if len(jobs) != 1 {
+		t.Errorf("1h cutoff returned %d jobs, want 1", len(jobs))
+	}
+}
+
+func TestMicrosoftAdapter_FreshnessBoundary(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	cutoff := now.Add(-time.Hour)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"positions": []map[string]any{
+					{"id": 1, "name": "At cutoff", "postedTs": cutoff.Unix(), "positionUrl": "/careers/job/1"},
+					{"id": 2, "name": "Just past cutoff", "postedTs": cutoff.Add(-time.Second).Unix(), "positionUrl": "/careers/job/2"},
+				},
+				"count": 2,
+			},
+		})
+	}))
+	defer srv.Close()
+
+	a := newMicrosoftTestAdapter(srv, "Microsoft")
+	a.SetFreshnessCutoff(time.Hour)
+	a.now = func() time.Time { return now }
+	jobs, err := a.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Title != "At cutoff" {
+		t.Errorf("jobs = %+v, want only the job posted exactly at the cutoff", jobs)
+	}
+}
+
+func TestMicrosoftAdapter_RemoteSignal(t *testing.T) {
+	a := NewMicrosoftAdapter("Microsoft", SearchConfig{}, http.DefaultClient)
+	now := time.Now()
+	tests := []struct {
+		pos  microsoftPosition
+		want bool
+	}{
+		{microsoftPosition{Locations: []string{"Redmond, WA"}, WorkLocationOption: "remote"}, true},
+		{microsoftPosition{Locations: []string{"Redmond, WA"}, WorkLocationOption: "onsite"}, false},
+		{microsoftPosition{Locations: []string{"Redmond, WA", "United States, Remote"}}, true},
+	}
+	for _, tt := range tests {
+		if got := jobFromPositionHelper(a, tt.pos, now).IsRemote; got != tt.want {
+			t.Errorf("IsRemote(%+v) = %v, want %v", tt.pos, got, tt.want)
+		}
+	}
+}
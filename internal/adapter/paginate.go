@@ -0,0 +1,51 @@
+package adapter
+
+import "context"
+
+// searchPageFunc fetches the page of search results starting at offset. It
+// returns the page's items, the total result count the API reports, and how
+// many of the items fall within the freshness window.
+type searchPageFunc[T any] func(ctx context.Context, offset int) (items []T, total, fresh int, err error)
+
+// searchPaginator walks a recency-sorted search API page by page, the shape
+// shared by search-API adapters (Microsoft, Amazon). Adapters supply the page
+// fetch; the paginator owns when to stop.
+type searchPaginator[T any] struct {
+	pageSize      int
+	auditMaxPages int  // page cap in audit mode, which never stops early
+	auditMode     bool // when true: keep paging past stale pages
+	fetchPage     searchPageFunc[T]
+}
+
+// fetchAll collects items from successive pages until one of:
+//   - a page holds no fresh items (results are sorted by recency, so later
+//     pages are stale too); skipped in audit mode
+//   - a page is empty or the offset reaches the reported total
+//   - audit mode has fetched auditMaxPages pages
+func (p searchPaginator[T]) fetchAll(ctx context.Context) ([]T, error) {
+	var all []T
+	offset := 0
+
+	for {
+		items, total, fresh, err := p.fetchPage(ctx, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if !p.auditMode && fresh == 0 {
+			break
+		}
+
+		offset += p.pageSize
+		if len(items) == 0 || offset >= total {
+			break
+		}
+		if p.auditMode && offset >= p.auditMaxPages*p.pageSize {
+			break
+		}
+	}
+
+	return all, nil
+}
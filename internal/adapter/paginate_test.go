@@ -0,0 +1,90 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakePages serves pages of size pageSize from items, reporting fresh[i] as
+// the fresh count of page i, and records the offsets requested.
+type fakePages struct {
+	pageSize int
+	items    []int
+	fresh    []int
+	offsets  []int
+	errAt    int // offset that fails; -1 for none
+}
+
+func (f *fakePages) fetch(_ context.Context, offset int) ([]int, int, int, error) {
+	f.offsets = append(f.offsets, offset)
+	if offset == f.errAt {
+		return nil, 0, 0, errors.New("boom")
+	}
+	end := min(offset+f.pageSize, len(f.items))
+	if offset >= end {
+		return nil, len(f.items), 0, nil
+	}
+	page := offset / f.pageSize
+	fresh := 0
+	if page < len(f.fresh) {
+		fresh = f.fresh[page]
+	}
+	return f.items[offset:end], len(f.items), fresh, nil
+}
+
+func TestSearchPaginator_StopConditions(t *testing.T) {
+	items := make([]int, 50) // five pages of 10
+	tests := []struct {
+		name        string
+		items       []int
+		fresh       []int
+		auditMode   bool
+		auditMax    int
+		wantOffsets []int
+		wantItems   int
+	}{
+		{"stops at first stale page", items, []int{10, 3, 0, 10, 10}, false, 20, []int{0, 10, 20}, 30},
+		{"first page stale", items, []int{0}, false, 20, []int{0}, 10},
+		{"stops at total", items, []int{10, 10, 10, 10, 10}, false, 20, []int{0, 10, 20, 30, 40}, 50},
+		{"audit ignores staleness", items, nil, true, 20, []int{0, 10, 20, 30, 40}, 50},
+		{"audit page cap", items, nil, true, 2, []int{0, 10}, 20},
+		{"empty board", nil, nil, true, 20, []int{0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakePages{pageSize: 10, items: tt.items, fresh: tt.fresh, errAt: -1}
+			p := searchPaginator[int]{pageSize: 10, auditMaxPages: tt.auditMax, auditMode: tt.auditMode, fetchPage: f.fetch}
+
+			got, err := p.fetchAll(context.Background())
+			if err != nil {
+				t.Fatalf("fetchAll: %v", err)
+			}
+			if len(got) != tt.wantItems {
+				t.Errorf("got %d items, want %d", len(got), tt.wantItems)
+			}
+			if len(f.offsets) != len(tt.wantOffsets) {
+				t.Fatalf("offsets = %v, want %v", f.offsets, tt.wantOffsets)
+			}
+			for i := range f.offsets {
+				if f.offsets[i] != tt.wantOffsets[i] {
+					t.Errorf("offsets = %v, want %v", f.offsets, tt.wantOffsets)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSearchPaginator_PageError(t *testing.T) {
+	f := &fakePages{pageSize: 10, items: make([]int, 30), fresh: []int{10, 10, 10}, errAt: 10}
+	p := searchPaginator[int]{pageSize: 10, auditMaxPages: 20, fetchPage: f.fetch}
+
+	got, err := p.fetchAll(context.Background())
+	if err == nil {
+		t.Fatal("expected error from failing page")
+	}
+	if got != nil {
+		t.Errorf("got %d items on error, want nil", len(got))
+	}
+}
@@ -0,0 +1,42 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// FileFetcher replays a recorded board: it reads a JSON array of jobs, with
+// model.Job field names (as in the dead-letter file's "job" records), from a
+// file instead of calling an ATS. The file is read on every fetch, so it can
+// be edited between polls. Jobs without a Company get companyName.
+type FileFetcher struct {
+	path        string
+	companyName string
+}
+
+// NewFileFetcher creates a fetcher that replays the jobs in path.
+func NewFileFetcher(path string, companyName string) *FileFetcher {
+	return &FileFetcher{path: path, companyName: companyName}
+}
+
+// FetchJobs decodes the recorded jobs.
+func (f *FileFetcher) FetchJobs(_ context.Context) ([]model.Job, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("replay for %s: %w", f.companyName, err)
+	}
+	var jobs []model.Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("replay for %s: %s: %w", f.companyName, f.path, &model.DecodeError{Err: err})
+	}
+	for i := range jobs {
+		if jobs[i].Company == "" {
+			jobs[i].Company = f.companyName
+		}
+	}
+	return jobs, nil
+}
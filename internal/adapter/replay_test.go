@@ -0,0 +1,48 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileFetcher_ReturnsDecodedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.json")
+	snapshot := `[
+		{"ID": "1", "Title": "Backend Engineer", "Location": "Remote", "URL": "https://example.com/1", "PostedAt": "2026-03-01T09:30:00Z"},
+		{"id": "2", "company": "Other", "title": "Designer"}
+	]`
+	if err := os.WriteFile(path, []byte(snapshot), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := NewFileFetcher(path, "Acme").FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("FetchJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+	posted := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if j := jobs[0]; j.ID != "1" || j.Title != "Backend Engineer" || j.Company != "Acme" || j.PostedAt == nil || !j.PostedAt.Equal(posted) {
+		t.Errorf("jobs[0] = %+v, want decoded job with company Acme", j)
+	}
+	if j := jobs[1]; j.ID != "2" || j.Company != "Other" || j.Title != "Designer" {
+		t.Errorf("jobs[1] = %+v, want its own company kept", j)
+	}
+}
+
+func TestFileFetcher_Errors(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"not": "a list"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(dir, "missing.json"), bad} {
+		if _, err := NewFileFetcher(path, "Acme").FetchJobs(context.Background()); err == nil {
+			t.Errorf("FetchJobs(%s) error = nil, want error", filepath.Base(path))
+		}
+	}
+}
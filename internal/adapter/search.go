@@ -0,0 +1,36 @@
+package adapter
+
+// Defaults used by search-API adapters (Microsoft, Amazon) when a company
+// doesn't configure search_query / search_location.
+const (
+	defaultSearchQuery    = "software engineer"
+	defaultSearchLocation = "United States"
+)
+
+// SearchConfig is the query and location sent to search-API adapters, which
+// return a filtered slice of a large careers site rather than a whole board.
+type SearchConfig struct {
+	Query    string // free-text role query, e.g. "data scientist"
+	Location string // location query, e.g. "Germany"
+}
+
+// WorkdaySearch narrows a Workday board server-side. Both fields are sent in
+// every listing request; the zero value fetches the whole board.
+type WorkdaySearch struct {
+	Text string // searchText, e.g. "software engineer"
+	// Facets is appliedFacets: facet ID to selected value IDs, e.g.
+	// {"locationCountry": ["bc33aa3152ec42d4995f4791a106ed09"]}. IDs are
+	// board-specific; copy them from the site's own /jobs request.
+	Facets map[string][]string
+}
+
+// withDefaults fills empty fields with the default query and location.
+func (c SearchConfig) withDefaults() SearchConfig {
+	if c.Query == "" {
+		c.Query = defaultSearchQuery
+	}
+	if c.Location == "" {
+		c.Location = defaultSearchLocation
+	}
+	return c
+}
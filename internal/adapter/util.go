@@ -6,13 +6,23 @@ import (
 	"strings"
 )
 
-var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+var (
+	htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+	// encodedTagRegex spots an entity-encoded tag such as "&lt;p&gt;" or
+	// "&lt;/li&gt;", the mark of double-encoded HTML.
+	encodedTagRegex = regexp.MustCompile(`&lt;/?[a-zA-Z]`)
+)
 
 // extractText converts an HTML or HTML-encoded string to plain text.
-// It first unescapes HTML entities (handles Greenhouse's double-encoding;
-// no-op on already-real HTML), strips all tags, then collapses whitespace.
+// Double-encoded HTML (Greenhouse's "&lt;p&gt;") is unescaped once to real
+// HTML first. Tags are then stripped and the remaining entities unescaped,
+// so "&amp;amp;" inside double-encoded text and "&amp;" inside ordinary HTML
+// both come out as "&", and an escaped "&lt;" in the text survives as "<"
+// rather than being taken for a tag. Whitespace is collapsed last.
 func extractText(content string) string {
-	unescaped := html.UnescapeString(content)
-	plain := htmlTagRegex.ReplaceAllString(unescaped, "")
+	if encodedTagRegex.MatchString(content) {
+		content = html.UnescapeString(content)
+	}
+	plain := html.UnescapeString(htmlTagRegex.ReplaceAllString(content, ""))
 	return strings.Join(strings.Fields(plain), " ")
 }
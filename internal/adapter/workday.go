@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+	"github.com/amishk599/firstin/internal/retry"
 )
 
 const workdayPageSize = 20
@@ -54,6 +56,7 @@ type workdayJobDetail struct {
 	Country             workdayCountry `json:"country"`
 	AdditionalLocations []string       `json:"additionalLocations"`
 	JobDescription      string         `json:"jobDescription"`
+	RemoteType          string         `json:"remoteType"` // e.g. "Fully Remote", "Hybrid"; often absent
 }
 
 type workdayCountry struct {
@@ -64,21 +67,26 @@ type workdayCountry struct {
 type WorkdayAdapter struct {
 	baseURL     string
 	companyName string
+	search      WorkdaySearch
 	client      *http.Client
-	preFilter   model.JobFilter // optional: used to skip detail fetches for listings that clearly won't match
-	auditMode   bool            // when true: return all listings, only detail-fetch fresh ones
+	preFilter   model.JobFilter  // optional: used to skip detail fetches for listings that clearly won't match
+	auditMode   bool             // when true: return all listings, only detail-fetch fresh ones
+	now         func() time.Time // clock for "Posted N Days Ago" dates
 	logger      *slog.Logger
 }
 
 // NewWorkdayAdapter creates a new adapter for a Workday career site.
+// search narrows the listing server-side; pass the zero value for the whole board.
 // An optional preFilter can be provided to skip detail API calls for listings that clearly won't match
 // pass nil to disable pre-filtering.
-func NewWorkdayAdapter(baseURL string, companyName string, client *http.Client, preFilter model.JobFilter, logger *slog.Logger) *WorkdayAdapter {
+func NewWorkdayAdapter(baseURL string, companyName string, search WorkdaySearch, client *http.Client, preFilter model.JobFilter, logger *slog.Logger) *WorkdayAdapter {
 	return &WorkdayAdapter{
 		baseURL:     strings.TrimRight(baseURL, "/"),
 		companyName: companyName,
+		search:      search,
 		client:      client,
 		preFilter:   preFilter,
+		now:         time.Now,
 		logger:      logger,
 	}
 }
@@ -133,12 +141,17 @@ func (a *WorkdayAdapter) fetchAllListings(ctx context.Context) ([]workdayListing
 	offset := 0
 	pagesScanned := 0
 
+	facets := make(map[string]any, len(a.search.Facets))
+	for k, v := range a.search.Facets {
+		facets[k] = v
+	}
+
 	for {
 		body := workdayListingRequest{
-			AppliedFacets: map[string]any{},
+			AppliedFacets: facets,
 			Limit:         workdayPageSize,
 			Offset:        offset,
-			SearchText:    "",
+			SearchText:    a.search.Text,
 		}
 
 		jsonBody, err := json.Marshal(body)
@@ -155,22 +168,22 @@ func (a *WorkdayAdapter) fetchAllListings(ctx context.Context) ([]workdayListing
 
 		resp, err := a.client.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("workday listing fetch for %s: %w", a.companyName, err)
+			return nil, fmt.Errorf("workday listing fetch for %s: %w", a.companyName, &model.NetworkError{Err: err})
 		}
 
 		if resp.StatusCode != http.StatusOK {
 			resp.Body.Close()
 			return nil, &model.HTTPError{
 				StatusCode: resp.StatusCode,
-				RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+				RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 				Err:        fmt.Errorf("workday listing fetch for %s: unexpected status %d", a.companyName, resp.StatusCode),
 			}
 		}
 
 		var listResp workdayListingResponse
-		if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
+		if err := decodeJSON(resp.Body, &listResp); err != nil {
 			resp.Body.Close()
-			return nil, fmt.Errorf("workday listing decode for %s: %w", a.companyName, err)
+			return nil, fmt.Errorf("workday listing fetch for %s: %w", a.companyName, err)
 		}
 		resp.Body.Close()
 
@@ -226,14 +239,16 @@ func (a *WorkdayAdapter) fetchAllListings(ctx context.Context) ([]workdayListing
 // Used in audit mode for stale listings where we skip the detail API call.
 func (a *WorkdayAdapter) jobFromListing(l workdayListing) model.Job {
 	job := model.Job{
-		ID:       l.ExternalPath,
-		Company:  a.companyName,
-		Title:    l.Title,
-		Location: l.LocationsText,
-		Source:   "workday",
-		Detail:   &model.JobDetail{PostedOn: l.PostedOn},
+		ID:                 l.ExternalPath,
+		Company:            a.companyName,
+		Title:              l.Title,
+		Location:           l.LocationsText,
+		NormalizedLocation: normalize.NormalizeLocation(l.LocationsText),
+		IsRemote:           normalize.IsRemote(l.LocationsText),
+		Source:             "workday",
+		Detail:             &model.JobDetail{PostedOn: l.PostedOn},
 	}
-	job.PostedAt = parsePostedOn(l.PostedOn)
+	job.PostedAt = parsePostedOn(l.PostedOn, a.now())
 	return job
 }
 
@@ -246,21 +261,21 @@ func (a *WorkdayAdapter) fetchDetail(ctx context.Context, listing workdayListing
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return model.Job{}, fmt.Errorf("workday detail fetch for %s: %w", a.companyName, err)
+		return model.Job{}, fmt.Errorf("workday detail fetch for %s: %w", a.companyName, &model.NetworkError{Err: err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return model.Job{}, &model.HTTPError{
 			StatusCode: resp.StatusCode,
-			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
 			Err:        fmt.Errorf("workday detail fetch for %s: unexpected status %d", a.companyName, resp.StatusCode),
 		}
 	}
 
 	var detail workdayDetailResponse
-	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
-		return model.Job{}, fmt.Errorf("workday detail decode for %s: %w", a.companyName, err)
+	if err := decodeJSON(resp.Body, &detail); err != nil {
+		return model.Job{}, fmt.Errorf("workday detail fetch for %s: %w", a.companyName, err)
 	}
 
 	info := detail.JobPostingInfo
@@ -270,13 +285,21 @@ func (a *WorkdayAdapter) fetchDetail(ctx context.Context, listing workdayListing
 		location = location + "; " + strings.Join(info.AdditionalLocations, "; ")
 	}
 
+	country := normalize.NormalizeCountry(info.Country.Descriptor)
+	city, state := normalize.SplitPlace(location, country)
+
 	job := model.Job{
-		ID:       info.JobReqID,
-		Company:  a.companyName,
-		Title:    info.Title,
-		Location: location,
-		URL:      info.ExternalURL,
-		Source:   "workday",
+		ID:                 info.JobReqID,
+		Company:            a.companyName,
+		Title:              info.Title,
+		Location:           location,
+		NormalizedLocation: normalize.NormalizeLocation(location),
+		URL:                info.ExternalURL,
+		Country:            country,
+		City:               city,
+		State:              state,
+		IsRemote:           normalize.IsRemote(location) || normalize.IsRemote(info.RemoteType),
+		Source:             "workday",
 	}
 
 	jobDetail := &model.JobDetail{
@@ -294,7 +317,7 @@ func (a *WorkdayAdapter) fetchDetail(ctx context.Context, listing workdayListing
 		}
 	}
 	if job.PostedAt == nil {
-		job.PostedAt = parsePostedOn(info.PostedOn)
+		job.PostedAt = parsePostedOn(info.PostedOn, a.now())
 	}
 
 	if info.JobDescription != "" {
@@ -351,9 +374,12 @@ func (a *WorkdayAdapter) listingPassesPreFilter(l workdayListing) bool {
 		return true
 	}
 
+	// Remote status and country are only known after the detail fetch; assume
+	// remote so a remote-only filter doesn't drop listings before we can tell.
 	candidate := model.Job{
 		Title:    l.Title,
 		Location: l.LocationsText,
+		IsRemote: true,
 	}
 	return a.preFilter.Match(candidate)
 }
@@ -380,9 +406,10 @@ func isFreshPosting(postedOn string) bool {
 
 var daysAgoRegex = regexp.MustCompile(`^Posted (\d+) Days? Ago$`)
 
-// parsePostedOn converts a Workday relative date string to an approximate timestamp.
-func parsePostedOn(postedOn string) *time.Time {
-	now := time.Now().UTC()
+// parsePostedOn converts a Workday relative date string to an approximate
+// timestamp: midnight UTC of the day it names, counting back from now.
+func parsePostedOn(postedOn string, now time.Time) *time.Time {
+	now = now.UTC()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
 	switch postedOn {
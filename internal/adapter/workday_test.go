@@ -1,6 +1,8 @@
 package adapter
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,7 +11,10 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/httpclient"
 	"github.com/amishk599/firstin/internal/model"
 )
 
@@ -86,6 +91,12 @@ func TestWorkdayFetchJobs_Success(t *testing.T) {
 	if j.Detail == nil || j.Detail.Description != "Build scalable systems." {
 		t.Errorf("expected description 'Build scalable systems.', got %v", j.Detail)
 	}
+	if j.Country != "United States" {
+		t.Errorf("expected country 'United States' from country.descriptor, got %q", j.Country)
+	}
+	if j.City != "" || j.State != "" {
+		t.Errorf("expected no city/state for a multi-location job, got %q/%q", j.City, j.State)
+	}
 }
 
 func TestWorkdayFetchJobs_PaginationContinuesWhenLastIsFresh(t *testing.T) {
@@ -236,6 +247,75 @@ func TestWorkdayFetchJobs_HTTPError(t *testing.T) {
 	}
 }
 
+func TestWorkdayFetchJobs_SendsSearchTextAndFacets(t *testing.T) {
+	var got workdayListingRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decode listing request: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total": 0, "jobPostings": []}`))
+	}))
+	defer srv.Close()
+
+	a := newWorkdayTestAdapter(srv, "SearchCo")
+	a.search = WorkdaySearch{
+		Text:   "software engineer",
+		Facets: map[string][]string{"locationCountry": {"bc33aa3152ec42d4995f4791a106ed09"}},
+	}
+
+	if _, err := a.FetchJobs(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.SearchText != "software engineer" {
+		t.Errorf("expected searchText 'software engineer', got %q", got.SearchText)
+	}
+	vals, ok := got.AppliedFacets["locationCountry"].([]any)
+	if !ok || len(vals) != 1 || vals[0] != "bc33aa3152ec42d4995f4791a106ed09" {
+		t.Errorf("expected locationCountry facet in appliedFacets, got %v", got.AppliedFacets)
+	}
+}
+
+func TestWorkdayFetchJobs_GzipResponses(t *testing.T) {
+	listingResp := `{"total": 1, "jobPostings": [{"title": "Software Engineer", "externalPath": "job/SWE/JR1", "locationsText": "Remote", "postedOn": "Posted Today"}]}`
+	detailResp := `{"jobPostingInfo": {"jobReqId": "JR1", "title": "Software Engineer", "location": "Remote", "startDate": "2026-02-17", "jobDescription": "<p>Big board.</p>"}}`
+
+	// Compress unconditionally, as some large tenants do.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := detailResp
+		if r.Method == http.MethodPost {
+			body = listingResp
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(body))
+		zw.Close()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	client, err := httpclient.New(config.HTTPConfig{Timeout: 5 * time.Second}, "test")
+	if err != nil {
+		t.Fatalf("httpclient.New: %v", err)
+	}
+	a := NewWorkdayAdapter(srv.URL, "GzipCo", WorkdaySearch{}, client, nil, slog.Default())
+
+	jobs, err := a.FetchJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != "JR1" {
+		t.Fatalf("jobs = %+v, want JR1 decoded from gzip", jobs)
+	}
+	if jobs[0].Detail == nil || jobs[0].Detail.Description != "Big board." {
+		t.Errorf("detail = %+v, want description decoded from gzip", jobs[0].Detail)
+	}
+}
+
 // mockFilter rejects jobs whose location doesn't contain the keyword (case-insensitive).
 type mockFilter struct {
 	locationKeyword string
@@ -314,6 +394,7 @@ func TestWorkdayFetchJobs_PreFilterSkipsNonMatchingListings(t *testing.T) {
 }
 
 func TestParsePostedOn(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
 	tests := []struct {
 		input    string
 		wantNil  bool
@@ -330,7 +411,7 @@ func TestParsePostedOn(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			got := parsePostedOn(tt.input)
+			got := parsePostedOn(tt.input, now)
 			if tt.wantNil {
 				if got != nil {
 					t.Errorf("expected nil for %q, got %v", tt.input, got)
@@ -340,17 +421,53 @@ func TestParsePostedOn(t *testing.T) {
 			if got == nil {
 				t.Fatalf("expected non-nil for %q", tt.input)
 			}
+			if want := time.Date(2025, 3, 10-tt.wantDays, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+				t.Errorf("parsePostedOn(%q) = %v, want %v", tt.input, got, want)
+			}
 		})
 	}
 }
 
+// TestParsePostedOn_MidnightUTC pins the day boundary: "today" is the UTC
+// date, so a clock just either side of midnight UTC lands on different days,
+// and a non-UTC clock is converted first.
+func TestParsePostedOn_MidnightUTC(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"just before midnight", time.Date(2025, 3, 10, 23, 59, 59, 0, time.UTC), time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"at midnight", time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"pacific evening is next UTC day", time.Date(2025, 3, 10, 17, 0, 0, 0, pst), time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePostedOn("Posted Yesterday", tt.now); got == nil || !got.Equal(tt.want) {
+				t.Errorf("parsePostedOn(Posted Yesterday) at %v = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkdayAdapter_ListingPostedAtUsesClock(t *testing.T) {
+	a := NewWorkdayAdapter("https://example.com", "TestCo", WorkdaySearch{}, http.DefaultClient, nil, slog.Default())
+	a.now = func() time.Time { return time.Date(2025, 3, 11, 0, 0, 1, 0, time.UTC) }
+
+	job := a.jobFromListing(workdayListing{ExternalPath: "/job/1", PostedOn: "Posted Today"})
+	if want := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC); job.PostedAt == nil || !job.PostedAt.Equal(want) {
+		t.Errorf("PostedAt = %v, want %v", job.PostedAt, want)
+	}
+}
+
 // newWorkdayTestAdapter creates a WorkdayAdapter wired to a test server.
 func newWorkdayTestAdapter(srv *httptest.Server, company string) *WorkdayAdapter {
 	return newWorkdayTestAdapterWithFilter(srv, company, nil)
 }
 
 func newWorkdayTestAdapterWithFilter(srv *httptest.Server, company string, preFilter model.JobFilter) *WorkdayAdapter {
-	a := NewWorkdayAdapter(srv.URL, company, srv.Client(), preFilter, slog.Default())
+	a := NewWorkdayAdapter(srv.URL, company, WorkdaySearch{}, srv.Client(), preFilter, slog.Default())
 	a.client = &http.Client{
 		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
 			req.URL.Scheme = "http"
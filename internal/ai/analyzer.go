@@ -6,27 +6,42 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"text/template"
+	"unicode/utf8"
 
 	"github.com/amishk599/firstin/internal/model"
 )
 
+// DefaultMaxDescriptionChars caps the description sent to the LLM unless
+// overridden with SetMaxDescriptionChars.
+const DefaultMaxDescriptionChars = 6000
+
 // LLMJobAnalyzer implements poller.JobAnalyzer using an LLM.
 type LLMJobAnalyzer struct {
-	provider LLMProvider
-	tmpl     *template.Template
-	logger   *slog.Logger
+	provider            LLMProvider
+	tmpl                *template.Template
+	maxDescriptionChars int // 0 sends descriptions whole
+	logger              *slog.Logger
 }
 
 // NewLLMJobAnalyzer creates an analyzer that enriches jobs with LLM-generated insights.
 func NewLLMJobAnalyzer(provider LLMProvider, tmpl *template.Template, logger *slog.Logger) *LLMJobAnalyzer {
 	return &LLMJobAnalyzer{
-		provider: provider,
-		tmpl:     tmpl,
-		logger:   logger,
+		provider:            provider,
+		tmpl:                tmpl,
+		maxDescriptionChars: DefaultMaxDescriptionChars,
+		logger:              logger,
 	}
 }
 
+// SetMaxDescriptionChars caps the description rendered into the prompt at n
+// characters, keeping the start of the text, to bound token use on very long
+// postings. Zero disables truncation.
+func (a *LLMJobAnalyzer) SetMaxDescriptionChars(n int) {
+	a.maxDescriptionChars = n
+}
+
 // Analyze enriches job with AI-generated insights. Returns the original job unchanged
 // when the description is unavailable or the LLM call fails.
 func (a *LLMJobAnalyzer) Analyze(ctx context.Context, job model.Job) (model.Job, error) {
@@ -34,9 +49,22 @@ func (a *LLMJobAnalyzer) Analyze(ctx context.Context, job model.Job) (model.Job,
 		return job, nil
 	}
 
+	description := job.Detail.Description
+	if a.maxDescriptionChars > 0 {
+		if n := utf8.RuneCountInString(description); n > a.maxDescriptionChars {
+			description = string([]rune(description)[:a.maxDescriptionChars])
+			a.logger.Info("truncated job description for ai analysis",
+				"company", job.Company,
+				"job_id", job.ID,
+				"chars", n,
+				"max_chars", a.maxDescriptionChars,
+			)
+		}
+	}
+
 	var promptBuf bytes.Buffer
 	if err := a.tmpl.Execute(&promptBuf, struct{ Description string }{
-		Description: job.Detail.Description,
+		Description: description,
 	}); err != nil {
 		return job, fmt.Errorf("render prompt: %w", err)
 	}
@@ -58,9 +86,15 @@ func (a *LLMJobAnalyzer) Analyze(ctx context.Context, job model.Job) (model.Job,
 // rawInsights is the JSON shape returned by the LLM (matches jobInsightsSchema).
 type rawInsights struct {
 	RoleType  string   `json:"role_type"`
+	Seniority string   `json:"seniority"`
 	YearsExp  string   `json:"years_exp"`
 	TechStack []string `json:"tech_stack"`
 	KeyPoints []string `json:"key_points"`
+
+	// Optional salary fields; null or absent when the description has no salary.
+	SalaryMin      *float64 `json:"salary_min"`
+	SalaryMax      *float64 `json:"salary_max"`
+	SalaryCurrency *string  `json:"salary_currency"`
 }
 
 // parseInsights deserializes the LLM response into a JobInsights struct.
@@ -74,6 +108,7 @@ func parseInsights(raw string) (*model.JobInsights, error) {
 
 	insights := &model.JobInsights{
 		RoleType:  ri.RoleType,
+		Seniority: ri.Seniority,
 		YearsExp:  ri.YearsExp,
 		TechStack: ri.TechStack,
 	}
@@ -83,6 +118,23 @@ func parseInsights(raw string) (*model.JobInsights, error) {
 		insights.KeyPoints[i] = ri.KeyPoints[i]
 	}
 
+	if ri.SalaryMin != nil && *ri.SalaryMin > 0 {
+		insights.SalaryMin = *ri.SalaryMin
+	}
+	if ri.SalaryMax != nil && *ri.SalaryMax > 0 {
+		insights.SalaryMax = *ri.SalaryMax
+	}
+	// A single stated figure ("$150,000/yr") is treated as both ends of the range.
+	if insights.SalaryMax == 0 {
+		insights.SalaryMax = insights.SalaryMin
+	}
+	if insights.SalaryMin == 0 {
+		insights.SalaryMin = insights.SalaryMax
+	}
+	if ri.SalaryCurrency != nil && insights.SalaryMax > 0 {
+		insights.SalaryCurrency = strings.ToUpper(strings.TrimSpace(*ri.SalaryCurrency))
+	}
+
 	// Cap tech stack at 8 items as a defensive guard.
 	if len(insights.TechStack) > 8 {
 		insights.TechStack = insights.TechStack[:8]
@@ -3,6 +3,11 @@ package ai
 import (
 	"context"
 	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"text/template"
 
@@ -19,6 +24,17 @@ func (m *mockProvider) Complete(_ context.Context, _ string) (string, error) {
 	return m.response, m.err
 }
 
+// recordingProvider captures the last prompt it was asked to complete.
+type recordingProvider struct {
+	prompt   string
+	response string
+}
+
+func (r *recordingProvider) Complete(_ context.Context, prompt string) (string, error) {
+	r.prompt = prompt
+	return r.response, nil
+}
+
 func newTestAnalyzer(provider LLMProvider) *LLMJobAnalyzer {
 	tmpl := template.Must(template.New("test").Parse("desc: {{.Description}}"))
 	return NewLLMJobAnalyzer(provider, tmpl, nil)
@@ -111,3 +127,174 @@ func TestParseInsights_CapsTechStackAtEight(t *testing.T) {
 		t.Errorf("TechStack len = %d, want 8 (capped)", len(insights.TechStack))
 	}
 }
+
+func TestAnalyze_ExtractsSalaryFromDescription(t *testing.T) {
+	desc := "We are hiring a backend engineer. The base salary range for this role is $150,000 - $210,000 USD per year."
+	respJSON := `{
+		"role_type": "backend",
+		"years_exp": "not specified",
+		"tech_stack": ["Go"],
+		"key_points": ["a", "b", "c"],
+		"salary_min": 150000,
+		"salary_max": 210000,
+		"salary_currency": "usd"
+	}`
+	analyzer := newTestAnalyzer(&mockProvider{response: respJSON})
+
+	result, err := analyzer.Analyze(context.Background(), jobWithDesc(desc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Insights == nil {
+		t.Fatal("expected non-nil Insights")
+	}
+	if result.Insights.SalaryMin != 150000 {
+		t.Errorf("SalaryMin = %v, want 150000", result.Insights.SalaryMin)
+	}
+	if result.Insights.SalaryMax != 210000 {
+		t.Errorf("SalaryMax = %v, want 210000", result.Insights.SalaryMax)
+	}
+	if result.Insights.SalaryCurrency != "USD" {
+		t.Errorf("SalaryCurrency = %q, want USD", result.Insights.SalaryCurrency)
+	}
+}
+
+func TestParseInsights_SalaryAbsentOrNull(t *testing.T) {
+	inputs := map[string]string{
+		"absent": `{"role_type":"backend","years_exp":"not specified","tech_stack":[],"key_points":["a","b","c"]}`,
+		"null":   `{"role_type":"backend","years_exp":"not specified","tech_stack":[],"key_points":["a","b","c"],"salary_min":null,"salary_max":null,"salary_currency":null}`,
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			insights, err := parseInsights(input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if insights.SalaryMin != 0 || insights.SalaryMax != 0 || insights.SalaryCurrency != "" {
+				t.Errorf("salary = %v-%v %q, want zero values", insights.SalaryMin, insights.SalaryMax, insights.SalaryCurrency)
+			}
+		})
+	}
+}
+
+func TestParseInsights_SingleSalaryFigureFillsBothEnds(t *testing.T) {
+	input := `{"role_type":"backend","years_exp":"5+ years","tech_stack":[],"key_points":["a","b","c"],"salary_min":180000,"salary_max":null,"salary_currency":"EUR"}`
+
+	insights, err := parseInsights(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if insights.SalaryMin != 180000 || insights.SalaryMax != 180000 {
+		t.Errorf("salary = %v-%v, want 180000-180000", insights.SalaryMin, insights.SalaryMax)
+	}
+	if insights.SalaryCurrency != "EUR" {
+		t.Errorf("SalaryCurrency = %q, want EUR", insights.SalaryCurrency)
+	}
+}
+
+func TestParseInsights_Seniority(t *testing.T) {
+	input := `{"role_type":"backend","seniority":"senior","years_exp":"8+ years","tech_stack":["Go"],"key_points":["a","b","c"]}`
+
+	insights, err := parseInsights(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if insights.Seniority != "senior" {
+		t.Errorf("Seniority = %q, want senior", insights.Seniority)
+	}
+}
+
+func TestJobInsightsSchema_SeniorityEnum(t *testing.T) {
+	props := jobInsightsSchema["properties"].(map[string]any)
+	seniority, ok := props["seniority"].(map[string]any)
+	if !ok {
+		t.Fatal("jobInsightsSchema missing seniority property")
+	}
+	enum, _ := seniority["enum"].([]string)
+	if len(enum) != len(model.SeniorityLevels) {
+		t.Errorf("seniority enum = %v, want %v", enum, model.SeniorityLevels)
+	}
+}
+
+func TestLoadPromptTemplate_CustomFileRendersIntoPrompt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompt.tmpl")
+	custom := "CUSTOM PROMPT: focus on on-call load.\n---\n{{.Description}}"
+	if err := os.WriteFile(path, []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := LoadPromptTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadPromptTemplate: %v", err)
+	}
+
+	provider := &recordingProvider{response: `{"role_type":"backend","years_exp":"","tech_stack":[],"key_points":["a","b","c"]}`}
+	analyzer := NewLLMJobAnalyzer(provider, tmpl, nil)
+	if _, err := analyzer.Analyze(context.Background(), jobWithDesc("pager rotation weekly")); err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	if !strings.Contains(provider.prompt, "CUSTOM PROMPT: focus on on-call load.") {
+		t.Errorf("prompt = %q, want custom template text", provider.prompt)
+	}
+	if !strings.Contains(provider.prompt, "pager rotation weekly") {
+		t.Errorf("prompt = %q, want rendered description", provider.prompt)
+	}
+}
+
+func TestLoadPromptTemplate_EmptyPathUsesBuiltin(t *testing.T) {
+	tmpl, err := LoadPromptTemplate("")
+	if err != nil {
+		t.Fatalf("LoadPromptTemplate: %v", err)
+	}
+	if tmpl != JobAnalysisTemplate {
+		t.Error("expected built-in JobAnalysisTemplate for empty path")
+	}
+}
+
+func TestLoadPromptTemplate_InvalidTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Description"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPromptTemplate(path); err == nil {
+		t.Fatal("expected parse error for malformed template")
+	}
+}
+
+func TestAnalyze_TruncatesLongDescription(t *testing.T) {
+	provider := &recordingProvider{response: `{"role_type":"backend","years_exp":"","tech_stack":[],"key_points":["a","b","c"]}`}
+	tmpl := template.Must(template.New("test").Parse("desc: {{.Description}}"))
+	analyzer := NewLLMJobAnalyzer(provider, tmpl, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	analyzer.SetMaxDescriptionChars(10)
+
+	// Multi-byte runes make sure the cut lands on a character boundary.
+	if _, err := analyzer.Analyze(context.Background(), jobWithDesc("héllo wörld, and much more text")); err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if want := "desc: héllo wörl"; provider.prompt != want {
+		t.Errorf("prompt = %q, want %q", provider.prompt, want)
+	}
+
+	analyzer.SetMaxDescriptionChars(0)
+	long := strings.Repeat("x", DefaultMaxDescriptionChars+100)
+	if _, err := analyzer.Analyze(context.Background(), jobWithDesc(long)); err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if provider.prompt != "desc: "+long {
+		t.Errorf("prompt length = %d with truncation disabled, want %d", len(provider.prompt), len("desc: "+long))
+	}
+}
+
+func TestNewLLMJobAnalyzer_DefaultTruncation(t *testing.T) {
+	provider := &recordingProvider{response: `{"role_type":"backend","years_exp":"","tech_stack":[],"key_points":["a","b","c"]}`}
+	tmpl := template.Must(template.New("test").Parse("{{.Description}}"))
+	analyzer := NewLLMJobAnalyzer(provider, tmpl, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if _, err := analyzer.Analyze(context.Background(), jobWithDesc(strings.Repeat("x", 20000))); err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if len(provider.prompt) != DefaultMaxDescriptionChars {
+		t.Errorf("prompt length = %d, want %d", len(provider.prompt), DefaultMaxDescriptionChars)
+	}
+}
@@ -15,6 +15,11 @@ func NewNopJobAnalyzer() *NopJobAnalyzer {
 	return &NopJobAnalyzer{}
 }
 
+// Disabled reports true: the analyzer never adds insights.
+func (n *NopJobAnalyzer) Disabled() bool {
+	return true
+}
+
 // Analyze returns the job unchanged.
 func (n *NopJobAnalyzer) Analyze(_ context.Context, job model.Job) (model.Job, error) {
 	return job, nil
@@ -6,7 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/retry"
 )
 
 // jobInsightsSchema is the JSON Schema enforced server-side via OpenAI structured outputs.
@@ -23,6 +27,10 @@ var jobInsightsSchema = map[string]any{
 				"data", "security", "mobile", "other",
 			},
 		},
+		"seniority": map[string]any{
+			"type": "string",
+			"enum": model.SeniorityLevels,
+		},
 		"years_exp": map[string]any{"type": "string"},
 		"tech_stack": map[string]any{
 			"type":  "array",
@@ -34,28 +42,41 @@ var jobInsightsSchema = map[string]any{
 			"minItems": 3,
 			"maxItems": 3,
 		},
+		// Optional: only present when the description states a salary range.
+		"salary_min":      map[string]any{"type": []string{"number", "null"}},
+		"salary_max":      map[string]any{"type": []string{"number", "null"}},
+		"salary_currency": map[string]any{"type": []string{"string", "null"}},
 	},
-	"required": []string{"role_type", "years_exp", "tech_stack", "key_points"},
+	"required": []string{"role_type", "seniority", "years_exp", "tech_stack", "key_points"},
 }
 
 // OpenAIProvider calls the OpenAI /v1/chat/completions endpoint with structured outputs.
+// It accumulates token usage across calls; see Usage.
 type OpenAIProvider struct {
 	baseURL    string
 	apiKey     string
 	model      string
 	httpClient *http.Client
+	usage      usageTracker
+	logger     *slog.Logger
 }
 
 // NewOpenAIProvider creates a provider targeting the OpenAI API.
-func NewOpenAIProvider(baseURL, apiKey, model string, httpClient *http.Client) *OpenAIProvider {
+func NewOpenAIProvider(baseURL, apiKey, model string, httpClient *http.Client, logger *slog.Logger) *OpenAIProvider {
 	return &OpenAIProvider{
 		baseURL:    baseURL,
 		apiKey:     apiKey,
 		model:      model,
 		httpClient: httpClient,
+		logger:     logger,
 	}
 }
 
+// Usage returns the cumulative token usage of all completions made so far.
+func (p *OpenAIProvider) Usage() TokenUsage {
+	return p.usage.snapshot()
+}
+
 // chatRequest mirrors the OpenAI /v1/chat/completions request body.
 type chatRequest struct {
 	Model          string         `json:"model"`
@@ -87,12 +108,20 @@ type chatResponse struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage *chatUsage `json:"usage,omitempty"`
 	Error *struct {
 		Message string `json:"message"`
 		Type    string `json:"type"`
 	} `json:"error,omitempty"`
 }
 
+// chatUsage mirrors the OpenAI usage object.
+type chatUsage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
 // Complete sends prompt to OpenAI and returns a guaranteed-valid JSON string
 // conforming to jobInsightsSchema. No markdown stripping required.
 func (p *OpenAIProvider) Complete(ctx context.Context, prompt string) (string, error) {
@@ -138,7 +167,11 @@ func (p *OpenAIProvider) Complete(ctx context.Context, prompt string) (string, e
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("llm returned HTTP %d: %s", resp.StatusCode, string(respBytes))
+		return "", &model.HTTPError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
+			Err:        fmt.Errorf("llm request: unexpected status %d: %s", resp.StatusCode, string(respBytes)),
+		}
 	}
 
 	var chatResp chatResponse
@@ -150,6 +183,21 @@ func (p *OpenAIProvider) Complete(ctx context.Context, prompt string) (string, e
 		return "", fmt.Errorf("llm error (%s): %s", chatResp.Error.Type, chatResp.Error.Message)
 	}
 
+	if chatResp.Usage != nil {
+		u := TokenUsage{
+			PromptTokens:     chatResp.Usage.PromptTokens,
+			CompletionTokens: chatResp.Usage.CompletionTokens,
+			TotalTokens:      chatResp.Usage.TotalTokens,
+		}
+		p.usage.add(u)
+		p.logger.Info("llm usage",
+			"model", p.model,
+			"prompt_tokens", u.PromptTokens,
+			"completion_tokens", u.CompletionTokens,
+			"total_tokens", u.TotalTokens,
+		)
+	}
+
 	if len(chatResp.Choices) == 0 {
 		return "", fmt.Errorf("llm returned no choices")
 	}
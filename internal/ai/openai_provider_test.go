@@ -1,13 +1,21 @@
 package ai
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
 func makeTestServer(t *testing.T, statusCode int, body any) (*httptest.Server, *http.Client) {
 	t.Helper()
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -35,7 +43,7 @@ func TestComplete_Success(t *testing.T) {
 	}
 	srv, client := makeTestServer(t, http.StatusOK, resp)
 
-	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client)
+	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client, discardLogger())
 	got, err := provider.Complete(context.Background(), "analyze this")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -48,7 +56,7 @@ func TestComplete_Success(t *testing.T) {
 func TestComplete_HTTPError(t *testing.T) {
 	srv, client := makeTestServer(t, http.StatusInternalServerError, map[string]string{"error": "server error"})
 
-	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client)
+	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client, discardLogger())
 	_, err := provider.Complete(context.Background(), "analyze this")
 	if err == nil {
 		t.Fatal("expected error on 5xx response")
@@ -58,7 +66,7 @@ func TestComplete_HTTPError(t *testing.T) {
 func TestComplete_RateLimited(t *testing.T) {
 	srv, client := makeTestServer(t, http.StatusTooManyRequests, map[string]string{"error": "rate limited"})
 
-	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client)
+	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client, discardLogger())
 	_, err := provider.Complete(context.Background(), "analyze this")
 	if err == nil {
 		t.Fatal("expected error on 429 response")
@@ -69,7 +77,7 @@ func TestComplete_EmptyChoices(t *testing.T) {
 	resp := chatResponse{Choices: nil}
 	srv, client := makeTestServer(t, http.StatusOK, resp)
 
-	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client)
+	provider := NewOpenAIProvider(srv.URL, "test-key", "test-model", client, discardLogger())
 	_, err := provider.Complete(context.Background(), "analyze this")
 	if err == nil {
 		t.Fatal("expected error when LLM returns no choices")
@@ -96,7 +104,7 @@ func TestComplete_SetsAuthHeader(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	provider := NewOpenAIProvider(srv.URL, "my-secret-key", "test-model", srv.Client())
+	provider := NewOpenAIProvider(srv.URL, "my-secret-key", "test-model", srv.Client(), discardLogger())
 	_, _ = provider.Complete(context.Background(), "hello")
 
 	if gotAuth != "Bearer my-secret-key" {
@@ -126,7 +134,7 @@ func TestComplete_SendsStructuredOutputFormat(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	provider := NewOpenAIProvider(srv.URL, "key", "gpt-4o-mini", srv.Client())
+	provider := NewOpenAIProvider(srv.URL, "key", "gpt-4o-mini", srv.Client(), discardLogger())
 	_, _ = provider.Complete(context.Background(), "analyze this")
 
 	if gotReq.ResponseFormat.Type != "json_schema" {
@@ -139,3 +147,39 @@ func TestComplete_SendsStructuredOutputFormat(t *testing.T) {
 		t.Errorf("temperature = %d, want 0", gotReq.Temperature)
 	}
 }
+
+func TestComplete_RecordsTokenUsage(t *testing.T) {
+	body := `{
+		"choices": [{"message": {"content": "{}"}}],
+		"usage": {"prompt_tokens": 812, "completion_tokens": 96, "total_tokens": 908}
+	}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	var logBuf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logBuf, nil))
+	provider := NewOpenAIProvider(srv.URL, "key", "gpt-4o-mini", srv.Client(), logger)
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.Complete(context.Background(), "analyze this"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got := provider.Usage()
+	want := TokenUsage{PromptTokens: 1624, CompletionTokens: 192, TotalTokens: 1816, Calls: 2}
+	if got != want {
+		t.Errorf("Usage() = %+v, want %+v", got, want)
+	}
+	if !strings.Contains(logBuf.String(), "total_tokens=908") {
+		t.Errorf("expected per-call usage log line, got %q", logBuf.String())
+	}
+
+	// 1624 prompt tokens at $0.15/1M + 192 completion tokens at $0.60/1M.
+	if cost := got.EstimatedCost(0.15, 0.60); cost < 0.000358 || cost > 0.000359 {
+		t.Errorf("EstimatedCost = %v, want ~0.0003588", cost)
+	}
+}
@@ -2,6 +2,9 @@ package ai
 
 import (
 	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
 	"text/template"
 )
 
@@ -11,3 +14,21 @@ var jobAnalysisPromptRaw string
 // JobAnalysisTemplate is the parsed prompt template for job analysis.
 // Parsed once at package init; reused on every Analyze call.
 var JobAnalysisTemplate = template.Must(template.New("job_analysis").Parse(jobAnalysisPromptRaw))
+
+// LoadPromptTemplate parses the text/template file at path for use with
+// NewLLMJobAnalyzer. The template receives a struct with a Description field.
+// An empty path returns the built-in JobAnalysisTemplate.
+func LoadPromptTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return JobAnalysisTemplate, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read prompt template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse prompt template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
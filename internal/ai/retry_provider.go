@@ -0,0 +1,45 @@
+package ai
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/amishk599/firstin/internal/retry"
+)
+
+// RetryProvider is a decorator that retries transient LLM failures (HTTP 429,
+// 5xx, network errors) with the same backoff policy as retry.RetryFetcher,
+// honoring Retry-After on 429 responses.
+type RetryProvider struct {
+	inner      LLMProvider
+	maxRetries int
+	baseDelay  time.Duration
+	logger     *slog.Logger
+}
+
+// NewRetryProvider wraps an LLMProvider with retry logic.
+// maxRetries is the number of additional attempts after the first failure.
+// baseDelay is the delay before the first retry, doubled on each subsequent retry.
+func NewRetryProvider(inner LLMProvider, maxRetries int, baseDelay time.Duration, logger *slog.Logger) *RetryProvider {
+	return &RetryProvider{
+		inner:      inner,
+		maxRetries: maxRetries,
+		baseDelay:  baseDelay,
+		logger:     logger,
+	}
+}
+
+// Complete calls the wrapped provider, retrying on transient errors.
+func (p *RetryProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	var out string
+	err := retry.Do(ctx, p.maxRetries, p.baseDelay, p.logger, func(ctx context.Context) error {
+		var err error
+		out, err = p.inner.Complete(ctx, prompt)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestRetryProvider_429ThenSuccess(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"message":"rate limited"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"content":"ok"}}]}`))
+	}))
+	defer srv.Close()
+
+	inner := NewOpenAIProvider(srv.URL, "key", "test-model", srv.Client(), discardLogger())
+	provider := NewRetryProvider(inner, 2, 10*time.Millisecond, discardLogger())
+
+	got, err := provider.Complete(context.Background(), "analyze this")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "ok" {
+		t.Errorf("got %q, want ok", got)
+	}
+	if c := calls.Load(); c != 2 {
+		t.Errorf("expected 2 HTTP calls (429 + retry), got %d", c)
+	}
+}
+
+func TestRetryProvider_Persistent500GivesUp(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	inner := NewOpenAIProvider(srv.URL, "key", "test-model", srv.Client(), discardLogger())
+	provider := NewRetryProvider(inner, 2, 10*time.Millisecond, discardLogger())
+
+	_, err := provider.Complete(context.Background(), "analyze this")
+	if err == nil {
+		t.Fatal("expected error after persistent 500s")
+	}
+	var httpErr *model.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 500 {
+		t.Errorf("expected HTTPError 500, got %v", err)
+	}
+	// 1 initial + 2 retries = 3
+	if c := calls.Load(); c != 3 {
+		t.Errorf("expected 3 HTTP calls, got %d", c)
+	}
+}
+
+func TestRetryProvider_DoesNotRetry4xx(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	inner := NewOpenAIProvider(srv.URL, "bad-key", "test-model", srv.Client(), discardLogger())
+	provider := NewRetryProvider(inner, 2, 10*time.Millisecond, discardLogger())
+
+	if _, err := provider.Complete(context.Background(), "analyze this"); err == nil {
+		t.Fatal("expected error on 401")
+	}
+	if c := calls.Load(); c != 1 {
+		t.Errorf("expected 1 HTTP call (no retry on 401), got %d", c)
+	}
+}
@@ -0,0 +1,38 @@
+package ai
+
+import "sync"
+
+// TokenUsage counts tokens consumed by LLM calls, mirroring OpenAI's usage object.
+type TokenUsage struct {
+	PromptTokens     int64
+	CompletionTokens int64
+	TotalTokens      int64
+	Calls            int64 // number of completions that reported usage
+}
+
+// EstimatedCost returns the approximate USD cost of u given per-million-token
+// prices for prompt (input) and completion (output) tokens.
+func (u TokenUsage) EstimatedCost(inputPer1M, outputPer1M float64) float64 {
+	return float64(u.PromptTokens)/1e6*inputPer1M + float64(u.CompletionTokens)/1e6*outputPer1M
+}
+
+// usageTracker accumulates TokenUsage across concurrent calls.
+type usageTracker struct {
+	mu    sync.Mutex
+	total TokenUsage
+}
+
+func (t *usageTracker) add(u TokenUsage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total.PromptTokens += u.PromptTokens
+	t.total.CompletionTokens += u.CompletionTokens
+	t.total.TotalTokens += u.TotalTokens
+	t.total.Calls++
+}
+
+func (t *usageTracker) snapshot() TokenUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
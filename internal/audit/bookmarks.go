@@ -0,0 +1,159 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// DefaultBookmarksPath is where the audit TUI keeps bookmarks, next to jobs.db.
+const DefaultBookmarksPath = "bookmarks.json"
+
+// bookmark is the persisted form of a bookmarked job.
+type bookmark struct {
+	Company      string     `json:"company"`
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Location     string     `json:"location"`
+	URL          string     `json:"url"`
+	PostedAt     *time.Time `json:"posted_at"`
+	BookmarkedAt time.Time  `json:"bookmarked_at"`
+}
+
+// Bookmarks is the set of jobs saved from the audit TUI, persisted as a JSON
+// array on every change so they survive across companies and sessions. Jobs
+// are keyed by company and ID. A nil *Bookmarks holds nothing.
+type Bookmarks struct {
+	path  string
+	marks []bookmark
+}
+
+// LoadBookmarks reads the bookmarks saved at path. A missing file is an
+// empty set.
+func LoadBookmarks(path string) (*Bookmarks, error) {
+	b := &Bookmarks{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading bookmarks: %w", err)
+	}
+	if err := json.Unmarshal(data, &b.marks); err != nil {
+		return nil, fmt.Errorf("decoding bookmarks %s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Has reports whether j is bookmarked.
+func (b *Bookmarks) Has(j model.Job) bool {
+	return b.index(j) >= 0
+}
+
+// Len returns the number of bookmarks.
+func (b *Bookmarks) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.marks)
+}
+
+// Toggle bookmarks j, or removes its bookmark, and saves the set. It reports
+// whether j is bookmarked afterwards. On a save error the change is undone.
+func (b *Bookmarks) Toggle(j model.Job) (bool, error) {
+	prev := b.marks
+	if i := b.index(j); i >= 0 {
+		b.marks = append(b.marks[:i:i], b.marks[i+1:]...)
+	} else {
+		b.marks = append(b.marks[:len(b.marks):len(b.marks)], bookmark{
+			Company:      j.Company,
+			ID:           j.ID,
+			Title:        j.Title,
+			Location:     j.Location,
+			URL:          j.URL,
+			PostedAt:     j.PostedAt,
+			BookmarkedAt: time.Now().UTC(),
+		})
+	}
+	if err := b.save(); err != nil {
+		b.marks = prev
+		return b.Has(j), err
+	}
+	return b.Has(j), nil
+}
+
+func (b *Bookmarks) index(j model.Job) int {
+	if b == nil {
+		return -1
+	}
+	for i, m := range b.marks {
+		if m.Company == j.Company && m.ID == j.ID {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes the set to a temp file and renames it over path, so a crash
+// never leaves a truncated file.
+func (b *Bookmarks) save() error {
+	marks := b.marks
+	if marks == nil {
+		marks = []bookmark{}
+	}
+	data, err := json.MarshalIndent(marks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding bookmarks: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), ".bookmarks-*")
+	if err != nil {
+		return fmt.Errorf("saving bookmarks: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("saving bookmarks: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("saving bookmarks: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), b.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("saving bookmarks: %w", err)
+	}
+	return nil
+}
+
+// ExportCSV writes every bookmark to path as CSV, oldest bookmark first.
+// Times are RFC 3339 in UTC; a missing posted_at is left blank.
+func (b *Bookmarks) ExportCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	cw := csv.NewWriter(f)
+	cw.Write([]string{"company", "title", "location", "url", "posted_at", "bookmarked_at"})
+	if b != nil {
+		for _, m := range b.marks {
+			postedAt := ""
+			if m.PostedAt != nil {
+				postedAt = m.PostedAt.UTC().Format(time.RFC3339)
+			}
+			cw.Write([]string{m.Company, m.Title, m.Location, m.URL, postedAt, m.BookmarkedAt.Format(time.RFC3339)})
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestBookmarks_TogglePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	b, err := LoadBookmarks(path)
+	if err != nil {
+		t.Fatalf("LoadBookmarks on missing file: %v", err)
+	}
+
+	job := model.Job{ID: "1", Company: "acme", Title: "Backend Engineer", URL: "https://example.com/1"}
+	sameIDOtherCompany := model.Job{ID: "1", Company: "beta", Title: "Designer"}
+
+	if marked, err := b.Toggle(job); err != nil || !marked {
+		t.Fatalf("Toggle = %v, %v; want true, nil", marked, err)
+	}
+	if b.Has(sameIDOtherCompany) {
+		t.Error("bookmark matched a job with the same ID at another company")
+	}
+
+	reloaded, err := LoadBookmarks(path)
+	if err != nil {
+		t.Fatalf("LoadBookmarks: %v", err)
+	}
+	if !reloaded.Has(job) || reloaded.Len() != 1 {
+		t.Fatalf("reloaded bookmarks: Has = %v, Len = %d; want true, 1", reloaded.Has(job), reloaded.Len())
+	}
+
+	if marked, err := reloaded.Toggle(job); err != nil || marked {
+		t.Fatalf("second Toggle = %v, %v; want false, nil", marked, err)
+	}
+	reloaded, err = LoadBookmarks(path)
+	if err != nil {
+		t.Fatalf("LoadBookmarks: %v", err)
+	}
+	if reloaded.Len() != 0 {
+		t.Errorf("Len after removing = %d, want 0", reloaded.Len())
+	}
+}
+
+func TestLoadBookmarks_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBookmarks(path); err == nil {
+		t.Error("expected error for corrupt bookmarks file")
+	}
+}
+
+func TestBookmarks_ExportCSV(t *testing.T) {
+	dir := t.TempDir()
+	b, err := LoadBookmarks(filepath.Join(dir, "bookmarks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, j := range []model.Job{
+		{ID: "1", Company: "acme", Title: "Backend Engineer", Location: "Remote", URL: "https://example.com/1"},
+		{ID: "2", Company: "beta", Title: "SRE, Platform", URL: "https://example.com/2"},
+	} {
+		if _, err := b.Toggle(j); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := filepath.Join(dir, "bookmarks.csv")
+	if err := b.ExportCSV(out); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d rows, want header + 2", len(records))
+	}
+	if records[2][0] != "beta" || records[2][1] != "SRE, Platform" {
+		t.Errorf("row 2 = %v, want beta's job in bookmark order", records[2])
+	}
+}
+
+func TestAuditModel_SpaceTogglesBookmark(t *testing.T) {
+	b, err := LoadBookmarks(filepath.Join(t.TempDir(), "bookmarks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs := []model.Job{
+		{ID: "1", Company: "acme", Title: "Backend Engineer"},
+		{ID: "2", Company: "acme", Title: "Frontend Engineer"},
+	}
+	m := auditModel{allJobs: jobs, leftCursor: 1, bookmarks: b}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	next, _ := m.updateListView(space)
+	m = next.(auditModel)
+	if !b.Has(jobs[1]) || b.Has(jobs[0]) {
+		t.Fatalf("after space: Has(cursor job) = %v, Has(other) = %v; want true, false", b.Has(jobs[1]), b.Has(jobs[0]))
+	}
+	rendered := renderJobs(jobs, 0, true, b, nil)
+	if strings.Contains(rendered, bookmarkMarker+"Backend") || !strings.Contains(rendered, bookmarkMarker+"Frontend") {
+		t.Errorf("renderJobs marker on wrong job:\n%s", rendered)
+	}
+
+	next, _ = m.updateListView(space)
+	m = next.(auditModel)
+	if b.Has(jobs[1]) {
+		t.Error("second space did not remove the bookmark")
+	}
+	if m.notice == "" {
+		t.Error("expected a status notice after toggling")
+	}
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// jobMarkdown formats j as a markdown block for pasting into Slack, Notion
+// and the like: title linked to the job, company and location, pay, the
+// apply link when it differs, and the AI key points when present.
+func jobMarkdown(j model.Job, fxRates map[string]float64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### [%s](%s)\n", j.Title, j.URL)
+	b.WriteString("**" + j.Company + "**")
+	if j.Location != "" {
+		b.WriteString(" · " + j.Location)
+	}
+	b.WriteString("\n")
+
+	var details []string
+	for _, f := range payFields(j, fxRates) {
+		details = append(details, fmt.Sprintf("- **%s:** %s", f.label, f.value))
+	}
+	if j.Detail != nil && j.Detail.ApplyURL != "" && j.Detail.ApplyURL != j.URL {
+		details = append(details, "- **Apply:** "+j.Detail.ApplyURL)
+	}
+	if len(details) > 0 {
+		b.WriteString("\n" + strings.Join(details, "\n") + "\n")
+	}
+
+	if j.Insights != nil {
+		var points []string
+		for _, pt := range j.Insights.KeyPoints {
+			if pt != "" {
+				points = append(points, "- "+pt)
+			}
+		}
+		if len(points) > 0 {
+			b.WriteString("\n**Key points**\n" + strings.Join(points, "\n") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// copyToClipboard writes text to the system clipboard via the platform's
+// clipboard command.
+func copyToClipboard(text string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("pbcopy")
+	case "linux":
+		if _, err := exec.LookPath("wl-copy"); err == nil {
+			cmd = exec.Command("wl-copy")
+		} else {
+			cmd = exec.Command("xclip", "-selection", "clipboard")
+		}
+	case "windows":
+		cmd = exec.Command("clip")
+	default:
+		return errors.New("no clipboard command for " + runtime.GOOS)
+	}
+	cmd.Stdin = bytes.NewBufferString(text)
+	return cmd.Run()
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestJobMarkdown_FullJob(t *testing.T) {
+	j := model.Job{
+		ID:       "42",
+		Company:  "Acme",
+		Title:    "Senior Backend Engineer",
+		Location: "Remote, US",
+		URL:      "https://boards.example.com/acme/42",
+		Detail: &model.JobDetail{
+			ApplyURL: "https://boards.example.com/acme/42/apply",
+			PayRanges: []model.PayRange{
+				{Title: "US Base", MinCents: 15000000, MaxCents: 20000000, CurrencyType: "USD"},
+				{MinCents: 10000000, MaxCents: 12000000, CurrencyType: "EUR"},
+			},
+		},
+		Insights: &model.JobInsights{
+			KeyPoints: [3]string{"Owns the billing pipeline", "Go and Postgres", "On-call one week in six"},
+		},
+	}
+
+	want := `### [Senior Backend Engineer](https://boards.example.com/acme/42)
+**Acme** · Remote, US
+
+- **US Base:** USD $150000 - $200000
+- **Pay Range:** EUR $100000 - $120000 (~$108000–$129600 USD)
+- **Apply:** https://boards.example.com/acme/42/apply
+
+**Key points**
+- Owns the billing pipeline
+- Go and Postgres
+- On-call one week in six
+`
+	got := jobMarkdown(j, map[string]float64{"EUR": 1.08})
+	if got != want {
+		t.Errorf("jobMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJobMarkdown_MinimalJob(t *testing.T) {
+	j := model.Job{Company: "Acme", Title: "Engineer", URL: "https://example.com/1"}
+	want := "### [Engineer](https://example.com/1)\n**Acme**\n"
+	if got := jobMarkdown(j, nil); got != want {
+		t.Errorf("jobMarkdown() = %q, want %q", got, want)
+	}
+}
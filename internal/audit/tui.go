@@ -3,6 +3,7 @@ package audit
 import (
 	"context"
 	"fmt"
+	"math"
 	"os/exec"
 	"runtime"
 	"sort"
@@ -18,15 +19,41 @@ import (
 	"github.com/amishk599/firstin/internal/poller"
 )
 
-var pst = time.FixedZone("PST", -8*60*60)
+// fmtLocalTime formats t in the user's local time zone (time.Local, which
+// honours TZ), so daylight time and non-US users see their own clock.
+func fmtLocalTime(t *time.Time, layout string) string {
+	return t.In(time.Local).Format(layout)
+}
 
-func fmtTimePST(t *time.Time, layout string) string {
-	return t.In(pst).Format(layout)
+// relativeAge formats how long ago t was relative to now, e.g. "45m ago",
+// "3h ago", "2d ago". Returns "n/a" for a nil time and "just now" for anything
+// under a minute (including small clock skew into the future).
+func relativeAge(t *time.Time, now time.Time) string {
+	if t == nil {
+		return "n/a"
+	}
+	d := now.Sub(*t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
 }
 
 // Lines per job item in the list view (title + subtitle + blank separator).
 const jobItemHeight = 3
 
+// bookmarkMarker prefixes bookmarked job titles in the list view.
+const bookmarkMarker = "★ "
+
+// BookmarksExportPath is where the x key writes bookmarks as CSV before quitting.
+const BookmarksExportPath = "bookmarks.csv"
+
 type viewState int
 
 const (
@@ -94,6 +121,13 @@ var (
 
 	descBodyStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("252"))
+
+	newTagStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("42")) // green
+
+	seenTagStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
 )
 
 // detailFetchedMsg is sent when an async detail fetch completes.
@@ -119,6 +153,7 @@ type auditModel struct {
 	width         int
 	height        int
 	filterCfg     config.FilterConfig
+	displayCfg    config.DisplayConfig
 	ready         bool
 
 	// Detail view state
@@ -135,6 +170,19 @@ type auditModel struct {
 	analyzeLoading bool
 	analyzeError   string
 
+	// seen maps job IDs to whether the store has already seen them; nil when
+	// no store was given, which disables the NEW/SEEN markers and filter.
+	seen    map[string]bool
+	newOnly bool // left pane shows only jobs not in the store
+
+	// Bookmarks state; nil disables the bookmark keys.
+	bookmarks *Bookmarks
+	notice    string // last bookmark or copy action or error, shown in the status bar
+
+	// printOnQuit collects markdown copies that couldn't reach the clipboard;
+	// RunAuditTUI writes them to stdout once the alt screen is gone.
+	printOnQuit []string
+
 	wantQuit bool
 }
 
@@ -174,7 +222,7 @@ func (m auditModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.analyzeError = fmt.Sprintf("analysis failed: %v", msg.err)
 		} else if msg.job.Insights == nil {
-			m.analyzeError = "AI enrichment is not enabled — set ai.enabled: true in config.yaml"
+			m.analyzeError = "analysis returned no summary"
 		} else {
 			m.analyzeError = ""
 			m.detailJob = msg.job
@@ -217,6 +265,28 @@ func (m auditModel) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "enter":
 		return m.openDetailView()
+	case "n":
+		if m.seen != nil {
+			m.newOnly = !m.newOnly
+			m.leftCursor = 0
+			m.leftViewport.SetYOffset(0)
+			m.recalcContent()
+		}
+		return m, nil
+	case " ":
+		m.toggleBookmark()
+		m.recalcContent()
+		return m, nil
+	case "x":
+		if m.bookmarks == nil {
+			return m, nil
+		}
+		if err := m.bookmarks.ExportCSV(BookmarksExportPath); err != nil {
+			m.notice = err.Error()
+			return m, nil
+		}
+		m.wantQuit = true
+		return m, tea.Quit
 	}
 
 	// Forward other keys (pgup/pgdn/home/end) to the active viewport.
@@ -236,6 +306,7 @@ func (m auditModel) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	case "esc", "backspace":
 		m.view = viewList
+		m.notice = ""
 		return m, nil
 	case "o":
 		url := m.detailJob.URL
@@ -244,6 +315,15 @@ func (m auditModel) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		openURL(url)
 		return m, nil
+	case "m":
+		md := jobMarkdown(m.detailJob, m.displayCfg.FXRates)
+		if err := copyToClipboard(md); err != nil {
+			m.printOnQuit = append(m.printOnQuit, md)
+			m.notice = "clipboard unavailable, markdown will be printed on quit"
+		} else {
+			m.notice = "copied as markdown"
+		}
+		return m, nil
 	case "r":
 		if m.detailJob.Detail != nil && m.detailJob.Detail.Description != "" {
 			m.showDescription = !m.showDescription
@@ -252,7 +332,7 @@ func (m auditModel) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "s":
-		if m.analyzer != nil && !m.analyzeLoading && m.detailJob.Insights == nil &&
+		if !m.aiDisabled() && !m.analyzeLoading && m.detailJob.Insights == nil &&
 			m.detailJob.Detail != nil && m.detailJob.Detail.Description != "" {
 			m.analyzeLoading = true
 			m.analyzeError = ""
@@ -267,6 +347,37 @@ func (m auditModel) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// toggleBookmark bookmarks or un-bookmarks the job under the cursor.
+func (m *auditModel) toggleBookmark() {
+	jobs := m.activeJobs()
+	if m.bookmarks == nil || len(jobs) == 0 {
+		return
+	}
+	job := jobs[m.activeCursor()]
+	marked, err := m.bookmarks.Toggle(job)
+	switch {
+	case err != nil:
+		m.notice = err.Error()
+	case marked:
+		m.notice = "bookmarked " + job.Title
+	default:
+		m.notice = "removed bookmark " + job.Title
+	}
+}
+
+// aiDisabledHint replaces the summary prompt when there is no AI analyzer.
+const aiDisabledHint = "AI summary disabled — set ai.enabled: true in config.yaml"
+
+// aiDisabled reports whether the 's' summary is unavailable: no analyzer was
+// given, or it is the stand-in used while ai.enabled is false.
+func (m auditModel) aiDisabled() bool {
+	if m.analyzer == nil {
+		return true
+	}
+	d, ok := m.analyzer.(poller.DisabledAnalyzer)
+	return ok && d.Disabled()
+}
+
 func (m auditModel) analyzeJobCmd(job model.Job) tea.Cmd {
 	analyzer := m.analyzer
 	return func() tea.Msg {
@@ -277,7 +388,7 @@ func (m auditModel) analyzeJobCmd(job model.Job) tea.Cmd {
 
 func (m *auditModel) moveCursor(delta int) {
 	if m.activePane == 0 {
-		m.leftCursor = clamp(m.leftCursor+delta, 0, max(len(m.allJobs)-1, 0))
+		m.leftCursor = clamp(m.leftCursor+delta, 0, max(len(m.leftJobs())-1, 0))
 	} else {
 		m.rightCursor = clamp(m.rightCursor+delta, 0, max(len(m.matchedJobs)-1, 0))
 	}
@@ -313,6 +424,7 @@ func (m auditModel) openDetailView() (tea.Model, tea.Cmd) {
 
 	job := jobs[cursor]
 	m.view = viewDetail
+	m.notice = ""
 	m.detailJob = job
 	m.detailError = ""
 	m.showDescription = false
@@ -381,17 +493,32 @@ func (m *auditModel) recalcLayout() {
 }
 
 func (m *auditModel) recalcContent() {
-	m.leftViewport.SetContent(renderJobs(m.allJobs, m.leftCursor, m.activePane == 0))
-	m.rightViewport.SetContent(renderJobs(m.matchedJobs, m.rightCursor, m.activePane == 1))
+	m.leftViewport.SetContent(renderJobs(m.leftJobs(), m.leftCursor, m.activePane == 0, m.bookmarks, m.seen))
+	m.rightViewport.SetContent(renderJobs(m.matchedJobs, m.rightCursor, m.activePane == 1, m.bookmarks, nil))
 }
 
 func (m auditModel) activeJobs() []model.Job {
 	if m.activePane == 0 {
-		return m.allJobs
+		return m.leftJobs()
 	}
 	return m.matchedJobs
 }
 
+// leftJobs returns the All Jobs pane's contents: every job, or only those
+// the store hasn't seen when the NEW filter is on.
+func (m auditModel) leftJobs() []model.Job {
+	if !m.newOnly {
+		return m.allJobs
+	}
+	var jobs []model.Job
+	for _, j := range m.allJobs {
+		if !m.seen[j.ID] {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs
+}
+
 func (m auditModel) activeCursor() int {
 	if m.activePane == 0 {
 		return m.leftCursor
@@ -399,6 +526,20 @@ func (m auditModel) activeCursor() int {
 	return m.rightCursor
 }
 
+// position returns the active pane's cursor as "N/total" (1-based) and how
+// far its viewport is scrolled, e.g. "12/340 35%".
+func (m auditModel) position() string {
+	vp := m.leftViewport
+	if m.activePane == 1 {
+		vp = m.rightViewport
+	}
+	total := len(m.activeJobs())
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d %d%%", m.activeCursor()+1, total, int(math.Round(vp.ScrollPercent()*100)))
+}
+
 func (m auditModel) View() string {
 	if !m.ready {
 		return "Initializing..."
@@ -416,6 +557,18 @@ func (m auditModel) viewList() string {
 
 	// Headers.
 	leftHeader := fmt.Sprintf(" All Jobs (%d)", len(m.allJobs))
+	if m.seen != nil {
+		newCount := 0
+		for _, j := range m.allJobs {
+			if !m.seen[j.ID] {
+				newCount++
+			}
+		}
+		leftHeader = fmt.Sprintf(" All Jobs (%d, %d new)", len(m.allJobs), newCount)
+		if m.newOnly {
+			leftHeader = fmt.Sprintf(" New Jobs (%d of %d)", newCount, len(m.allJobs))
+		}
+	}
 	rightHeader := fmt.Sprintf(" Matched Jobs (%d)", len(m.matchedJobs))
 
 	var leftHeaderRendered, rightHeaderRendered string
@@ -449,8 +602,18 @@ func (m auditModel) viewList() string {
 
 	// Status bar.
 	filteredCount := len(m.allJobs) - len(m.matchedJobs)
-	statusText := fmt.Sprintf(" %d total | %d matched | %d filtered out    ←/→/Tab switch  ↑/↓ cursor  Enter detail  Esc back  q quit",
-		len(m.allJobs), len(m.matchedJobs), filteredCount)
+	statusText := fmt.Sprintf(" %s | %d total | %d matched | %d filtered out    ←/→/Tab switch  ↑/↓ cursor  Enter detail  Esc back  q quit",
+		m.position(), len(m.allJobs), len(m.matchedJobs), filteredCount)
+	if m.bookmarks != nil {
+		statusText = fmt.Sprintf(" %s | %d total | %d matched | %d filtered out | %d bookmarked    ←/→/Tab switch  ↑/↓ cursor  Enter detail  Space bookmark  x export+quit  Esc back  q quit",
+			m.position(), len(m.allJobs), len(m.matchedJobs), filteredCount, m.bookmarks.Len())
+	}
+	if m.seen != nil {
+		statusText += "  n new only"
+	}
+	if m.notice != "" {
+		statusText += "    " + m.notice
+	}
 	statusBar := statusBarStyle.Width(m.width).Render(statusText)
 
 	return headerRow + "\n" + panes + "\n" + statusBar
@@ -465,14 +628,17 @@ func (m auditModel) viewDetail() string {
 	border := activeBorderStyle.Width(m.width - 2)
 	content := border.Render(m.detailViewport.View())
 
-	statusText := " o open URL  esc/backspace back  ↑/↓ scroll  q quit"
+	statusText := " o open URL  m copy markdown  esc/backspace back  ↑/↓ scroll  q quit"
 	if m.detailJob.Detail != nil && m.detailJob.Detail.Description != "" {
-		if m.analyzer != nil && m.detailJob.Insights == nil && !m.analyzeLoading {
-			statusText = " o open URL  r desc  s summary  esc/backspace back  ↑/↓ scroll  q quit"
+		if !m.aiDisabled() && m.detailJob.Insights == nil && !m.analyzeLoading {
+			statusText = " o open URL  m copy markdown  r desc  s summary  esc/backspace back  ↑/↓ scroll  q quit"
 		} else {
-			statusText = " o open URL  r desc  esc/backspace back  ↑/↓ scroll  q quit"
+			statusText = " o open URL  m copy markdown  r desc  esc/backspace back  ↑/↓ scroll  q quit"
 		}
 	}
+	if m.notice != "" {
+		statusText += "    " + m.notice
+	}
 	statusBar := statusBarStyle.Width(m.width).Render(statusText)
 
 	return title + "\n" + content + "\n" + statusBar
@@ -496,27 +662,28 @@ func (m auditModel) renderDetail() string {
 	addField("Location", j.Location)
 	addField("Job ID", j.ID)
 	addField("Source", j.Source)
+	addField("Tags", strings.Join(j.Tags, ", "))
 
 	b.WriteByte('\n')
 
 	if j.PostedAt != nil {
-		addField("Posted At", fmtTimePST(j.PostedAt, "2006-01-02 15:04 MST"))
+		addField("Posted At", fmt.Sprintf("%s (%s)", fmtLocalTime(j.PostedAt, "2006-01-02 15:04 MST"), relativeAge(j.PostedAt, time.Now())))
 	}
 
 	if j.Detail != nil {
 		d := j.Detail
 
 		if d.UpdatedAt != nil {
-			addField("Updated At", fmtTimePST(d.UpdatedAt, "2006-01-02 15:04 MST"))
+			addField("Updated At", fmtLocalTime(d.UpdatedAt, "2006-01-02 15:04 MST"))
 		}
 		if d.FirstPublished != nil {
-			addField("First Published", fmtTimePST(d.FirstPublished, "2006-01-02 15:04 MST"))
+			addField("First Published", fmtLocalTime(d.FirstPublished, "2006-01-02 15:04 MST"))
 		}
 		if d.StartDate != nil {
-			addField("Start Date", fmtTimePST(d.StartDate, "2006-01-02 MST"))
+			addField("Start Date", fmtLocalTime(d.StartDate, "2006-01-02 MST"))
 		}
 		if d.PublishedAt != nil {
-			addField("Published At", fmtTimePST(d.PublishedAt, "2006-01-02 15:04 MST"))
+			addField("Published At", fmtLocalTime(d.PublishedAt, "2006-01-02 15:04 MST"))
 		}
 		if d.PostedOn != "" {
 			addField("Posted On", d.PostedOn)
@@ -524,17 +691,18 @@ func (m auditModel) renderDetail() string {
 		if d.RequisitionID != "" {
 			addField("Requisition ID", d.RequisitionID)
 		}
+		addField("Team", d.Team)
+		addField("Commitment", d.Commitment)
+		if len(d.Locations) > 1 {
+			addField("Locations", strings.Join(d.Locations, "; "))
+		}
 
-		if len(d.PayRanges) > 0 {
-			b.WriteByte('\n')
-			for _, pr := range d.PayRanges {
-				rangeStr := formatPayRange(pr)
-				label := "Pay Range"
-				if pr.Title != "" {
-					label = pr.Title
-				}
-				addField(label, rangeStr)
-			}
+	}
+
+	if pay := payFields(j, m.displayCfg.FXRates); len(pay) > 0 {
+		b.WriteByte('\n')
+		for _, f := range pay {
+			addField(f.label, f.value)
 		}
 	}
 
@@ -560,6 +728,7 @@ func (m auditModel) renderDetail() string {
 		b.WriteByte('\n')
 		b.WriteString(divider("── AI Summary ") + "\n\n")
 		addField("Role", ins.RoleType)
+		addField("Seniority", ins.Seniority)
 		addField("Experience", ins.YearsExp)
 		if len(ins.TechStack) > 0 {
 			addField("Stack", strings.Join(ins.TechStack, ", "))
@@ -573,9 +742,16 @@ func (m auditModel) renderDetail() string {
 	} else if m.analyzeLoading {
 		b.WriteByte('\n')
 		b.WriteString(descHintStyle.Render("  analyzing job description...") + "\n")
-	} else if m.analyzeError == "" && j.Detail != nil && j.Detail.Description != "" {
+	} else if j.Detail != nil && j.Detail.Description != "" {
+		hint := "press s for job description summary"
+		switch {
+		case m.aiDisabled():
+			hint = aiDisabledHint
+		case m.analyzeError != "":
+			hint = m.analyzeError
+		}
 		b.WriteByte('\n')
-		b.WriteString(descHintStyle.Render("  press s for job description summary") + "\n")
+		b.WriteString(descHintStyle.Render("  "+hint) + "\n")
 	}
 
 	if j.Detail != nil && j.Detail.Description != "" {
@@ -592,21 +768,77 @@ func (m auditModel) renderDetail() string {
 	return b.String()
 }
 
-func formatPayRange(pr model.PayRange) string {
+// formatPayRange renders a pay range in its native currency. When the currency
+// is not USD and fxRates has a rate for it, an approximate USD range is appended,
+// e.g. "EUR $100000 - $120000 (~$108000–$129600 USD)".
+// field is a labelled value shown in the detail view.
+type field struct {
+	label string
+	value string
+}
+
+// payFields lists j's structured pay ranges, labelled by their title, or
+// falls back to the salary the LLM found in the description prose when the
+// ATS provided none.
+func payFields(j model.Job, fxRates map[string]float64) []field {
+	var fields []field
+	if j.Detail != nil {
+		for _, pr := range j.Detail.PayRanges {
+			label := "Pay Range"
+			if pr.Title != "" {
+				label = pr.Title
+			}
+			fields = append(fields, field{label, formatPayRange(pr, fxRates)})
+		}
+	}
+	if pr, ok := aiSalaryRange(j); ok {
+		fields = append(fields, field{"Pay Range", formatPayRange(pr, fxRates) + "  (AI-extracted salary)"})
+	}
+	return fields
+}
+
+func formatPayRange(pr model.PayRange, fxRates map[string]float64) string {
 	minDollars := float64(pr.MinCents) / 100
 	maxDollars := float64(pr.MaxCents) / 100
 	currency := pr.CurrencyType
 	if currency == "" {
 		currency = "USD"
 	}
-	return fmt.Sprintf("%s $%.0f - $%.0f", currency, minDollars, maxDollars)
+	out := fmt.Sprintf("%s $%.0f - $%.0f", currency, minDollars, maxDollars)
+
+	code := strings.ToUpper(currency)
+	if rate, ok := fxRates[code]; ok && code != "USD" && rate > 0 {
+		out += fmt.Sprintf(" (~$%.0f–$%.0f USD)", minDollars*rate, maxDollars*rate)
+	}
+	return out
+}
+
+// aiSalaryRange converts an AI-extracted salary into a PayRange for display.
+// Returns false when the job already has structured pay ranges or the
+// analyzer found no salary.
+func aiSalaryRange(j model.Job) (model.PayRange, bool) {
+	if j.Insights == nil || j.Insights.SalaryMax <= 0 {
+		return model.PayRange{}, false
+	}
+	if j.Detail != nil && len(j.Detail.PayRanges) > 0 {
+		return model.PayRange{}, false
+	}
+	return model.PayRange{
+		MinCents:     int64(j.Insights.SalaryMin * 100),
+		MaxCents:     int64(j.Insights.SalaryMax * 100),
+		CurrencyType: j.Insights.SalaryCurrency,
+	}, true
 }
 
-func renderJobs(jobs []model.Job, cursor int, isActive bool) string {
+// renderJobs renders the job list, marking jobs in marks (which may be nil)
+// with a star. When seen is non-nil each title is tagged NEW or SEEN by
+// whether its ID is in seen.
+func renderJobs(jobs []model.Job, cursor int, isActive bool, marks *Bookmarks, seen map[string]bool) string {
 	if len(jobs) == 0 {
 		return "  (no jobs)"
 	}
 
+	now := time.Now()
 	var b strings.Builder
 	for i, j := range jobs {
 		isSelected := isActive && i == cursor
@@ -620,16 +852,32 @@ func renderJobs(jobs []model.Job, cursor int, isActive bool) string {
 			prefix = "> "
 		}
 
+		title := j.Title
+		if marks.Has(j) {
+			title = bookmarkMarker + title
+		}
 		b.WriteString(prefix)
-		b.WriteString(titleSt.Render(j.Title))
+		if seen != nil {
+			if seen[j.ID] {
+				b.WriteString(seenTagStyle.Render("SEEN"))
+			} else {
+				b.WriteString(newTagStyle.Render("NEW "))
+			}
+			b.WriteByte(' ')
+		}
+		b.WriteString(titleSt.Render(title))
 		b.WriteByte('\n')
 
 		posted := "n/a"
 		if j.PostedAt != nil {
-			posted = j.PostedAt.Format("2006-01-02")
+			posted = fmtLocalTime(j.PostedAt, "2006-01-02") + " · " + relativeAge(j.PostedAt, now)
+		}
+		subtitle := fmt.Sprintf("%s · %s", j.Location, posted)
+		if len(j.Tags) > 0 {
+			subtitle += " · " + strings.Join(j.Tags, ", ")
 		}
 		b.WriteString(prefix)
-		b.WriteString(subtitleSt.Render(fmt.Sprintf("%s · %s", j.Location, posted)))
+		b.WriteString(subtitleSt.Render(subtitle))
 		b.WriteByte('\n')
 
 		if i < len(jobs)-1 {
@@ -639,6 +887,19 @@ func renderJobs(jobs []model.Job, cursor int, isActive bool) string {
 	return b.String()
 }
 
+// seenJobs reports, for each job ID, whether jobStore has already seen it.
+func seenJobs(jobStore model.JobStore, jobs []model.Job) (map[string]bool, error) {
+	seen := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		ok, err := jobStore.HasSeen(j.ID)
+		if err != nil {
+			return nil, fmt.Errorf("checking seen jobs: %w", err)
+		}
+		seen[j.ID] = ok
+	}
+	return seen, nil
+}
+
 func sortJobsByDate(jobs []model.Job) {
 	sort.Slice(jobs, func(i, j int) bool {
 		if jobs[i].PostedAt == nil && jobs[j].PostedAt == nil {
@@ -701,18 +962,32 @@ func openURL(url string) {
 
 // RunAuditTUI launches the interactive split-pane audit TUI.
 // detailFetcher may be nil for adapters that don't support on-demand detail fetching.
-// analyzer may be nil; when non-nil the 's' key triggers AI analysis in the detail view.
+// analyzer may be nil; unless it is nil or a poller.DisabledAnalyzer the 's' key
+// triggers AI analysis in the detail view, and otherwise the view says AI is disabled.
+// bookmarks may be nil; when non-nil space toggles a bookmark and 'x' exports them and quits.
+// jobStore may be nil; when non-nil All Jobs are tagged NEW/SEEN against it and 'n' shows only NEW ones.
 // Returns wantQuit=true if the user pressed q/ctrl+c, false if they pressed esc to return to the picker.
-func RunAuditTUI(allJobs, matchedJobs []model.Job, filterCfg config.FilterConfig, detailFetcher model.JobDetailFetcher, analyzer poller.JobAnalyzer) (bool, error) {
+func RunAuditTUI(allJobs, matchedJobs []model.Job, filterCfg config.FilterConfig, displayCfg config.DisplayConfig, detailFetcher model.JobDetailFetcher, analyzer poller.JobAnalyzer, bookmarks *Bookmarks, jobStore model.JobStore) (bool, error) {
 	sortJobsByDate(allJobs)
 	sortJobsByDate(matchedJobs)
 
+	var seen map[string]bool
+	if jobStore != nil {
+		var err error
+		if seen, err = seenJobs(jobStore, allJobs); err != nil {
+			return false, err
+		}
+	}
+
 	m := auditModel{
 		allJobs:       allJobs,
 		matchedJobs:   matchedJobs,
 		filterCfg:     filterCfg,
+		displayCfg:    displayCfg,
 		detailFetcher: detailFetcher,
 		analyzer:      analyzer,
+		bookmarks:     bookmarks,
+		seen:          seen,
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -721,5 +996,8 @@ func RunAuditTUI(allJobs, matchedJobs []model.Job, filterCfg config.FilterConfig
 		return false, err
 	}
 	final := result.(auditModel)
+	for _, md := range final.printOnQuit {
+		fmt.Println(md)
+	}
 	return final.wantQuit, nil
 }
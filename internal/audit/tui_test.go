@@ -0,0 +1,274 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/amishk599/firstin/internal/ai"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/poller"
+	"github.com/amishk599/firstin/internal/store"
+)
+
+func TestFormatPayRange(t *testing.T) {
+	rates := map[string]float64{"EUR": 1.08, "INR": 0.012}
+
+	tests := []struct {
+		name  string
+		pr    model.PayRange
+		rates map[string]float64
+		want  string
+	}{
+		{
+			name: "USD without rates",
+			pr:   model.PayRange{MinCents: 15000000, MaxCents: 20000000, CurrencyType: "USD"},
+			want: "USD $150000 - $200000",
+		},
+		{
+			name: "empty currency defaults to USD",
+			pr:   model.PayRange{MinCents: 10000000, MaxCents: 12000000},
+			want: "USD $100000 - $120000",
+		},
+		{
+			name: "non-USD without rates shows native only",
+			pr:   model.PayRange{MinCents: 10000000, MaxCents: 12000000, CurrencyType: "EUR"},
+			want: "EUR $100000 - $120000",
+		},
+		{
+			name:  "configured rate appends USD approximation",
+			pr:    model.PayRange{MinCents: 10000000, MaxCents: 12000000, CurrencyType: "EUR"},
+			rates: rates,
+			want:  "EUR $100000 - $120000 (~$108000–$129600 USD)",
+		},
+		{
+			name:  "lowercase currency code still converts",
+			pr:    model.PayRange{MinCents: 300000000, MaxCents: 500000000, CurrencyType: "inr"},
+			rates: rates,
+			want:  "inr $3000000 - $5000000 (~$36000–$60000 USD)",
+		},
+		{
+			name:  "unknown currency skips conversion",
+			pr:    model.PayRange{MinCents: 10000000, MaxCents: 12000000, CurrencyType: "GBP"},
+			rates: rates,
+			want:  "GBP $100000 - $120000",
+		},
+		{
+			name:  "USD is never converted",
+			pr:    model.PayRange{MinCents: 10000000, MaxCents: 12000000, CurrencyType: "USD"},
+			rates: map[string]float64{"USD": 2},
+			want:  "USD $100000 - $120000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPayRange(tt.pr, tt.rates); got != tt.want {
+				t.Errorf("formatPayRange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAISalaryRange(t *testing.T) {
+	withSalary := &model.JobInsights{SalaryMin: 150000, SalaryMax: 200000, SalaryCurrency: "USD"}
+
+	if _, ok := aiSalaryRange(model.Job{}); ok {
+		t.Error("expected no AI salary when Insights is nil")
+	}
+	if _, ok := aiSalaryRange(model.Job{Insights: &model.JobInsights{}}); ok {
+		t.Error("expected no AI salary when analyzer found none")
+	}
+
+	structured := model.Job{
+		Insights: withSalary,
+		Detail:   &model.JobDetail{PayRanges: []model.PayRange{{MinCents: 1, MaxCents: 2}}},
+	}
+	if _, ok := aiSalaryRange(structured); ok {
+		t.Error("expected structured pay range to take precedence over AI salary")
+	}
+
+	pr, ok := aiSalaryRange(model.Job{Insights: withSalary})
+	if !ok {
+		t.Fatal("expected AI salary range")
+	}
+	if got := formatPayRange(pr, nil); got != "USD $150000 - $200000" {
+		t.Errorf("formatPayRange(AI salary) = %q", got)
+	}
+}
+
+func TestFmtLocalTime_UsesLocalZone(t *testing.T) {
+	orig := time.Local
+	defer func() { time.Local = orig }()
+	time.Local = time.FixedZone("CEST", 2*60*60)
+
+	at := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	if got, want := fmtLocalTime(&at, "2006-01-02 15:04 MST"), "2026-07-01 12:00 CEST"; got != want {
+		t.Errorf("fmtLocalTime() = %q, want %q", got, want)
+	}
+}
+
+func TestRelativeAge(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name string
+		t    *time.Time
+		want string
+	}{
+		{"nil", nil, "n/a"},
+		{"seconds", ago(59 * time.Second), "just now"},
+		{"future skew", ago(-30 * time.Second), "just now"},
+		{"one minute", ago(time.Minute), "1m ago"},
+		{"just under an hour", ago(59*time.Minute + 59*time.Second), "59m ago"},
+		{"one hour", ago(time.Hour), "1h ago"},
+		{"just under a day", ago(23*time.Hour + 59*time.Minute), "23h ago"},
+		{"one day", ago(24 * time.Hour), "1d ago"},
+		{"several days", ago(50 * time.Hour), "2d ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeAge(tt.t, now); got != tt.want {
+				t.Errorf("relativeAge() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeenJobs_ClassifiesAgainstStore(t *testing.T) {
+	s, err := store.NewSQLiteStore(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := s.MarkSeenBatch([]string{"old-1", "old-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := []model.Job{
+		{ID: "old-1", Title: "Seen Before"},
+		{ID: "new-1", Title: "Fresh Role"},
+		{ID: "old-2", Title: "Also Seen"},
+	}
+	seen, err := seenJobs(s, jobs)
+	if err != nil {
+		t.Fatalf("seenJobs: %v", err)
+	}
+	want := map[string]bool{"old-1": true, "new-1": false, "old-2": true}
+	for id, w := range want {
+		if seen[id] != w {
+			t.Errorf("seen[%q] = %v, want %v", id, seen[id], w)
+		}
+	}
+
+	rendered := renderJobs(jobs, 0, true, nil, seen)
+	for _, line := range strings.Split(rendered, "\n") {
+		switch {
+		case strings.Contains(line, "Fresh Role") && !strings.Contains(line, "NEW"):
+			t.Errorf("new job not tagged NEW: %q", line)
+		case strings.Contains(line, "Seen Before") && !strings.Contains(line, "SEEN"):
+			t.Errorf("seen job not tagged SEEN: %q", line)
+		}
+	}
+
+	m := auditModel{allJobs: jobs, seen: seen}
+	next, _ := m.updateListView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = next.(auditModel)
+	if left := m.leftJobs(); len(left) != 1 || left[0].ID != "new-1" {
+		t.Errorf("leftJobs with n filter = %v, want only new-1", left)
+	}
+}
+
+func TestPosition_ReflectsCursorAndScroll(t *testing.T) {
+	jobs := make([]model.Job, 100)
+	for i := range jobs {
+		jobs[i] = model.Job{ID: fmt.Sprint(i), Title: "Engineer"}
+	}
+	m := auditModel{allJobs: jobs, width: 120, height: 34}
+	m.recalcLayout()
+
+	down := func(n int) {
+		for range n {
+			next, _ := m.updateListView(tea.KeyMsg{Type: tea.KeyDown})
+			m = next.(auditModel)
+		}
+	}
+
+	if got := m.position(); got != "1/100 0%" {
+		t.Errorf("at top: position() = %q, want 1/100 0%%", got)
+	}
+	down(49)
+	if got := m.position(); !strings.HasPrefix(got, "50/100 ") || got == "50/100 0%" || got == "50/100 100%" {
+		t.Errorf("mid-list: position() = %q, want 50/100 part-way scrolled", got)
+	}
+	down(60) // past the end stops at the last job
+	if got := m.position(); got != "100/100 100%" {
+		t.Errorf("at bottom: position() = %q, want 100/100 100%%", got)
+	}
+	if !strings.Contains(m.viewList(), "100/100 100%") {
+		t.Error("status bar does not show the position")
+	}
+
+	next, _ := m.updateListView(tea.KeyMsg{Type: tea.KeyTab})
+	m = next.(auditModel)
+	if got := m.position(); got != "0/0" {
+		t.Errorf("empty matched pane: position() = %q, want 0/0", got)
+	}
+}
+
+// summaryAnalyzer is a working analyzer that always finds insights.
+type summaryAnalyzer struct{}
+
+func (summaryAnalyzer) Analyze(_ context.Context, job model.Job) (model.Job, error) {
+	job.Insights = &model.JobInsights{RoleType: "backend"}
+	return job, nil
+}
+
+func TestDetailView_AIDisabledHint(t *testing.T) {
+	job := model.Job{ID: "1", Title: "Engineer", Detail: &model.JobDetail{Description: "Build things."}}
+	tests := []struct {
+		name         string
+		analyzer     poller.JobAnalyzer
+		wantDisabled bool
+	}{
+		{"no analyzer", nil, true},
+		{"ai disabled", ai.NewNopJobAnalyzer(), true},
+		{"ai enabled", summaryAnalyzer{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := auditModel{view: viewDetail, detailJob: job, analyzer: tt.analyzer, width: 120, height: 34}
+			m.recalcLayout()
+
+			updated, cmd := m.updateDetailView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+			m = updated.(auditModel)
+			if got := cmd == nil; got != tt.wantDisabled {
+				t.Fatalf("analysis skipped = %v, want %v", got, tt.wantDisabled)
+			}
+			if tt.wantDisabled {
+				if m.analyzeLoading {
+					t.Error("analyzeLoading set, want no analysis started")
+				}
+				if !strings.Contains(m.renderDetail(), aiDisabledHint) {
+					t.Errorf("detail = %q, want the AI disabled hint", m.renderDetail())
+				}
+				if strings.Contains(m.viewDetail(), "s summary") {
+					t.Error("status bar offers s summary with AI disabled")
+				}
+				return
+			}
+			if strings.Contains(m.renderDetail(), aiDisabledHint) {
+				t.Error("detail shows the AI disabled hint with a working analyzer")
+			}
+		})
+	}
+}
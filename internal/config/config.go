@@ -2,20 +2,97 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"text/template"
 	"time"
 
+	"github.com/amishk599/firstin/internal/model"
 	"gopkg.in/yaml.v3"
 )
 
 // Config is the root configuration for the FirstIn poller.
 type Config struct {
 	PollingInterval time.Duration
-	Companies      []CompanyConfig
-	Filters        FilterConfig
-	Notification   NotificationConfig
-	RateLimit      RateLimitConfig
-	AI             AIConfig
+	ShutdownTimeout time.Duration // how long an in-flight poll may finish after SIGTERM
+	PollTimeout     time.Duration // deadline for one company poll; 0 disables
+	Companies       []CompanyConfig
+	Filters         FilterConfig
+	Notification    NotificationConfig
+	RateLimit       RateLimitConfig
+	Retry           RetryConfig
+	AI              AIConfig
+	Display         DisplayConfig
+	Audit           AuditConfig
+	Server          ServerConfig
+	Store           StoreConfig
+	Tracing         TracingConfig
+	HTTP            HTTPConfig
+	Tags            []TagRule
+}
+
+// HTTPConfig customizes the HTTP client shared by adapters and notifiers.
+type HTTPConfig struct {
+	Timeout   time.Duration // per-request timeout, defaults to 30s
+	UserAgent string        // sent on every request; empty uses "firstin/<version>"
+	// ProxyURL routes all requests through a proxy, e.g. "http://proxy:3128".
+	// Empty falls back to the HTTP_PROXY/HTTPS_PROXY environment variables.
+	ProxyURL string
+	// MaxResponseBytes caps how much of a response body is read, after
+	// decompression; defaults to DefaultMaxResponseBytes.
+	MaxResponseBytes int64
+}
+
+// DefaultMaxResponseBytes is http.max_response_bytes when unset: far above
+// the largest real board, far below what would exhaust memory.
+const DefaultMaxResponseBytes = 64 << 20
+
+// TagRule labels jobs whose title or description mentions any keyword
+// (whole words, case-insensitive) with Tag, e.g. "Go" for "go" or "golang".
+type TagRule struct {
+	Tag      string   `yaml:"tag"`
+	Keywords []string `yaml:"keywords"`
+}
+
+// TracingConfig controls optional OpenTelemetry tracing of poll cycles.
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the OTLP/HTTP collector address as host:port, e.g. "localhost:4318".
+	Endpoint string `yaml:"endpoint"`
+	// Insecure sends spans over plain HTTP instead of HTTPS.
+	Insecure bool `yaml:"insecure"`
+}
+
+// ServerConfig controls the optional control HTTP server run by "start".
+type ServerConfig struct {
+	// Addr is the listen address, e.g. "127.0.0.1:8080". Empty disables the server.
+	Addr string `yaml:"addr"`
+}
+
+// StoreConfig selects where seen job IDs are kept.
+type StoreConfig struct {
+	// Type is "sqlite" (default), persisting to jobs.db, or "memory", which
+	// dedups only within the running process and writes no file.
+	Type string `yaml:"type"`
+}
+
+// AuditConfig controls how the audit command fetches boards.
+type AuditConfig struct {
+	// MaxPages caps how many search result pages adapters that page through
+	// a search API (microsoft, amazon) fetch in audit mode. Zero keeps the
+	// adapter's default of 20.
+	MaxPages int `yaml:"max_pages"`
+}
+
+// DisplayConfig controls presentation-only settings used by the audit TUI.
+type DisplayConfig struct {
+	// FXRates maps an ISO currency code to its USD value (1 unit = rate USD),
+	// e.g. {"EUR": 1.08}. Used to show an approximate USD range next to
+	// non-USD pay ranges. Currencies without a rate are shown as-is.
+	FXRates map[string]float64 `yaml:"fx_rates"`
 }
 
 // AIConfig controls the optional OpenAI enrichment layer.
@@ -25,12 +102,47 @@ type AIConfig struct {
 	Model   string        // OpenAI model identifier, e.g. "gpt-4o-mini"
 	APIKey  string        // expanded from env var by Load
 	Timeout time.Duration // per-request timeout
+
+	// MaxRetries is the number of additional attempts after a transient LLM
+	// failure (429, 5xx, network error). Defaults to 2.
+	MaxRetries int
+
+	// PromptTemplate is an optional path to a text/template file overriding the
+	// built-in analysis prompt. Relative paths resolve against the config file's
+	// directory. Empty uses the built-in prompt.
+	PromptTemplate string
+
+	// MaxDescriptionChars caps the job description sent to the LLM, keeping
+	// its start. Defaults to 6000; 0 sends descriptions whole.
+	MaxDescriptionChars int
+
+	// USD price per 1M prompt/completion tokens, used only to estimate spend
+	// in the periodic usage log. Zero omits the cost estimate.
+	InputCostPer1M  float64
+	OutputCostPer1M float64
 }
 
 // RateLimitConfig controls ATS-level rate limiting.
 type RateLimitConfig struct {
-	MinDelay     time.Duration            // minimum gap between requests to the same ATS
+	MinDelay     time.Duration            // minimum gap between requests to the same ATS, detail requests included
 	ATSOverrides map[string]time.Duration // per-ATS overrides, keyed by ATS name
+
+	// IntervalOverrides replaces polling_interval for an ATS group's pass
+	// interval, keyed by ATS name.
+	IntervalOverrides map[string]time.Duration
+
+	// StartupStagger offsets each ATS group's first poll by its index times
+	// this, so startup doesn't hit every provider at once. Zero disables it.
+	StartupStagger time.Duration
+
+	// MaxConcurrentFetches caps fetches in flight across all ATS groups.
+	// Zero means unlimited (one per ATS group).
+	MaxConcurrentFetches int
+
+	// MaxPerATSConcurrency is how many companies of one ATS group may be
+	// polled at once, each still starting min_delay after the last. Zero or
+	// one polls the group sequentially.
+	MaxPerATSConcurrency int
 }
 
 // MinDelayFor returns the configured delay for the given ATS, falling back to MinDelay.
@@ -41,10 +153,209 @@ func (r RateLimitConfig) MinDelayFor(ats string) time.Duration {
 	return r.MinDelay
 }
 
+// RetryPolicy controls how transient fetch failures are retried.
+type RetryPolicy struct {
+	MaxRetries int           // additional attempts after the first failure
+	BaseDelay  time.Duration // delay before the first retry, doubled each retry
+}
+
+// RetryConfig holds the default fetch retry policy and per-ATS overrides.
+type RetryConfig struct {
+	RetryPolicy
+	ATSOverrides map[string]RetryPolicy // keyed by ATS name
+
+	// MaxRetryAfter caps a server's Retry-After: a longer one waits this
+	// long instead, so a buggy board can't stall a poll cycle. Default 60s.
+	MaxRetryAfter time.Duration
+}
+
+// For returns the retry policy for the given ATS, falling back to the default.
+func (r RetryConfig) For(ats string) RetryPolicy {
+	if p, ok := r.ATSOverrides[ats]; ok {
+		return p
+	}
+	return r.RetryPolicy
+}
+
 // NotificationConfig controls which notifier is used and its settings.
 type NotificationConfig struct {
-	Type       string `yaml:"type"`        // "log" or "slack"
+	Type       string `yaml:"type"`        // "log", "slack", "matrix", "ntfy" or "pagerduty"
 	WebhookURL string `yaml:"webhook_url"` // required if type is "slack"
+	TopicURL   string `yaml:"topic_url"`   // required if type is "ntfy", e.g. "https://ntfy.sh/my-topic"
+
+	// Slack threaded mode: post a "New roles at {company}" parent message per
+	// company and thread each job under it. Webhooks can't thread, so this
+	// uses chat.postMessage and requires a bot token and channel instead of
+	// webhook_url.
+	SlackThreaded bool   `yaml:"slack_threaded"`
+	BotToken      string `yaml:"bot_token"` // expanded from env var by Load, e.g. "xoxb-..."
+	Channel       string `yaml:"channel"`   // channel ID or name, e.g. "C0123456789"
+
+	// SlackTemplate is an optional path to a text/template file rendering each
+	// job's Slack message body ({"blocks": [...]}) in place of the built-in
+	// layout; see notifier.LoadSlackTemplate. Relative paths resolve against
+	// the config file's directory.
+	SlackTemplate string `yaml:"slack_template"`
+
+	// InsightsFirst leads Slack alerts with the AI summary (role, experience,
+	// stack, key points) instead of listing it after the job's metadata.
+	// Alerts without insights keep the usual layout. Ignored by
+	// slack_template.
+	InsightsFirst bool `yaml:"insights_first"`
+
+	// MessageDelay is the pause between Slack messages, e.g. "1s" for a busy
+	// workspace or "0s" to send back to back. Unset uses
+	// notifier.DefaultMessageDelay (500ms). A digest is a single message, so
+	// it never waits.
+	MessageDelay *time.Duration `yaml:"message_delay"`
+
+	// Enrich fetches each new job's detail (description, pay ranges) before
+	// notifying, for ATSs with a detail endpoint (greenhouse, workday,
+	// microsoft). Costs one extra request per notified job; in the daemon
+	// they are spaced by the ATS min_delay like board fetches, and skipped
+	// when they could not start before poll_timeout (see
+	// DetailPacingConflicts).
+	Enrich bool `yaml:"enrich"`
+
+	// NotifyOnChange re-notifies an already-seen job when its description
+	// changes materially (new pay, new requirements). Descriptions are
+	// hashed in the store; boards whose listings carry no description cost
+	// detail requests, ChangeChecksPerPoll per company poll.
+	NotifyOnChange bool `yaml:"notify_on_change"`
+
+	// ChangeChecksPerPoll caps the detail requests NotifyOnChange makes per
+	// company poll; each poll checks the next few seen jobs in turn. Zero
+	// keeps the default of 5 (poller.DefaultChangeChecks).
+	ChangeChecksPerPoll int `yaml:"change_checks_per_poll"`
+
+	// NotifyOnClose notifies again when a notified role is no longer listed
+	// (filled or pulled). Boards fetched only down to the freshness cutoff
+	// (workday, microsoft, amazon) can't tell closed from old and are
+	// skipped.
+	NotifyOnClose bool `yaml:"notify_on_close"`
+
+	// DeadLetterPath is an optional JSONL file that jobs whose notification
+	// failed are appended to, with the error and time. Empty disables it.
+	DeadLetterPath string `yaml:"dead_letter_path"`
+
+	// TestOnStart sends a test notification when the daemon starts, so a
+	// broken webhook shows up at once rather than at the first match. A
+	// failure is logged, or stops the daemon with TestOnStartFailFast.
+	TestOnStart         bool `yaml:"test_on_start"`
+	TestOnStartFailFast bool `yaml:"test_on_start_fail_fast"`
+
+	// QuietHours holds notifications inside a daily window and sends them
+	// when it ends. Unset start and end disable it.
+	QuietHours QuietHoursConfig `yaml:"quiet_hours"`
+
+	// Digest collects notifications and sends them together once a day at a
+	// set time, e.g. a morning digest of everything found overnight, as one
+	// message listing every job (PagerDuty still gets one event per job).
+	// Unset at disables it.
+	Digest DigestConfig `yaml:"digest"`
+
+	// RoleRoutes sends jobs whose AI role type (JobInsights.RoleType, matched
+	// case-insensitively) has an entry to that notifier instead, e.g. ML
+	// roles to a channel that pings someone. Each route is a notifier config
+	// of its own (type, webhook_url, ...). Jobs without insights or with an
+	// unrouted role use this notifier. Requires ai.enabled.
+	RoleRoutes map[string]NotificationConfig `yaml:"role_routes"`
+
+	// Matrix settings, required if type is "matrix".
+	HomeserverURL string `yaml:"homeserver_url"` // e.g. "https://matrix.org"
+	RoomID        string `yaml:"room_id"`        // e.g. "!abc123:matrix.org"
+	AccessToken   string `yaml:"access_token"`   // expanded from env var by Load
+
+	// PagerDuty settings, required if type is "pagerduty". Only jobs whose
+	// title contains a priority keyword page; other matches are dropped.
+	RoutingKey       string   `yaml:"routing_key"`       // Events API v2 integration key
+	PriorityKeywords []string `yaml:"priority_keywords"` // case-insensitive title keywords
+	Severity         string   `yaml:"severity"`          // event severity; "" is "warning"
+
+	// Secret files, for secret managers that mount secrets as files: when
+	// set, Load replaces the matching inline value with the file's trimmed
+	// contents. Relative paths resolve against the config file's directory.
+	WebhookURLFile  string `yaml:"webhook_url_file"`
+	BotTokenFile    string `yaml:"bot_token_file"`
+	AccessTokenFile string `yaml:"access_token_file"`
+	RoutingKeyFile  string `yaml:"routing_key_file"`
+}
+
+// secretFile is a *_file key whose file contents replace the inline value
+// at dst.
+type secretFile struct {
+	key  string
+	path string
+	dst  *string
+}
+
+// notificationSecrets returns n's *_file keys, named under prefix.
+func notificationSecrets(prefix string, n *NotificationConfig) []secretFile {
+	return []secretFile{
+		{prefix + ".webhook_url_file", n.WebhookURLFile, &n.WebhookURL},
+		{prefix + ".bot_token_file", n.BotTokenFile, &n.BotToken},
+		{prefix + ".access_token_file", n.AccessTokenFile, &n.AccessToken},
+		{prefix + ".routing_key_file", n.RoutingKeyFile, &n.RoutingKey},
+	}
+}
+
+// QuietHoursConfig is a daily "HH:MM" window in Timezone, which defaults to
+// the Pacific time Slack alerts are displayed in. End before Start wraps past
+// midnight, e.g. 22:00 to 07:00. Path is the file held jobs are kept in
+// across restarts.
+type QuietHoursConfig struct {
+	Start    string `yaml:"start"`
+	End      string `yaml:"end"`
+	Timezone string `yaml:"timezone"`
+	Path     string `yaml:"path"` // default "quiet_hours.json"
+}
+
+const (
+	defaultQuietHoursTimezone = "America/Los_Angeles"
+	defaultQuietHoursPath     = "quiet_hours.json"
+)
+
+// BufferPath returns the file held jobs are kept in.
+func (q QuietHoursConfig) BufferPath() string {
+	if q.Path == "" {
+		return defaultQuietHoursPath
+	}
+	return q.Path
+}
+
+// Enabled reports whether quiet hours are configured.
+func (q QuietHoursConfig) Enabled() bool {
+	return q.Start != "" || q.End != ""
+}
+
+// Window parses the window into offsets from midnight and its location.
+func (q QuietHoursConfig) Window() (start, end time.Duration, loc *time.Location, err error) {
+	if start, err = parseClock(q.Start); err != nil {
+		return 0, 0, nil, fmt.Errorf("start: %w", err)
+	}
+	if end, err = parseClock(q.End); err != nil {
+		return 0, 0, nil, fmt.Errorf("end: %w", err)
+	}
+	if start == end {
+		return 0, 0, nil, fmt.Errorf("start and end must differ")
+	}
+	tz := q.Timezone
+	if tz == "" {
+		tz = defaultQuietHoursTimezone
+	}
+	if loc, err = time.LoadLocation(tz); err != nil {
+		return 0, 0, nil, fmt.Errorf("timezone: %w", err)
+	}
+	return start, end, loc, nil
+}
+
+// parseClock parses "HH:MM" (24-hour) into an offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
 }
 
 // CompanyConfig describes a single company board to poll.
@@ -54,6 +365,169 @@ type CompanyConfig struct {
 	BoardToken string `yaml:"board_token"`
 	WorkdayURL string `yaml:"workday_url"`
 	Enabled    bool   `yaml:"enabled"`
+
+	// Muted pauses polling of an enabled company without changing its
+	// configured intent: it still shows in listings and audit, but the
+	// daemon builds no poller for it.
+	Muted bool `yaml:"muted"`
+
+	// Search-API adapters (microsoft, amazon) only: role query and location
+	// sent with each search. Default to "software engineer" / "United States".
+	SearchQuery    string `yaml:"search_query"`
+	SearchLocation string `yaml:"search_location"`
+
+	// Workday only: searchText and appliedFacets sent with each listing
+	// request so the server narrows large boards. Facet values are the
+	// board's own facet IDs. Empty fetches the whole board.
+	WorkdaySearchText string              `yaml:"workday_search_text"`
+	WorkdayFacets     map[string][]string `yaml:"workday_facets"`
+
+	// Greenhouse only: fetch just this department (and its sub-departments)
+	// from the departments endpoint instead of the whole board. Zero fetches
+	// every job.
+	DepartmentID int64 `yaml:"department_id"`
+
+	// MaxAge overrides filters.max_age for this company, e.g. "24h" for a
+	// board that posts rarely. Zero uses the global value.
+	MaxAge time.Duration `yaml:"max_age"`
+
+	// ActiveWindow limits polling to certain days and hours, e.g. weekday
+	// mornings for a board that only posts then. Unset polls around the clock.
+	ActiveWindow ActiveWindowConfig `yaml:"active_window"`
+}
+
+// DigestConfig is the daily "HH:MM" time a digest is sent at, in Timezone
+// (default Pacific, like quiet hours). Days limits which days it is sent on,
+// like an active window's days; jobs found on other days wait for the next
+// one. Path is the file pending jobs are kept in across restarts.
+type DigestConfig struct {
+	At       string   `yaml:"at"`
+	Days     []string `yaml:"days"`
+	Timezone string   `yaml:"timezone"`
+	Path     string   `yaml:"path"` // default "digest.json"
+}
+
+const defaultDigestPath = "digest.json"
+
+// Enabled reports whether a digest is configured.
+func (d DigestConfig) Enabled() bool {
+	return d.At != "" || len(d.Days) > 0
+}
+
+// Schedule parses the digest time into its days, offset from midnight and
+// location. No days means every day.
+func (d DigestConfig) Schedule() (days []time.Weekday, at time.Duration, loc *time.Location, err error) {
+	for _, s := range d.Days {
+		ds, err := parseDays(s)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("days: %w", err)
+		}
+		days = append(days, ds...)
+	}
+	if at, err = parseClock(d.At); err != nil {
+		return nil, 0, nil, fmt.Errorf("at: %w", err)
+	}
+	tz := d.Timezone
+	if tz == "" {
+		tz = defaultQuietHoursTimezone
+	}
+	if loc, err = time.LoadLocation(tz); err != nil {
+		return nil, 0, nil, fmt.Errorf("timezone: %w", err)
+	}
+	return days, at, loc, nil
+}
+
+// BufferPath returns the file pending digest jobs are kept in.
+func (d DigestConfig) BufferPath() string {
+	if d.Path == "" {
+		return defaultDigestPath
+	}
+	return d.Path
+}
+
+// ActiveWindowConfig is the days and daily "HH:MM" window a company is polled
+// in, in Timezone, which defaults to the Pacific time alerts are displayed
+// in. Days are names like "mon" or ranges like "mon-fri"; empty means every
+// day. Unset start and end cover the whole day; end before start wraps past
+// midnight and belongs to the day it starts on.
+type ActiveWindowConfig struct {
+	Days     []string `yaml:"days"`
+	Start    string   `yaml:"start"`
+	End      string   `yaml:"end"`
+	Timezone string   `yaml:"timezone"`
+}
+
+// Enabled reports whether an active window is configured.
+func (w ActiveWindowConfig) Enabled() bool {
+	return len(w.Days) > 0 || w.Start != "" || w.End != ""
+}
+
+// Window parses the window into its days, offsets from midnight and
+// location. No days means every day; start == end means the whole day.
+func (w ActiveWindowConfig) Window() (days []time.Weekday, start, end time.Duration, loc *time.Location, err error) {
+	for _, d := range w.Days {
+		ds, err := parseDays(d)
+		if err != nil {
+			return nil, 0, 0, nil, fmt.Errorf("days: %w", err)
+		}
+		days = append(days, ds...)
+	}
+	if w.Start != "" || w.End != "" {
+		if start, err = parseClock(w.Start); err != nil {
+			return nil, 0, 0, nil, fmt.Errorf("start: %w", err)
+		}
+		if end, err = parseClock(w.End); err != nil {
+			return nil, 0, 0, nil, fmt.Errorf("end: %w", err)
+		}
+		if start == end {
+			return nil, 0, 0, nil, fmt.Errorf("start and end must differ")
+		}
+	}
+	tz := w.Timezone
+	if tz == "" {
+		tz = defaultQuietHoursTimezone
+	}
+	if loc, err = time.LoadLocation(tz); err != nil {
+		return nil, 0, 0, nil, fmt.Errorf("timezone: %w", err)
+	}
+	return days, start, end, loc, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseDays parses a day name ("mon", "Monday") or an inclusive range of
+// them ("mon-fri", wrapping past Saturday for "fri-mon").
+func parseDays(s string) ([]time.Weekday, error) {
+	parse := func(name string) (time.Weekday, error) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if len(name) >= 3 {
+			if d, ok := weekdays[name[:3]]; ok && strings.HasPrefix(strings.ToLower(d.String()), name) {
+				return d, nil
+			}
+		}
+		return 0, fmt.Errorf("invalid day %q, want a name like \"mon\" or a range like \"mon-fri\"", s)
+	}
+	from, to, isRange := strings.Cut(s, "-")
+	first, err := parse(from)
+	if err != nil {
+		return nil, err
+	}
+	if !isRange {
+		return []time.Weekday{first}, nil
+	}
+	last, err := parse(to)
+	if err != nil {
+		return nil, err
+	}
+	days := []time.Weekday{first}
+	for d := first; d != last; {
+		d = (d + 1) % 7
+		days = append(days, d)
+	}
+	return days, nil
 }
 
 // FilterConfig holds keyword and location filter settings.
@@ -63,6 +537,85 @@ type FilterConfig struct {
 	Locations            []string
 	ExcludeLocations     []string
 	MaxAge               time.Duration // max age of a job posting to be considered fresh
+	Seniority            []string      // AI-classified seniority levels to keep; empty keeps all
+	MaxNewPerPoll        int           // cap on jobs notified by one company poll; 0 is unlimited
+	Countries            []string      // structured countries to keep (Job.Country); empty keeps all
+	ExcludeCountries     []string      // structured countries to drop
+	RemoteOnly           bool          // keep only jobs flagged remote (Job.IsRemote)
+	TitleMatchAll        bool          // require every title keyword instead of any
+	BlockJobIDs          []string      // job IDs never notified, even when they match
+	AllowJobIDs          []string      // job IDs that bypass the title/location filters
+
+	// FreshnessBasis is what MaxAge is measured from: "posted" (the ATS's
+	// PostedAt, the default) or "first_seen" (when this store first saw the
+	// job), for boards whose posting dates are unreliable.
+	FreshnessBasis string
+
+	// PostingStatuses are the posting statuses (model.PostingStatuses) kept
+	// besides open, e.g. "unlisted" to hear about Ashby's unlisted postings.
+	// Jobs whose ATS reports no status always count as open.
+	PostingStatuses []string
+
+	// CrossCompanyDedup notifies a role (normalized title + location) once
+	// per poll pass even when several companies post it; with
+	// CrossCompanyDedupPersist the store also suppresses it in later passes.
+	CrossCompanyDedup        bool
+	CrossCompanyDedupPersist bool
+}
+
+// Active reports whether the daemon should poll this company: enabled and not muted.
+func (c CompanyConfig) Active() bool {
+	return c.Enabled && !c.Muted
+}
+
+// MaxAgeOr returns the company's max_age override, or global when unset.
+func (c CompanyConfig) MaxAgeOr(global time.Duration) time.Duration {
+	if c.MaxAge > 0 {
+		return c.MaxAge
+	}
+	return global
+}
+
+// DetailPacingConflicts returns, sorted, the ATSs of enabled companies whose
+// min_delay is at least poll_timeout while enrich or notify_on_change is set.
+// Detail requests are spaced by min_delay, so none of theirs can start
+// before the poll deadline and the jobs go out without detail.
+func (c *Config) DetailPacingConflicts() []string {
+	n := c.Notification
+	if (!n.Enrich && !n.NotifyOnChange) || c.PollTimeout <= 0 {
+		return nil
+	}
+	var conflicts []string
+	for _, co := range c.Companies {
+		if co.Enabled && c.RateLimit.MinDelayFor(co.ATS) >= c.PollTimeout && !slices.Contains(conflicts, co.ATS) {
+			conflicts = append(conflicts, co.ATS)
+		}
+	}
+	slices.Sort(conflicts)
+	return conflicts
+}
+
+// Mute marks the named companies (case-insensitive) as muted and returns any
+// names that don't match a configured company.
+func (c *Config) Mute(names []string) []string {
+	var unknown []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for i := range c.Companies {
+			if strings.EqualFold(c.Companies[i].Name, name) {
+				c.Companies[i].Muted = true
+				found = true
+			}
+		}
+		if !found {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
 }
 
 const defaultOpenAIBaseURL = "https://api.openai.com/v1"
@@ -70,11 +623,28 @@ const defaultOpenAIBaseURL = "https://api.openai.com/v1"
 // rawConfig is used for YAML unmarshaling (snake_case fields and duration as string).
 type rawConfig struct {
 	PollingInterval string             `yaml:"polling_interval"`
+	ShutdownTimeout string             `yaml:"shutdown_timeout"`
+	PollTimeout     string             `yaml:"poll_timeout"`
 	Companies       []CompanyConfig    `yaml:"companies"`
 	Filters         rawFilterConfig    `yaml:"filters"`
 	Notification    NotificationConfig `yaml:"notification"`
 	RateLimit       rawRateLimitConfig `yaml:"rate_limit"`
+	Retry           rawRetryConfig     `yaml:"retry"`
 	AI              rawAIConfig        `yaml:"ai"`
+	Display         DisplayConfig      `yaml:"display"`
+	Audit           AuditConfig        `yaml:"audit"`
+	Server          ServerConfig       `yaml:"server"`
+	Store           StoreConfig        `yaml:"store"`
+	Tracing         TracingConfig      `yaml:"tracing"`
+	HTTP            rawHTTPConfig      `yaml:"http"`
+	Tags            []TagRule          `yaml:"tags"`
+}
+
+type rawHTTPConfig struct {
+	Timeout          string `yaml:"timeout"`
+	UserAgent        string `yaml:"user_agent"`
+	ProxyURL         string `yaml:"proxy_url"`
+	MaxResponseBytes int64  `yaml:"max_response_bytes"`
 }
 
 type rawAIConfig struct {
@@ -82,12 +652,55 @@ type rawAIConfig struct {
 	BaseURL string `yaml:"base_url"`
 	Model   string `yaml:"model"`
 	APIKey  string `yaml:"api_key"`
+
+	APIKeyFile string `yaml:"api_key_file"` // overrides api_key with the file's trimmed contents
+
 	Timeout string `yaml:"timeout"`
+
+	MaxRetries *int `yaml:"max_retries"`
+
+	PromptTemplate string `yaml:"prompt_template"`
+
+	MaxDescriptionChars *int `yaml:"max_description_chars"`
+
+	InputCostPer1M  float64 `yaml:"input_cost_per_1m"`
+	OutputCostPer1M float64 `yaml:"output_cost_per_1m"`
+}
+
+type rawRetryPolicy struct {
+	MaxRetries *int   `yaml:"max_retries"`
+	BaseDelay  string `yaml:"base_delay"`
+}
+
+type rawRetryConfig struct {
+	rawRetryPolicy `yaml:",inline"`
+	ATSOverrides   map[string]rawRetryPolicy `yaml:"ats_overrides"`
+	MaxRetryAfter  string                    `yaml:"max_retry_after"`
+}
+
+// resolve fills unset fields of a raw policy from def.
+func (r rawRetryPolicy) resolve(def RetryPolicy, field string) (RetryPolicy, error) {
+	p := def
+	if r.MaxRetries != nil {
+		p.MaxRetries = *r.MaxRetries
+	}
+	if r.BaseDelay != "" {
+		d, err := time.ParseDuration(r.BaseDelay)
+		if err != nil {
+			return RetryPolicy{}, fmt.Errorf("parse %s.base_delay %q: %w", field, r.BaseDelay, err)
+		}
+		p.BaseDelay = d
+	}
+	return p, nil
 }
 
 type rawRateLimitConfig struct {
-	MinDelay     string            `yaml:"min_delay"`
-	ATSOverrides map[string]string `yaml:"ats_overrides"`
+	MinDelay             string            `yaml:"min_delay"`
+	ATSOverrides         map[string]string `yaml:"ats_overrides"`
+	IntervalOverrides    map[string]string `yaml:"interval_overrides"`
+	StartupStagger       string            `yaml:"startup_stagger"`
+	MaxConcurrentFetches int               `yaml:"max_concurrent_fetches"`
+	MaxPerATSConcurrency int               `yaml:"max_per_ats_concurrency"`
 }
 
 type rawFilterConfig struct {
@@ -96,20 +709,48 @@ type rawFilterConfig struct {
 	Locations            []string `yaml:"locations"`
 	ExcludeLocations     []string `yaml:"exclude_locations"`
 	MaxAge               string   `yaml:"max_age"`
+	Seniority            []string `yaml:"seniority"`
+	MaxNewPerPoll        int      `yaml:"max_new_per_poll"`
+	Countries            []string `yaml:"countries"`
+	ExcludeCountries     []string `yaml:"exclude_countries"`
+	RemoteOnly           bool     `yaml:"remote_only"`
+	TitleMatchAll        bool     `yaml:"title_match_all"`
+	BlockJobIDs          []string `yaml:"block_job_ids"`
+	AllowJobIDs          []string `yaml:"allow_job_ids"`
+	FreshnessBasis       string   `yaml:"freshness_basis"`
+	PostingStatuses      []string `yaml:"posting_statuses"`
+
+	CrossCompanyDedup        bool `yaml:"cross_company_dedup"`
+	CrossCompanyDedupPersist bool `yaml:"cross_company_dedup_persist"`
 }
 
-// Load reads and parses the YAML config file at path, validates it, and returns Config.
-func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
+// Load reads and parses the config files at paths, validates the result,
+// and returns Config. Files ending in .json are parsed as JSON, anything else
+// as YAML; both use the same keys. A directory stands for its .yaml, .yml and
+// .json files in name order.
+//
+// Several files are merged in order (see mergeConfig): later files override
+// earlier scalars and lists, nested sections merge key by key, and companies
+// merge by name, so an override file can add companies or change fields of
+// existing ones. Relative template paths resolve against the directory of
+// the file that sets them.
+func Load(paths ...string) (*Config, error) {
+	files, err := configFiles(paths)
 	if err != nil {
-		return nil, fmt.Errorf("read config: %w", err)
+		return nil, err
 	}
 
-	// Expand environment variables
-	expanded := os.ExpandEnv(string(data))
+	merged := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range files {
+		node, err := readConfigNode(f)
+		if err != nil {
+			return nil, err
+		}
+		mergeConfig(merged, node)
+	}
 
 	var raw rawConfig
-	if err := yaml.Unmarshal([]byte(expanded), &raw); err != nil {
+	if err := merged.Decode(&raw); err != nil {
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
@@ -118,6 +759,22 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("parse polling_interval %q: %w", raw.PollingInterval, err)
 	}
 
+	shutdownTimeout := 30 * time.Second // default
+	if raw.ShutdownTimeout != "" {
+		shutdownTimeout, err = time.ParseDuration(raw.ShutdownTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("parse shutdown_timeout %q: %w", raw.ShutdownTimeout, err)
+		}
+	}
+
+	pollTimeout := 60 * time.Second // default
+	if raw.PollTimeout != "" {
+		pollTimeout, err = time.ParseDuration(raw.PollTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("parse poll_timeout %q: %w", raw.PollTimeout, err)
+		}
+	}
+
 	maxAge := 1 * time.Hour // default: 1 hour
 	if raw.Filters.MaxAge != "" {
 		maxAge, err = time.ParseDuration(raw.Filters.MaxAge)
@@ -143,6 +800,45 @@ func Load(path string) (*Config, error) {
 		atsOverrides[ats] = d
 	}
 
+	intervalOverrides := make(map[string]time.Duration)
+	for ats, raw := range raw.RateLimit.IntervalOverrides {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parse rate_limit.interval_overrides[%q]: %w", ats, err)
+		}
+		intervalOverrides[ats] = d
+	}
+
+	var startupStagger time.Duration
+	if raw.RateLimit.StartupStagger != "" {
+		startupStagger, err = time.ParseDuration(raw.RateLimit.StartupStagger)
+		if err != nil {
+			return nil, fmt.Errorf("parse rate_limit.startup_stagger %q: %w", raw.RateLimit.StartupStagger, err)
+		}
+	}
+
+	// Fetch retries default to 2 retries from a 5s base delay; per-ATS
+	// overrides inherit any field they leave unset from the default.
+	retryDefault, err := raw.Retry.rawRetryPolicy.resolve(RetryPolicy{MaxRetries: 2, BaseDelay: 5 * time.Second}, "retry")
+	if err != nil {
+		return nil, err
+	}
+	retryOverrides := make(map[string]RetryPolicy)
+	for ats, override := range raw.Retry.ATSOverrides {
+		p, err := override.resolve(retryDefault, fmt.Sprintf("retry.ats_overrides[%q]", ats))
+		if err != nil {
+			return nil, err
+		}
+		retryOverrides[ats] = p
+	}
+	maxRetryAfter := 60 * time.Second // default
+	if raw.Retry.MaxRetryAfter != "" {
+		maxRetryAfter, err = time.ParseDuration(raw.Retry.MaxRetryAfter)
+		if err != nil {
+			return nil, fmt.Errorf("parse retry.max_retry_after %q: %w", raw.Retry.MaxRetryAfter, err)
+		}
+	}
+
 	aiTimeout := 30 * time.Second // default
 	if raw.AI.Timeout != "" {
 		aiTimeout, err = time.ParseDuration(raw.AI.Timeout)
@@ -151,25 +847,114 @@ func Load(path string) (*Config, error) {
 		}
 	}
 
+	httpTimeout := 30 * time.Second // default
+	if raw.HTTP.Timeout != "" {
+		httpTimeout, err = time.ParseDuration(raw.HTTP.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("parse http.timeout %q: %w", raw.HTTP.Timeout, err)
+		}
+	}
+	maxResponseBytes := raw.HTTP.MaxResponseBytes
+	if maxResponseBytes == 0 {
+		maxResponseBytes = DefaultMaxResponseBytes
+	}
+
+	// *_file secrets take precedence over their inline values.
+	secrets := []secretFile{{"ai.api_key_file", raw.AI.APIKeyFile, &raw.AI.APIKey}}
+	secrets = append(secrets, notificationSecrets("notification", &raw.Notification)...)
+	// Routed notifiers take the same *_file keys. Map values are not
+	// addressable, so each route is resolved in a copy and stored back.
+	routes := make(map[string]*NotificationConfig, len(raw.Notification.RoleRoutes))
+	for _, role := range slices.Sorted(maps.Keys(raw.Notification.RoleRoutes)) {
+		route := raw.Notification.RoleRoutes[role]
+		routes[role] = &route
+		secrets = append(secrets, notificationSecrets(fmt.Sprintf("notification.role_routes[%q]", role), &route)...)
+	}
+	for _, sec := range secrets {
+		if sec.path == "" {
+			continue
+		}
+		data, err := os.ReadFile(sec.path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sec.key, err)
+		}
+		*sec.dst = strings.TrimSpace(string(data))
+	}
+	for role, route := range routes {
+		raw.Notification.RoleRoutes[role] = *route
+	}
+
+	aiMaxRetries := 2 // default
+	if raw.AI.MaxRetries != nil {
+		aiMaxRetries = *raw.AI.MaxRetries
+	}
+
+	aiMaxDescriptionChars := 6000 // default
+	if raw.AI.MaxDescriptionChars != nil {
+		aiMaxDescriptionChars = *raw.AI.MaxDescriptionChars
+	}
+
 	aiBaseURL := raw.AI.BaseURL
 	if aiBaseURL == "" {
 		aiBaseURL = defaultOpenAIBaseURL
 	}
 
+	// Currency codes are matched case-insensitively against pay range data.
+	var fxRates map[string]float64
+	if len(raw.Display.FXRates) > 0 {
+		fxRates = make(map[string]float64, len(raw.Display.FXRates))
+		for code, rate := range raw.Display.FXRates {
+			fxRates[strings.ToUpper(code)] = rate
+		}
+	}
+
+	if raw.Store.Type == "" {
+		raw.Store.Type = "sqlite"
+	}
+
+	freshnessBasis := raw.Filters.FreshnessBasis
+	if freshnessBasis == "" {
+		freshnessBasis = "posted"
+	}
+
 	cfg := &Config{
 		PollingInterval: interval,
-		Companies: raw.Companies,
+		ShutdownTimeout: shutdownTimeout,
+		PollTimeout:     pollTimeout,
+		Companies:       raw.Companies,
 		Filters: FilterConfig{
 			TitleKeywords:        raw.Filters.TitleKeywords,
 			TitleExcludeKeywords: raw.Filters.TitleExcludeKeywords,
 			Locations:            raw.Filters.Locations,
 			ExcludeLocations:     raw.Filters.ExcludeLocations,
 			MaxAge:               maxAge,
+			Seniority:            raw.Filters.Seniority,
+			MaxNewPerPoll:        raw.Filters.MaxNewPerPoll,
+			Countries:            raw.Filters.Countries,
+			ExcludeCountries:     raw.Filters.ExcludeCountries,
+			RemoteOnly:           raw.Filters.RemoteOnly,
+			TitleMatchAll:        raw.Filters.TitleMatchAll,
+			BlockJobIDs:          raw.Filters.BlockJobIDs,
+			AllowJobIDs:          raw.Filters.AllowJobIDs,
+			PostingStatuses:      raw.Filters.PostingStatuses,
+			FreshnessBasis:       freshnessBasis,
+
+			CrossCompanyDedup:        raw.Filters.CrossCompanyDedup,
+			CrossCompanyDedupPersist: raw.Filters.CrossCompanyDedupPersist,
 		},
 		Notification: raw.Notification,
 		RateLimit: RateLimitConfig{
-			MinDelay:     rateLimitDelay,
-			ATSOverrides: atsOverrides,
+			MinDelay:             rateLimitDelay,
+			ATSOverrides:         atsOverrides,
+			IntervalOverrides:    intervalOverrides,
+			StartupStagger:       startupStagger,
+			MaxConcurrentFetches: raw.RateLimit.MaxConcurrentFetches,
+			MaxPerATSConcurrency: raw.RateLimit.MaxPerATSConcurrency,
+		},
+		Retry: RetryConfig{
+			RetryPolicy:   retryDefault,
+			ATSOverrides:  retryOverrides,
+			MaxRetryAfter: maxRetryAfter,
 		},
 		AI: AIConfig{
 			Enabled: raw.AI.Enabled,
@@ -177,7 +962,30 @@ func Load(path string) (*Config, error) {
 			Model:   raw.AI.Model,
 			APIKey:  raw.AI.APIKey,
 			Timeout: aiTimeout,
+
+			MaxRetries: aiMaxRetries,
+
+			PromptTemplate: raw.AI.PromptTemplate,
+
+			MaxDescriptionChars: aiMaxDescriptionChars,
+
+			InputCostPer1M:  raw.AI.InputCostPer1M,
+			OutputCostPer1M: raw.AI.OutputCostPer1M,
+		},
+		Display: DisplayConfig{
+			FXRates: fxRates,
 		},
+		Audit:   raw.Audit,
+		Server:  raw.Server,
+		Store:   raw.Store,
+		Tracing: raw.Tracing,
+		HTTP: HTTPConfig{
+			Timeout:          httpTimeout,
+			UserAgent:        raw.HTTP.UserAgent,
+			ProxyURL:         raw.HTTP.ProxyURL,
+			MaxResponseBytes: maxResponseBytes,
+		},
+		Tags: raw.Tags,
 	}
 
 	if err := validate(cfg); err != nil {
@@ -191,27 +999,154 @@ func validate(cfg *Config) error {
 	if cfg.PollingInterval <= 0 {
 		return fmt.Errorf("polling_interval must be positive, got %v", cfg.PollingInterval)
 	}
+	if cfg.ShutdownTimeout < 0 {
+		return fmt.Errorf("shutdown_timeout must not be negative, got %v", cfg.ShutdownTimeout)
+	}
+	if cfg.PollTimeout < 0 {
+		return fmt.Errorf("poll_timeout must not be negative, got %v", cfg.PollTimeout)
+	}
 	enabled := 0
 	for _, c := range cfg.Companies {
 		if c.Enabled {
 			enabled++
 		}
+		if c.MaxAge != 0 && (c.MaxAge < 1*time.Hour || c.MaxAge > 24*time.Hour) {
+			return fmt.Errorf("companies[%q].max_age must be between 1h and 24h, got %v", c.Name, c.MaxAge)
+		}
+		if c.DepartmentID != 0 && c.ATS != "greenhouse" {
+			return fmt.Errorf("companies[%q].department_id is only supported for greenhouse", c.Name)
+		}
+		if c.ActiveWindow.Enabled() {
+			if _, _, _, _, err := c.ActiveWindow.Window(); err != nil {
+				return fmt.Errorf("companies[%q].active_window: %w", c.Name, err)
+			}
+		}
 	}
 	if enabled == 0 {
 		return fmt.Errorf("at least one company must be enabled")
 	}
 
+	if cfg.Tracing.Enabled && cfg.Tracing.Endpoint == "" {
+		return fmt.Errorf("tracing.endpoint is required when tracing.enabled is true")
+	}
+
+	if cfg.HTTP.Timeout <= 0 {
+		return fmt.Errorf("http.timeout must be positive, got %v", cfg.HTTP.Timeout)
+	}
+	if cfg.HTTP.MaxResponseBytes <= 0 {
+		return fmt.Errorf("http.max_response_bytes must be positive, got %d", cfg.HTTP.MaxResponseBytes)
+	}
+	if cfg.HTTP.ProxyURL != "" {
+		u, err := url.Parse(cfg.HTTP.ProxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("http.proxy_url %q must be an absolute URL like http://host:port", cfg.HTTP.ProxyURL)
+		}
+	}
+
+	for ats, d := range cfg.RateLimit.IntervalOverrides {
+		if d <= 0 {
+			return fmt.Errorf("rate_limit.interval_overrides[%q] must be positive, got %s", ats, d)
+		}
+	}
+	if cfg.RateLimit.StartupStagger < 0 {
+		return fmt.Errorf("rate_limit.startup_stagger must not be negative, got %s", cfg.RateLimit.StartupStagger)
+	}
+	if cfg.RateLimit.MaxConcurrentFetches < 0 {
+		return fmt.Errorf("rate_limit.max_concurrent_fetches must not be negative, got %d", cfg.RateLimit.MaxConcurrentFetches)
+	}
+	if cfg.RateLimit.MaxPerATSConcurrency < 0 {
+		return fmt.Errorf("rate_limit.max_per_ats_concurrency must not be negative, got %d", cfg.RateLimit.MaxPerATSConcurrency)
+	}
+
+	for ats, p := range cfg.Retry.ATSOverrides {
+		if p.MaxRetries < 0 || p.BaseDelay < 0 {
+			return fmt.Errorf("retry.ats_overrides[%q]: max_retries and base_delay must not be negative", ats)
+		}
+	}
+	if cfg.Retry.MaxRetries < 0 || cfg.Retry.BaseDelay < 0 {
+		return fmt.Errorf("retry: max_retries and base_delay must not be negative")
+	}
+	if cfg.Retry.MaxRetryAfter <= 0 {
+		return fmt.Errorf("retry.max_retry_after must be positive, got %s", cfg.Retry.MaxRetryAfter)
+	}
+
 	if cfg.Filters.MaxAge < 1*time.Hour || cfg.Filters.MaxAge > 24*time.Hour {
 		return fmt.Errorf("filters.max_age must be between 1h and 24h, got %v", cfg.Filters.MaxAge)
 	}
 
-	if cfg.Notification.Type == "slack" {
-		if cfg.Notification.WebhookURL == "" {
-			return fmt.Errorf("notification.webhook_url is required when type is \"slack\"")
+	if cfg.Filters.MaxNewPerPoll < 0 {
+		return fmt.Errorf("filters.max_new_per_poll must not be negative, got %d", cfg.Filters.MaxNewPerPoll)
+	}
+
+	if cfg.Filters.FreshnessBasis != "posted" && cfg.Filters.FreshnessBasis != "first_seen" {
+		return fmt.Errorf("filters.freshness_basis must be \"posted\" or \"first_seen\", got %q", cfg.Filters.FreshnessBasis)
+	}
+
+	if cfg.Filters.CrossCompanyDedupPersist && !cfg.Filters.CrossCompanyDedup {
+		return fmt.Errorf("filters.cross_company_dedup_persist requires filters.cross_company_dedup: true")
+	}
+
+	for _, level := range cfg.Filters.Seniority {
+		if !slices.Contains(model.SeniorityLevels, strings.ToLower(level)) {
+			return fmt.Errorf("filters.seniority: unknown level %q (valid: %s)", level, strings.Join(model.SeniorityLevels, ", "))
 		}
-		if len(cfg.Notification.WebhookURL) < len("https://hooks.slack.com/") ||
-			cfg.Notification.WebhookURL[:len("https://hooks.slack.com/")] != "https://hooks.slack.com/" {
-			return fmt.Errorf("notification.webhook_url must start with https://hooks.slack.com/")
+	}
+	for _, status := range cfg.Filters.PostingStatuses {
+		if !slices.Contains(model.PostingStatuses, strings.ToLower(status)) {
+			return fmt.Errorf("filters.posting_statuses: unknown status %q (valid: %s)", status, strings.Join(model.PostingStatuses, ", "))
+		}
+	}
+	if len(cfg.Filters.Seniority) > 0 && !cfg.AI.Enabled {
+		return fmt.Errorf("filters.seniority requires ai.enabled: true")
+	}
+
+	if err := validateNotification(cfg.Notification); err != nil {
+		return err
+	}
+	for role, route := range cfg.Notification.RoleRoutes {
+		if len(route.RoleRoutes) > 0 {
+			return fmt.Errorf("notification.role_routes[%q]: role_routes cannot be nested", role)
+		}
+		if err := validateNotification(route); err != nil {
+			return fmt.Errorf("notification.role_routes[%q]: %w", role, err)
+		}
+	}
+	if len(cfg.Notification.RoleRoutes) > 0 && !cfg.AI.Enabled {
+		return fmt.Errorf("notification.role_routes requires ai.enabled: true")
+	}
+
+	if cfg.Notification.QuietHours.Enabled() {
+		if _, _, _, err := cfg.Notification.QuietHours.Window(); err != nil {
+			return fmt.Errorf("notification.quiet_hours: %w", err)
+		}
+	}
+	if cfg.Notification.Digest.Enabled() {
+		if _, _, _, err := cfg.Notification.Digest.Schedule(); err != nil {
+			return fmt.Errorf("notification.digest: %w", err)
+		}
+	}
+
+	for i, rule := range cfg.Tags {
+		if strings.TrimSpace(rule.Tag) == "" || len(rule.Keywords) == 0 {
+			return fmt.Errorf("tags[%d]: tag and keywords are required", i)
+		}
+	}
+
+	if cfg.Store.Type != "sqlite" && cfg.Store.Type != "memory" {
+		return fmt.Errorf("store.type must be \"sqlite\" or \"memory\", got %q", cfg.Store.Type)
+	}
+
+	if cfg.Notification.ChangeChecksPerPoll < 0 {
+		return fmt.Errorf("notification.change_checks_per_poll must not be negative, got %d", cfg.Notification.ChangeChecksPerPoll)
+	}
+
+	if cfg.Audit.MaxPages < 0 {
+		return fmt.Errorf("audit.max_pages must not be negative, got %d", cfg.Audit.MaxPages)
+	}
+
+	for code, rate := range cfg.Display.FXRates {
+		if rate <= 0 {
+			return fmt.Errorf("display.fx_rates[%q] must be positive, got %v", code, rate)
 		}
 	}
 
@@ -225,6 +1160,80 @@ func validate(cfg *Config) error {
 		if cfg.AI.Model == "" {
 			return fmt.Errorf("ai.model is required when ai.enabled is true")
 		}
+		if cfg.AI.MaxRetries < 0 {
+			return fmt.Errorf("ai.max_retries must not be negative, got %d", cfg.AI.MaxRetries)
+		}
+		if cfg.AI.MaxDescriptionChars < 0 {
+			return fmt.Errorf("ai.max_description_chars must not be negative, got %d", cfg.AI.MaxDescriptionChars)
+		}
+		if cfg.AI.PromptTemplate != "" {
+			data, err := os.ReadFile(cfg.AI.PromptTemplate)
+			if err != nil {
+				return fmt.Errorf("ai.prompt_template: %w", err)
+			}
+			if _, err := template.New("prompt_template").Parse(string(data)); err != nil {
+				return fmt.Errorf("ai.prompt_template %s: %w", cfg.AI.PromptTemplate, err)
+			}
+		}
+		if cfg.AI.InputCostPer1M < 0 || cfg.AI.OutputCostPer1M < 0 {
+			return fmt.Errorf("ai.input_cost_per_1m and ai.output_cost_per_1m must not be negative")
+		}
+	}
+
+	return nil
+}
+
+// validateNotification checks the settings one notifier needs for its type.
+func validateNotification(n NotificationConfig) error {
+	if n.Type == "slack" && n.SlackThreaded {
+		if n.BotToken == "" || n.Channel == "" {
+			return fmt.Errorf("notification.bot_token and channel are required when slack_threaded is true")
+		}
+	} else if n.Type == "slack" {
+		if n.WebhookURL == "" {
+			return fmt.Errorf("notification.webhook_url is required when type is \"slack\"")
+		}
+		if len(n.WebhookURL) < len("https://hooks.slack.com/") ||
+			n.WebhookURL[:len("https://hooks.slack.com/")] != "https://hooks.slack.com/" {
+			return fmt.Errorf("notification.webhook_url must start with https://hooks.slack.com/")
+		}
+	}
+
+	if n.SlackTemplate != "" {
+		if _, err := os.Stat(n.SlackTemplate); err != nil {
+			return fmt.Errorf("notification.slack_template: %w", err)
+		}
+	}
+
+	if n.MessageDelay != nil && *n.MessageDelay < 0 {
+		return fmt.Errorf("notification.message_delay must not be negative, got %v", *n.MessageDelay)
+	}
+
+	if n.Type == "matrix" {
+		if n.HomeserverURL == "" || n.RoomID == "" || n.AccessToken == "" {
+			return fmt.Errorf("notification.homeserver_url, room_id and access_token are required when type is \"matrix\"")
+		}
+		if !strings.HasPrefix(n.HomeserverURL, "https://") && !strings.HasPrefix(n.HomeserverURL, "http://") {
+			return fmt.Errorf("notification.homeserver_url must be an http(s) URL")
+		}
+	}
+
+	if n.Type == "pagerduty" {
+		if n.RoutingKey == "" {
+			return fmt.Errorf("notification.routing_key is required when type is \"pagerduty\"")
+		}
+		if len(n.PriorityKeywords) == 0 {
+			return fmt.Errorf("notification.priority_keywords is required when type is \"pagerduty\" (paging on every match is not supported)")
+		}
+		if n.Severity != "" && !slices.Contains(PagerDutySeverities, n.Severity) {
+			return fmt.Errorf("notification.severity must be one of %s, got %q", strings.Join(PagerDutySeverities, ", "), n.Severity)
+		}
+	}
+
+	if n.Type == "ntfy" {
+		if !strings.HasPrefix(n.TopicURL, "https://") && !strings.HasPrefix(n.TopicURL, "http://") {
+			return fmt.Errorf("notification.topic_url must be an http(s) URL when type is \"ntfy\"")
+		}
 	}
 
 	return nil
@@ -3,6 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -45,6 +48,99 @@ filters:
 	}
 }
 
+func TestLoad_JSONMatchesYAML(t *testing.T) {
+	yamlContent := `
+polling_interval: 5m
+poll_timeout: 45s
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+    max_age: 2h
+  - name: globex
+    ats: workday
+    workday_url: "https://globex.wd5.myworkdayjobs.com/en-US/careers"
+    enabled: true
+    workday_facets:
+      locationCountry: ["bc33aa3152ec42d4995f4791a106ed09"]
+filters:
+  title_keywords: [engineer]
+  max_age: 12h
+  block_job_ids: ["123"]
+notification:
+  type: log
+  quiet_hours:
+    start: "22:00"
+    end: "07:00"
+rate_limit:
+  min_delay: 30s
+  ats_overrides:
+    workday: 1m
+retry:
+  ats_overrides:
+    workday:
+      max_retries: 4
+http:
+  timeout: 10s
+`
+	jsonContent := `{
+	"polling_interval": "5m",
+	"poll_timeout": "45s",
+	"companies": [
+		{"name": "acme", "ats": "greenhouse", "board_token": "acme", "enabled": true, "max_age": "2h"},
+		{
+			"name": "globex",
+			"ats": "workday",
+			"workday_url": "https://globex.wd5.myworkdayjobs.com/en-US/careers",
+			"enabled": true,
+			"workday_facets": {"locationCountry": ["bc33aa3152ec42d4995f4791a106ed09"]}
+		}
+	],
+	"filters": {"title_keywords": ["engineer"], "max_age": "12h", "block_job_ids": ["123"]},
+	"notification": {"type": "log", "quiet_hours": {"start": "22:00", "end": "07:00"}},
+	"rate_limit": {"min_delay": "30s", "ats_overrides": {"workday": "1m"}},
+	"retry": {"ats_overrides": {"workday": {"max_retries": 4}}},
+	"http": {"timeout": "10s"}
+}`
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "config.yaml")
+	jsonPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(yamlPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonPath, []byte(jsonContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fromYAML, err := Load(yamlPath)
+	if err != nil {
+		t.Fatalf("Load(yaml): %v", err)
+	}
+	fromJSON, err := Load(jsonPath)
+	if err != nil {
+		t.Fatalf("Load(json): %v", err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("JSON config differs from YAML:\n json: %+v\n yaml: %+v", fromJSON, fromYAML)
+	}
+	if fromJSON.Companies[0].MaxAge != 2*time.Hour {
+		t.Errorf("companies[0].max_age = %v, want 2h", fromJSON.Companies[0].MaxAge)
+	}
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	// A trailing comma is fine in YAML flow syntax but not in JSON.
+	content := `{"polling_interval": "5m", "companies": [{"name": "acme", "ats": "greenhouse", "board_token": "acme", "enabled": true},]}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load: expected error for malformed JSON")
+	}
+}
+
 func TestLoad_MissingFile(t *testing.T) {
 	_, err := Load(filepath.Join(t.TempDir(), "nonexistent.yaml"))
 	if err == nil {
@@ -112,3 +208,1037 @@ filters:
 		t.Fatal("Load: expected validation error when no company is enabled")
 	}
 }
+
+func TestLoad_DisplayFXRates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+display:
+  fx_rates:
+    eur: 1.08
+    INR: 0.012
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := cfg.Display.FXRates["EUR"]; got != 1.08 {
+		t.Errorf("FXRates[EUR] = %v, want 1.08 (keys upper-cased)", got)
+	}
+	if got := cfg.Display.FXRates["INR"]; got != 0.012 {
+		t.Errorf("FXRates[INR] = %v, want 0.012", got)
+	}
+}
+
+func TestLoad_PromptTemplate(t *testing.T) {
+	dir := t.TempDir()
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+ai:
+  enabled: true
+  api_key: "key"
+  model: "gpt-4o-mini"
+  prompt_template: "prompt.tmpl"
+`
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(base), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmplPath := filepath.Join(dir, "prompt.tmpl")
+	if err := os.WriteFile(tmplPath, []byte("Summarize: {{.Description}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.AI.PromptTemplate != tmplPath {
+		t.Errorf("PromptTemplate = %q, want %q (resolved against config dir)", cfg.AI.PromptTemplate, tmplPath)
+	}
+
+	if err := os.WriteFile(tmplPath, []byte("Summarize: {{.Description"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load: expected error for unparseable prompt template")
+	}
+}
+
+func TestLoad_MutedCompany(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+  - name: noisy
+    ats: lever
+    board_token: "noisy"
+    enabled: true
+    muted: true
+  - name: Other
+    ats: ashby
+    board_token: "other"
+    enabled: true
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Companies) != 3 {
+		t.Fatalf("Companies = %d, want 3 (muted companies stay configured)", len(cfg.Companies))
+	}
+	if !cfg.Companies[0].Active() || cfg.Companies[1].Active() {
+		t.Errorf("Active() = %v/%v, want true/false", cfg.Companies[0].Active(), cfg.Companies[1].Active())
+	}
+
+	unknown := cfg.Mute([]string{"other", "missing"})
+	if cfg.Companies[2].Active() {
+		t.Error("Mute(other) should mute \"Other\" case-insensitively")
+	}
+	if len(unknown) != 1 || unknown[0] != "missing" {
+		t.Errorf("Mute unknown = %v, want [missing]", unknown)
+	}
+}
+
+func TestLoad_MatrixNotification(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: matrix
+`
+	tests := []struct {
+		name    string
+		extra   string
+		wantErr bool
+	}{
+		{"complete", "  homeserver_url: https://matrix.org\n  room_id: \"!r:matrix.org\"\n  access_token: tok\n", false},
+		{"missing token", "  homeserver_url: https://matrix.org\n  room_id: \"!r:matrix.org\"\n", true},
+		{"bad homeserver", "  homeserver_url: matrix.org\n  room_id: \"!r:matrix.org\"\n  access_token: tok\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_PagerDutySeverity(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: pagerduty
+  routing_key: rk
+  priority_keywords: [staff]
+`
+	tests := []struct {
+		name    string
+		extra   string
+		wantErr bool
+	}{
+		{"default", "", false},
+		{"critical", "  severity: critical\n", false},
+		{"unknown", "  severity: urgent\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_SlackThreaded(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: slack
+`
+	tests := []struct {
+		name    string
+		extra   string
+		wantErr bool
+	}{
+		{"threaded with bot token", "  slack_threaded: true\n  bot_token: xoxb-1\n  channel: C123\n", false},
+		{"threaded missing channel", "  slack_threaded: true\n  bot_token: xoxb-1\n", true},
+		{"threaded ignores webhook", "  slack_threaded: true\n  bot_token: xoxb-1\n  channel: C123\n  webhook_url: nope\n", false},
+		{"webhook still required unthreaded", "  bot_token: xoxb-1\n  channel: C123\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_MessageDelay(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: slack
+  webhook_url: https://hooks.slack.com/services/x
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    *time.Duration
+		wantErr bool
+	}{
+		{"unset", "", nil, false},
+		{"set", "  message_delay: 1s\n", durationPtr(time.Second), false},
+		{"zero", "  message_delay: 0s\n", durationPtr(0), false},
+		{"negative", "  message_delay: -1s\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := cfg.Notification.MessageDelay
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("MessageDelay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration { return &d }
+
+func TestLoad_FreshnessBasis(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "posted", false},
+		{"first seen", "filters:\n  freshness_basis: first_seen\n", "first_seen", false},
+		{"unknown", "filters:\n  freshness_basis: updated\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Filters.FreshnessBasis != tt.want {
+				t.Errorf("FreshnessBasis = %q, want %q", cfg.Filters.FreshnessBasis, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_Tags(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    []TagRule
+		wantErr bool
+	}{
+		{"unset", "", nil, false},
+		{"rules", "tags:\n  - tag: Go\n    keywords: [go, golang]\n  - tag: Remote\n    keywords: [remote]\n",
+			[]TagRule{{Tag: "Go", Keywords: []string{"go", "golang"}}, {Tag: "Remote", Keywords: []string{"remote"}}}, false},
+		{"missing keywords", "tags:\n  - tag: Go\n", nil, true},
+		{"missing tag", "tags:\n  - keywords: [go]\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(cfg.Tags, tt.want) {
+				t.Errorf("Tags = %+v, want %+v", cfg.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_RetryConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+retry:
+  max_retries: 3
+  ats_overrides:
+    workday:
+      max_retries: 5
+      base_delay: 10s
+    lever:
+      max_retries: 0
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		ats  string
+		want RetryPolicy
+	}{
+		{"greenhouse", RetryPolicy{MaxRetries: 3, BaseDelay: 5 * time.Second}}, // default base_delay
+		{"workday", RetryPolicy{MaxRetries: 5, BaseDelay: 10 * time.Second}},
+		{"lever", RetryPolicy{MaxRetries: 0, BaseDelay: 5 * time.Second}}, // inherits base_delay
+	}
+	for _, tt := range tests {
+		if got := cfg.Retry.For(tt.ats); got != tt.want {
+			t.Errorf("Retry.For(%q) = %+v, want %+v", tt.ats, got, tt.want)
+		}
+	}
+	if cfg.Retry.MaxRetryAfter != 60*time.Second {
+		t.Errorf("Retry.MaxRetryAfter = %v, want default 60s", cfg.Retry.MaxRetryAfter)
+	}
+}
+
+func TestLoad_RetryMaxRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"set", "2m", 2 * time.Minute, false},
+		{"zero", "0s", 0, true},
+		{"invalid", "soon", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			content := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+retry:
+  max_retry_after: ` + tt.value + "\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Load: want error for max_retry_after %q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.Retry.MaxRetryAfter != tt.want {
+				t.Errorf("Retry.MaxRetryAfter = %v, want %v", cfg.Retry.MaxRetryAfter, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_IntervalOverrides(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+rate_limit:
+  interval_overrides:
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{"per ats", "    greenhouse: 2m\n    workday: 10m\n", map[string]time.Duration{"greenhouse": 2 * time.Minute, "workday": 10 * time.Minute}, false},
+		{"invalid duration", "    workday: often\n", nil, true},
+		{"zero", "    workday: 0s\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(cfg.RateLimit.IntervalOverrides) != len(tt.want) {
+				t.Fatalf("IntervalOverrides = %v, want %v", cfg.RateLimit.IntervalOverrides, tt.want)
+			}
+			for ats, d := range tt.want {
+				if got := cfg.RateLimit.IntervalOverrides[ats]; got != d {
+					t.Errorf("IntervalOverrides[%q] = %v, want %v", ats, got, d)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_RetryConfigInvalidDelay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+retry:
+  ats_overrides:
+    workday:
+      base_delay: soon
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load: expected error for invalid retry base_delay")
+	}
+}
+
+func TestLoad_HTTPConfig(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    HTTPConfig
+		wantErr bool
+	}{
+		{"defaults", "", HTTPConfig{Timeout: 30 * time.Second, MaxResponseBytes: DefaultMaxResponseBytes}, false},
+		{"custom", "http:\n  timeout: 10s\n  user_agent: bot/1\n  proxy_url: http://proxy:3128\n  max_response_bytes: 1048576\n",
+			HTTPConfig{Timeout: 10 * time.Second, UserAgent: "bot/1", ProxyURL: "http://proxy:3128", MaxResponseBytes: 1 << 20}, false},
+		{"zero timeout", "http:\n  timeout: 0s\n", HTTPConfig{}, true},
+		{"negative max_response_bytes", "http:\n  max_response_bytes: -1\n", HTTPConfig{}, true},
+		{"relative proxy", "http:\n  proxy_url: proxy:3128\n", HTTPConfig{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.HTTP != tt.want {
+				t.Errorf("HTTP = %+v, want %+v", cfg.HTTP, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_CompanyMaxAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxAge  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"override", "    max_age: 24h\n", 24 * time.Hour, false},
+		{"unset uses global", "", 2 * time.Hour, false},
+		{"out of range", "    max_age: 30m\n", 0, true},
+		{"not a duration", "    max_age: soon\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := `
+polling_interval: 5m
+filters:
+  max_age: 2h
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+` + tt.maxAge
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := cfg.Companies[0].MaxAgeOr(cfg.Filters.MaxAge); got != tt.want {
+				t.Errorf("MaxAgeOr = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_QuietHours(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: log
+`
+	tests := []struct {
+		name      string
+		extra     string
+		wantStart time.Duration
+		wantEnd   time.Duration
+		wantTZ    string
+		wantErr   bool
+	}{
+		{"overnight default timezone", "  quiet_hours:\n    start: \"22:00\"\n    end: \"07:30\"\n", 22 * time.Hour, 7*time.Hour + 30*time.Minute, "America/Los_Angeles", false},
+		{"explicit timezone", "  quiet_hours:\n    start: \"12:00\"\n    end: \"13:00\"\n    timezone: UTC\n", 12 * time.Hour, 13 * time.Hour, "UTC", false},
+		{"bad time", "  quiet_hours:\n    start: \"10pm\"\n    end: \"07:00\"\n", 0, 0, "", true},
+		{"missing end", "  quiet_hours:\n    start: \"22:00\"\n", 0, 0, "", true},
+		{"same start and end", "  quiet_hours:\n    start: \"07:00\"\n    end: \"07:00\"\n", 0, 0, "", true},
+		{"bad timezone", "  quiet_hours:\n    start: \"22:00\"\n    end: \"07:00\"\n    timezone: Mars/Olympus\n", 0, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			start, end, loc, err := cfg.Notification.QuietHours.Window()
+			if err != nil {
+				t.Fatalf("Window() error = %v", err)
+			}
+			if start != tt.wantStart || end != tt.wantEnd || loc.String() != tt.wantTZ {
+				t.Errorf("Window() = %v, %v, %v; want %v, %v, %v", start, end, loc, tt.wantStart, tt.wantEnd, tt.wantTZ)
+			}
+		})
+	}
+}
+
+func TestLoad_Digest(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: log
+`
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	tests := []struct {
+		name     string
+		extra    string
+		wantDays []time.Weekday
+		wantAt   time.Duration
+		wantTZ   string
+		wantErr  bool
+	}{
+		{"every day default timezone", "  digest:\n    at: \"08:00\"\n", nil, 8 * time.Hour, "America/Los_Angeles", false},
+		{"business days", "  digest:\n    at: \"07:30\"\n    days: [mon-fri]\n    timezone: UTC\n", weekdays, 7*time.Hour + 30*time.Minute, "UTC", false},
+		{"days without at", "  digest:\n    days: [mon-fri]\n", nil, 0, "", true},
+		{"bad time", "  digest:\n    at: \"8am\"\n", nil, 0, "", true},
+		{"bad day", "  digest:\n    at: \"08:00\"\n    days: [someday]\n", nil, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			days, at, loc, err := cfg.Notification.Digest.Schedule()
+			if err != nil {
+				t.Fatalf("Schedule() error = %v", err)
+			}
+			if !slices.Equal(days, tt.wantDays) || at != tt.wantAt || loc.String() != tt.wantTZ {
+				t.Errorf("Schedule() = %v, %v, %v; want %v, %v, %v", days, at, loc, tt.wantDays, tt.wantAt, tt.wantTZ)
+			}
+			if got := cfg.Notification.Digest.BufferPath(); got != "digest.json" {
+				t.Errorf("BufferPath() = %q, want digest.json", got)
+			}
+		})
+	}
+}
+
+func TestLoad_ActiveWindow(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+    active_window:
+`
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	tests := []struct {
+		name      string
+		extra     string
+		wantDays  []time.Weekday
+		wantStart time.Duration
+		wantEnd   time.Duration
+		wantTZ    string
+		wantErr   bool
+	}{
+		{"weekday mornings", "      days: [mon-fri]\n      start: \"08:00\"\n      end: \"12:00\"\n", weekdays, 8 * time.Hour, 12 * time.Hour, "America/Los_Angeles", false},
+		{"named days all day", "      days: [Saturday, sun]\n      timezone: UTC\n", []time.Weekday{time.Saturday, time.Sunday}, 0, 0, "UTC", false},
+		{"wrapping range", "      days: [fri-mon]\n", []time.Weekday{time.Friday, time.Saturday, time.Sunday, time.Monday}, 0, 0, "America/Los_Angeles", false},
+		{"bad day", "      days: [someday]\n", nil, 0, 0, "", true},
+		{"missing end", "      start: \"08:00\"\n", nil, 0, 0, "", true},
+		{"bad timezone", "      days: [mon]\n      timezone: Mars/Olympus\n", nil, 0, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			days, start, end, loc, err := cfg.Companies[0].ActiveWindow.Window()
+			if err != nil {
+				t.Fatalf("Window() error = %v", err)
+			}
+			if !reflect.DeepEqual(days, tt.wantDays) || start != tt.wantStart || end != tt.wantEnd || loc.String() != tt.wantTZ {
+				t.Errorf("Window() = %v, %v, %v, %v; want %v, %v, %v, %v", days, start, end, loc, tt.wantDays, tt.wantStart, tt.wantEnd, tt.wantTZ)
+			}
+		})
+	}
+}
+
+func TestLoad_SecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "secrets", "webhook"), "https://hooks.slack.com/services/T000/B000/FROMFILE\n")
+	writeFile(t, filepath.Join(dir, "secrets", "openai"), "  sk-from-file\n")
+
+	t.Setenv("FIRSTIN_TEST_SECRETS", filepath.Join(dir, "secrets"))
+	path := filepath.Join(dir, "config.yaml")
+	writeFile(t, path, `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: slack
+  webhook_url: "https://hooks.slack.com/services/T000/B000/INLINE"
+  webhook_url_file: "secrets/webhook"
+ai:
+  enabled: true
+  model: gpt-4o-mini
+  api_key: "sk-inline"
+  api_key_file: "${FIRSTIN_TEST_SECRETS}/openai"
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if want := "https://hooks.slack.com/services/T000/B000/FROMFILE"; cfg.Notification.WebhookURL != want {
+		t.Errorf("WebhookURL = %q, want %q (file wins, relative to config dir, trimmed)", cfg.Notification.WebhookURL, want)
+	}
+	if cfg.AI.APIKey != "sk-from-file" {
+		t.Errorf("APIKey = %q, want sk-from-file (env-expanded path, trimmed)", cfg.AI.APIKey)
+	}
+}
+
+func TestLoad_RoleRouteSecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "secrets", "ml-webhook"), "https://hooks.slack.com/services/T000/B000/ML\n")
+	path := filepath.Join(dir, "config.yaml")
+	writeFile(t, path, `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+ai:
+  enabled: true
+  model: gpt-4o-mini
+  api_key: "sk-test"
+notification:
+  type: log
+  role_routes:
+    "AI/ML":
+      type: slack
+      webhook_url_file: "secrets/ml-webhook"
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got, want := cfg.Notification.RoleRoutes["AI/ML"].WebhookURL, "https://hooks.slack.com/services/T000/B000/ML"; got != want {
+		t.Errorf("RoleRoutes[AI/ML].WebhookURL = %q, want %q (file relative to config dir, trimmed)", got, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, strings.Replace(string(data), "secrets/ml-webhook", "secrets/missing", 1))
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), `notification.role_routes["AI/ML"].webhook_url_file`) {
+		t.Errorf("Load error = %v, want it to name the route's webhook_url_file", err)
+	}
+}
+
+func TestLoad_SecretFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+notification:
+  type: slack
+  webhook_url_file: "missing/webhook"
+`)
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("Load: expected error for missing secret file")
+	}
+	if !strings.Contains(err.Error(), "notification.webhook_url_file") {
+		t.Errorf("error = %v, want it to name notification.webhook_url_file", err)
+	}
+}
+
+func TestLoad_StoreType(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "sqlite", false},
+		{"memory", "store:\n  type: memory\n", "memory", false},
+		{"unknown", "store:\n  type: redis\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeFile(t, path, base+tt.extra)
+			cfg, err := Load(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Load: expected error for unknown store.type")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.Store.Type != tt.want {
+				t.Errorf("Store.Type = %q, want %q", cfg.Store.Type, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_CrossCompanyDedup(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+filters:
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, base+"  cross_company_dedup: true\n  cross_company_dedup_persist: true\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.Filters.CrossCompanyDedup || !cfg.Filters.CrossCompanyDedupPersist {
+		t.Errorf("Filters = %+v, want cross-company dedup and persist enabled", cfg.Filters)
+	}
+
+	writeFile(t, path, base+"  cross_company_dedup_persist: true\n")
+	if _, err := Load(path); err == nil {
+		t.Error("Load: expected error for cross_company_dedup_persist without cross_company_dedup")
+	}
+}
+
+func TestLoad_RoleRoutes(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+ai:
+  enabled: true
+  model: gpt-4o-mini
+  api_key: "sk-test"
+notification:
+  type: log
+  role_routes:
+    "AI/ML":
+      type: ntfy
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, base+"      topic_url: \"https://ntfy.sh/ml-roles\"\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := cfg.Notification.RoleRoutes["AI/ML"].TopicURL; got != "https://ntfy.sh/ml-roles" {
+		t.Errorf("RoleRoutes[AI/ML].TopicURL = %q, want https://ntfy.sh/ml-roles", got)
+	}
+
+	// Each route is validated like the main notifier.
+	writeFile(t, path, base)
+	_, err = Load(path)
+	if err == nil || !strings.Contains(err.Error(), `role_routes["AI/ML"]`) {
+		t.Errorf("Load error = %v, want a role_routes[\"AI/ML\"] validation error", err)
+	}
+}
+
+func TestDetailPacingConflicts(t *testing.T) {
+	cfg := &Config{
+		PollTimeout: time.Minute,
+		RateLimit: RateLimitConfig{
+			MinDelay:     2 * time.Minute,
+			ATSOverrides: map[string]time.Duration{"lever": 10 * time.Second},
+		},
+		Companies: []CompanyConfig{
+			{Name: "acme", ATS: "greenhouse", Enabled: true},
+			{Name: "beta", ATS: "greenhouse", Enabled: true},
+			{Name: "gamma", ATS: "lever", Enabled: true},
+			{Name: "delta", ATS: "workday", Enabled: false},
+		},
+	}
+	if got := cfg.DetailPacingConflicts(); got != nil {
+		t.Errorf("without enrich: conflicts = %v, want none", got)
+	}
+
+	cfg.Notification.Enrich = true
+	if got := cfg.DetailPacingConflicts(); !slices.Equal(got, []string{"greenhouse"}) {
+		t.Errorf("with enrich: conflicts = %v, want [greenhouse]", got)
+	}
+
+	cfg.PollTimeout = 0
+	if got := cfg.DetailPacingConflicts(); got != nil {
+		t.Errorf("without poll_timeout: conflicts = %v, want none", got)
+	}
+}
+
+func TestLoad_ChangeChecksPerPoll(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    int
+		wantErr bool
+	}{
+		{"unset", "", 0, false},
+		{"set", "notification:\n  change_checks_per_poll: 20\n", 20, false},
+		{"negative", "notification:\n  change_checks_per_poll: -1\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Notification.ChangeChecksPerPoll != tt.want {
+				t.Errorf("Notification.ChangeChecksPerPoll = %d, want %d", cfg.Notification.ChangeChecksPerPoll, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_AuditMaxPages(t *testing.T) {
+	base := `
+polling_interval: 5m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+`
+	tests := []struct {
+		name    string
+		extra   string
+		want    int
+		wantErr bool
+	}{
+		{"unset", "", 0, false},
+		{"set", "audit:\n  max_pages: 100\n", 100, false},
+		{"negative", "audit:\n  max_pages: -1\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(base+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Audit.MaxPages != tt.want {
+				t.Errorf("Audit.MaxPages = %d, want %d", cfg.Audit.MaxPages, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFiles expands paths into the files to load, in order. A directory
+// contributes its *.yaml, *.yml and *.json files in name order.
+func configFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, fmt.Errorf("read config dir: %w", err)
+		}
+		var inDir []string
+		for _, e := range entries {
+			switch strings.ToLower(filepath.Ext(e.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !e.IsDir() {
+					inDir = append(inDir, filepath.Join(p, e.Name()))
+				}
+			}
+		}
+		if len(inDir) == 0 {
+			return nil, fmt.Errorf("read config dir %s: no .yaml, .yml or .json files", p)
+		}
+		sort.Strings(inDir)
+		files = append(files, inDir...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("read config: no config file given")
+	}
+	return files, nil
+}
+
+// relativePathKeys are the section/key pairs holding file paths, which
+// resolve against the directory of the config file that sets them. The
+// notification keys apply to each notification.role_routes entry too.
+var relativePathKeys = [][2]string{
+	{"ai", "prompt_template"},
+	{"ai", "api_key_file"},
+	{"notification", "slack_template"},
+	{"notification", "webhook_url_file"},
+	{"notification", "bot_token_file"},
+	{"notification", "access_token_file"},
+	{"notification", "routing_key_file"},
+}
+
+// readConfigNode reads one config file, expands environment variables and
+// parses it into a YAML node. Files ending in .json must be well-formed
+// JSON; JSON is valid YAML flow syntax, so the YAML parser then reads it and
+// the yaml tags and duration handling apply to both formats. Relative paths
+// in relativePathKeys are resolved against the file's directory.
+func readConfigNode(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	expanded := []byte(os.ExpandEnv(string(data)))
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var syntax json.RawMessage
+		if err := json.Unmarshal(expanded, &syntax); err != nil {
+			return nil, fmt.Errorf("parse config %s: invalid JSON: %w", path, err)
+		}
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(expanded, &doc); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		// Empty file: contributes nothing.
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse config %s: top level must be a mapping", path)
+	}
+
+	dir := filepath.Dir(path)
+	for _, key := range relativePathKeys {
+		rebasePath(lookup(lookup(root, key[0]), key[1]), dir)
+	}
+	// Routed notifiers take the same path keys as notification.
+	if routes := lookup(lookup(root, "notification"), "role_routes"); routes != nil && routes.Kind == yaml.MappingNode {
+		for i := 1; i < len(routes.Content); i += 2 {
+			for _, key := range relativePathKeys {
+				if key[0] == "notification" {
+					rebasePath(lookup(routes.Content[i], key[1]), dir)
+				}
+			}
+		}
+	}
+	return root, nil
+}
+
+// rebasePath joins a relative, non-empty path scalar v onto dir.
+func rebasePath(v *yaml.Node, dir string) {
+	if v != nil && v.Kind == yaml.ScalarNode && v.Value != "" && !filepath.IsAbs(v.Value) {
+		v.Value = filepath.Join(dir, v.Value)
+	}
+}
+
+// mergeConfig merges src into dst, both top-level mappings:
+//   - a key only in src is added
+//   - mappings present in both are merged recursively
+//   - companies lists are merged by name (case-insensitive): a src company
+//     whose name is already in dst is merged field by field into it, others
+//     are appended in order
+//   - anything else in src (scalars, other lists) replaces the dst value
+func mergeConfig(dst, src *yaml.Node) {
+	mergeMapping(dst, src, true)
+}
+
+func mergeMapping(dst, src *yaml.Node, top bool) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, val := src.Content[i], src.Content[i+1]
+		existing := lookup(dst, key.Value)
+		switch {
+		case existing == nil:
+			dst.Content = append(dst.Content, key, val)
+		case existing.Kind == yaml.MappingNode && val.Kind == yaml.MappingNode:
+			mergeMapping(existing, val, false)
+		case top && key.Value == "companies" && existing.Kind == yaml.SequenceNode && val.Kind == yaml.SequenceNode:
+			mergeCompanies(existing, val)
+		default:
+			*existing = *val
+		}
+	}
+}
+
+// mergeCompanies merges the companies sequence src into dst by name.
+func mergeCompanies(dst, src *yaml.Node) {
+	for _, company := range src.Content {
+		name := lookup(company, "name")
+		var match *yaml.Node
+		if name != nil {
+			for _, c := range dst.Content {
+				if n := lookup(c, "name"); n != nil && strings.EqualFold(n.Value, name.Value) {
+					match = c
+					break
+				}
+			}
+		}
+		if match != nil && match.Kind == yaml.MappingNode && company.Kind == yaml.MappingNode {
+			mergeMapping(match, company, false)
+			continue
+		}
+		dst.Content = append(dst.Content, company)
+	}
+}
+
+// lookup returns the value for key in mapping node m, or nil.
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
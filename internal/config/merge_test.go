@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const mergeBase = `
+polling_interval: 10m
+companies:
+  - name: acme
+    ats: greenhouse
+    board_token: "acme"
+    enabled: true
+  - name: globex
+    ats: lever
+    board_token: "globex"
+    enabled: true
+filters:
+  title_keywords: [engineer, developer]
+  locations: [Remote]
+  max_age: 12h
+rate_limit:
+  min_delay: 1m
+  ats_overrides:
+    greenhouse: 2m
+notification:
+  slack_template: "slack.json.tmpl"
+`
+
+const mergeOverride = `
+polling_interval: 5m
+companies:
+  - name: ACME
+    muted: true
+  - name: initech
+    ats: ashby
+    board_token: "initech"
+    enabled: true
+filters:
+  title_keywords: [sre]
+rate_limit:
+  ats_overrides:
+    lever: 3m
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_MergesFilesInOrder(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "shared", "base.yaml")
+	override := filepath.Join(dir, "prod.yaml")
+	writeFile(t, base, mergeBase)
+	writeFile(t, filepath.Join(dir, "shared", "slack.json.tmpl"), `{"blocks": []}`)
+	writeFile(t, override, mergeOverride)
+
+	cfg, err := Load(base, override)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.PollingInterval != 5*time.Minute {
+		t.Errorf("PollingInterval = %v, want override 5m", cfg.PollingInterval)
+	}
+
+	// Companies merge by name, case-insensitively: acme keeps its base
+	// fields and gains muted (and the override's spelling of its name),
+	// globex is untouched, initech is appended.
+	if len(cfg.Companies) != 3 {
+		t.Fatalf("got %d companies, want 3: %+v", len(cfg.Companies), cfg.Companies)
+	}
+	acme := cfg.Companies[0]
+	if acme.Name != "ACME" || acme.BoardToken != "acme" || !acme.Enabled || !acme.Muted {
+		t.Errorf("acme = %+v, want base fields plus muted", acme)
+	}
+	if cfg.Companies[1].Name != "globex" || cfg.Companies[2].Name != "initech" {
+		t.Errorf("company order = %s, %s; want globex, initech", cfg.Companies[1].Name, cfg.Companies[2].Name)
+	}
+
+	// Lists are replaced; sibling keys in a merged section survive.
+	if got := cfg.Filters.TitleKeywords; len(got) != 1 || got[0] != "sre" {
+		t.Errorf("TitleKeywords = %v, want [sre]", got)
+	}
+	if got := cfg.Filters.Locations; len(got) != 1 || got[0] != "Remote" {
+		t.Errorf("Locations = %v, want base [Remote]", got)
+	}
+	if cfg.Filters.MaxAge != 12*time.Hour {
+		t.Errorf("MaxAge = %v, want base 12h", cfg.Filters.MaxAge)
+	}
+	if cfg.RateLimit.MinDelay != time.Minute {
+		t.Errorf("MinDelay = %v, want base 1m", cfg.RateLimit.MinDelay)
+	}
+	want := map[string]time.Duration{"greenhouse": 2 * time.Minute, "lever": 3 * time.Minute}
+	for ats, d := range want {
+		if got := cfg.RateLimit.ATSOverrides[ats]; got != d {
+			t.Errorf("ATSOverrides[%q] = %v, want %v", ats, got, d)
+		}
+	}
+
+	// Relative paths resolve against the file that set them.
+	if want := filepath.Join(dir, "shared", "slack.json.tmpl"); cfg.Notification.SlackTemplate != want {
+		t.Errorf("SlackTemplate = %q, want %q", cfg.Notification.SlackTemplate, want)
+	}
+}
+
+func TestLoad_Directory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "10-base.yaml"), mergeBase)
+	writeFile(t, filepath.Join(dir, "slack.json.tmpl"), `{"blocks": []}`)
+	writeFile(t, filepath.Join(dir, "20-prod.json"), `{"polling_interval": "2m"}`)
+	writeFile(t, filepath.Join(dir, "README.md"), "not config")
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.PollingInterval != 2*time.Minute {
+		t.Errorf("PollingInterval = %v, want 2m from the later file", cfg.PollingInterval)
+	}
+	if len(cfg.Companies) != 2 {
+		t.Errorf("got %d companies, want 2 from the base file", len(cfg.Companies))
+	}
+}
+
+func TestLoad_EmptyDirectory(t *testing.T) {
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Fatal("Load: expected error for a directory without config files")
+	}
+}
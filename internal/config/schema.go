@@ -0,0 +1,160 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// ATSNames are the ats values a company may use.
+var ATSNames = []string{"greenhouse", "ashby", "lever", "gem", "workday", "microsoft", "amazon"}
+
+// NotificationTypes are the notification.type values.
+var NotificationTypes = []string{"log", "slack", "matrix", "ntfy", "pagerduty"}
+
+// PagerDutySeverities are the notification.severity values, the Events API
+// v2 payload severities.
+var PagerDutySeverities = []string{"critical", "error", "warning", "info"}
+
+// durationPattern matches strings accepted by time.ParseDuration.
+const durationPattern = `^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// clockPattern matches the "HH:MM" times parsed by parseClock.
+const clockPattern = `^([01]?[0-9]|2[0-3]):[0-5][0-9]$`
+
+// Schema hints keyed by dotted YAML path. List items and map values share
+// their field's path, so "companies.ats" is the ats of every company.
+var (
+	// schemaDurations are string fields parsed with time.ParseDuration. Fields
+	// typed time.Duration are detected from the struct.
+	schemaDurations = []string{
+		"polling_interval",
+		"shutdown_timeout",
+		"poll_timeout",
+		"filters.max_age",
+		"rate_limit.min_delay",
+		"rate_limit.ats_overrides",
+		"rate_limit.interval_overrides",
+		"rate_limit.startup_stagger",
+		"retry.base_delay",
+		"retry.ats_overrides.base_delay",
+		"retry.max_retry_after",
+		"ai.timeout",
+		"http.timeout",
+	}
+	schemaEnums = map[string][]string{
+		"companies.ats":            ATSNames,
+		"filters.freshness_basis":  {"posted", "first_seen"},
+		"filters.posting_statuses": model.PostingStatuses,
+		"notification.type":        NotificationTypes,
+		"notification.severity":    PagerDutySeverities,
+		"store.type":               {"sqlite", "memory"},
+	}
+	schemaPatterns = map[string]string{
+		"notification.quiet_hours.start": clockPattern,
+		"notification.quiet_hours.end":   clockPattern,
+		"notification.digest.at":         clockPattern,
+		"companies.active_window.start":  clockPattern,
+		"companies.active_window.end":    clockPattern,
+	}
+	// schemaRequired lists the keys an object at the path must set; "" is
+	// the top level.
+	schemaRequired = map[string][]string{
+		"":          {"polling_interval", "companies"},
+		"companies": {"name", "ats"},
+		"tags":      {"tag", "keywords"},
+	}
+)
+
+// JSONSchema returns a JSON Schema (draft 2020-12) for the config file,
+// generated from the YAML struct tags so editors can offer completion and
+// validation. It describes the file as written, before defaults apply, so
+// durations are strings like "5m".
+func JSONSchema() map[string]any {
+	g := schemaGen{refs: map[reflect.Type]string{}}
+	s := g.schema(reflect.TypeOf(rawConfig{}), "", "#")
+	s["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	s["title"] = "firstin config"
+	return s
+}
+
+// schemaGen walks the config types. refs maps a struct type being generated
+// to its JSON pointer, so a recursive field (notification.role_routes)
+// becomes a $ref instead of recursing forever.
+type schemaGen struct {
+	refs map[reflect.Type]string
+}
+
+func (g schemaGen) schema(t reflect.Type, path, pointer string) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if ref, ok := g.refs[t]; ok {
+		return map[string]any{"$ref": ref}
+	}
+
+	if t == reflect.TypeOf(time.Duration(0)) || (t.Kind() == reflect.String && slices.Contains(schemaDurations, path)) {
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		s := map[string]any{"type": "string"}
+		if enum, ok := schemaEnums[path]; ok {
+			s["enum"] = enum
+		}
+		if p, ok := schemaPatterns[path]; ok {
+			s["pattern"] = p
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem(), path, pointer+"/items")}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem(), path, pointer+"/additionalProperties")}
+	case reflect.Struct:
+		g.refs[t] = pointer
+		defer delete(g.refs, t)
+		props := map[string]any{}
+		g.addFields(props, t, path, pointer)
+		s := map[string]any{"type": "object", "properties": props}
+		if req, ok := schemaRequired[path]; ok {
+			s["required"] = req
+		}
+		return s
+	}
+	return map[string]any{}
+}
+
+// addFields adds the schema of each yaml-tagged field of struct t to props,
+// flattening ",inline" embedded structs.
+func (g schemaGen) addFields(props map[string]any, t reflect.Type, path, pointer string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && opts == "inline" {
+			g.addFields(props, f.Type, path, pointer)
+			continue
+		}
+		if !f.IsExported() || name == "" {
+			continue
+		}
+		child := name
+		if path != "" {
+			child = path + "." + name
+		}
+		props[name] = g.schema(f.Type, child, pointer+"/properties/"+name)
+	}
+}
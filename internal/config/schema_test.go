@@ -0,0 +1,109 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// schemaAt walks properties (and items for arrays) of s along keys.
+func schemaAt(t *testing.T, s map[string]any, keys ...string) map[string]any {
+	t.Helper()
+	for _, k := range keys {
+		if items, ok := s["items"].(map[string]any); ok {
+			s = items
+		}
+		props, ok := s["properties"].(map[string]any)
+		if !ok {
+			t.Fatalf("no properties before %q", k)
+		}
+		if s, ok = props[k].(map[string]any); !ok {
+			t.Fatalf("no property %q", k)
+		}
+	}
+	return s
+}
+
+func TestJSONSchema(t *testing.T) {
+	// Round-trip through JSON so the test sees what the command prints.
+	data, err := json.Marshal(JSONSchema())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var s map[string]any
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	strs := func(v any) []string {
+		var out []string
+		for _, x := range v.([]any) {
+			out = append(out, x.(string))
+		}
+		return out
+	}
+
+	if got := strs(s["required"]); !reflect.DeepEqual(got, []string{"polling_interval", "companies"}) {
+		t.Errorf("required = %v, want polling_interval and companies", got)
+	}
+	companies := schemaAt(t, s, "companies")
+	if companies["type"] != "array" {
+		t.Errorf("companies type = %v, want array", companies["type"])
+	}
+	if got := strs(companies["items"].(map[string]any)["required"]); !reflect.DeepEqual(got, []string{"name", "ats"}) {
+		t.Errorf("companies required = %v, want name and ats", got)
+	}
+	if got := strs(schemaAt(t, s, "companies", "ats")["enum"]); !reflect.DeepEqual(got, ATSNames) {
+		t.Errorf("ats enum = %v, want %v", got, ATSNames)
+	}
+	if got := strs(schemaAt(t, s, "notification", "type")["enum"]); !reflect.DeepEqual(got, NotificationTypes) {
+		t.Errorf("notification.type enum = %v, want %v", got, NotificationTypes)
+	}
+
+	for _, path := range [][]string{
+		{"polling_interval"},
+		{"filters", "max_age"},
+		{"companies", "max_age"}, // time.Duration field
+	} {
+		if got := schemaAt(t, s, path...)["pattern"]; got != durationPattern {
+			t.Errorf("%v pattern = %v, want duration pattern", path, got)
+		}
+	}
+	if got := schemaAt(t, s, "retry", "max_retries")["type"]; got != "integer" {
+		t.Errorf("retry.max_retries type = %v, want integer (from inline policy)", got)
+	}
+	if got := schemaAt(t, s, "notification", "role_routes")["additionalProperties"]; !reflect.DeepEqual(got, map[string]any{"$ref": "#/properties/notification"}) {
+		t.Errorf("role_routes values = %v, want $ref to notification", got)
+	}
+}
+
+// TestJSONSchema_HintPathsExist guards the hint tables against renamed keys.
+func TestJSONSchema_HintPathsExist(t *testing.T) {
+	s := JSONSchema()
+	var paths []string
+	paths = append(paths, schemaDurations...)
+	for p := range schemaEnums {
+		paths = append(paths, p)
+	}
+	for p := range schemaPatterns {
+		paths = append(paths, p)
+	}
+	for _, p := range paths {
+		node := s
+		for _, k := range strings.Split(p, ".") {
+			for _, wrap := range []string{"items", "additionalProperties"} {
+				if inner, ok := node[wrap].(map[string]any); ok {
+					node = inner
+				}
+			}
+			props, _ := node["properties"].(map[string]any)
+			next, ok := props[k].(map[string]any)
+			if !ok {
+				t.Errorf("hint path %q: no key %q", p, k)
+				break
+			}
+			node = next
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+)
+
+// CountryFilter matches jobs on the structured Job.Country set by adapters,
+// which is more reliable than substring-matching a free-text location.
+// Jobs without a country (the ATS provides none) pass, so a missing signal
+// never silently drops a posting. Countries are compared in normalized form
+// (see normalize.NormalizeCountry), so "US" matches "United States of America".
+type CountryFilter struct {
+	include map[string]bool
+	exclude map[string]bool
+}
+
+// NewCountryFilter returns a filter that keeps jobs whose country is in include
+// (empty includes every country) and not in exclude.
+func NewCountryFilter(include, exclude []string) *CountryFilter {
+	return &CountryFilter{
+		include: countrySet(include),
+		exclude: countrySet(exclude),
+	}
+}
+
+func countrySet(countries []string) map[string]bool {
+	set := make(map[string]bool, len(countries))
+	for _, c := range countries {
+		if n := normalize.NormalizeCountry(c); n != "" {
+			set[strings.ToLower(n)] = true
+		}
+	}
+	return set
+}
+
+// Match returns true if the job's country is allowed, or if it is unknown.
+func (f *CountryFilter) Match(job model.Job) bool {
+	if job.Country == "" {
+		return true
+	}
+	country := strings.ToLower(normalize.NormalizeCountry(job.Country))
+	if f.exclude[country] {
+		return false
+	}
+	return len(f.include) == 0 || f.include[country]
+}
@@ -0,0 +1,38 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func jobInCountry(country string) model.Job {
+	return model.Job{Title: "Software Engineer", Country: country}
+}
+
+func TestCountryFilter_Match(t *testing.T) {
+	tests := []struct {
+		name      string
+		include   []string
+		exclude   []string
+		job       model.Job
+		wantMatch bool
+	}{
+		{"included country matches", []string{"United States"}, nil, jobInCountry("United States"), true},
+		{"other country rejected", []string{"United States"}, nil, jobInCountry("India"), false},
+		{"alias matches canonical", []string{"US"}, nil, jobInCountry("United States of America"), true},
+		{"case insensitive", []string{"canada"}, nil, jobInCountry("Canada"), true},
+		{"excluded country rejected", nil, []string{"India"}, jobInCountry("India"), false},
+		{"exclude wins over include", []string{"GB"}, []string{"United Kingdom"}, jobInCountry("UK"), false},
+		{"empty lists match all", nil, nil, jobInCountry("Germany"), true},
+		{"unknown country passes", []string{"US"}, []string{"India"}, jobInCountry(""), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewCountryFilter(tt.include, tt.exclude)
+			if got := f.Match(tt.job); got != tt.wantMatch {
+				t.Errorf("Match(%q) = %v, want %v", tt.job.Country, got, tt.wantMatch)
+			}
+		})
+	}
+}
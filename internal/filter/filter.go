@@ -4,18 +4,24 @@ import (
 	"strings"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
 )
 
 // TitleAndLocationFilter matches jobs whose title contains any of the title
-// keywords and whose location contains any of the location keywords.
+// keywords (all of them in match-all mode) and whose location contains any of the location keywords.
 // It also rejects jobs whose title matches any exclude keyword or whose
 // location matches any exclude location.
 // Matching is case-insensitive. Empty keyword lists are treated as "match all".
+// Locations are compared in normalized form (see normalize.NormalizeLocation),
+// so "CA" matches "San Francisco, California" and "US" matches "USA". Jobs
+// with a structured City or State are matched part by part instead of by
+// substring, so "India" does not match "Indianapolis, Indiana".
 type TitleAndLocationFilter struct {
 	titleKeywords        []string
 	titleExcludeKeywords []string
 	locations            []string
 	excludeLocations     []string
+	titleMatchAll        bool // require every title keyword instead of any
 }
 
 // NewTitleAndLocationFilter returns a filter that requires both a title keyword
@@ -25,28 +31,61 @@ func NewTitleAndLocationFilter(titleKeywords, titleExcludeKeywords, locations, e
 	return &TitleAndLocationFilter{
 		titleKeywords:        titleKeywords,
 		titleExcludeKeywords: titleExcludeKeywords,
-		locations:            locations,
-		excludeLocations:     excludeLocations,
+		locations:            normalizeLocations(locations),
+		excludeLocations:     normalizeLocations(excludeLocations),
 	}
 }
 
+// SetTitleMatchAll switches title matching from any keyword (the default) to
+// every keyword, e.g. "senior", "backend" and "go" must all appear.
+func (f *TitleAndLocationFilter) SetTitleMatchAll(all bool) {
+	f.titleMatchAll = all
+}
+
+// normalizeLocations canonicalizes location keywords so they compare against
+// Job.NormalizedLocation on equal terms.
+func normalizeLocations(locs []string) []string {
+	if locs == nil {
+		return nil
+	}
+	out := make([]string, 0, len(locs))
+	for _, loc := range locs {
+		if n := normalize.NormalizeLocation(loc); n != "" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
 // Match returns true if the job's title contains any title keyword (and none of
 // the exclude keywords) and the job's location contains any location keyword
 // (and none of the exclude locations). Empty keyword lists pass all.
 func (f *TitleAndLocationFilter) Match(job model.Job) bool {
 	titleLower := strings.ToLower(job.Title)
-	locationLower := strings.ToLower(job.Location)
+	// Jobs built outside an adapter (e.g. Workday listing pre-filter candidates)
+	// may not carry a normalized location yet.
+	location := job.NormalizedLocation
+	if location == "" {
+		location = normalize.NormalizeLocation(job.Location)
+	}
+	locationLower := strings.ToLower(location)
+	matchesLocation := func(loc string) bool {
+		if job.City != "" || job.State != "" {
+			return matchesPlace(job, loc)
+		}
+		return strings.Contains(locationLower, strings.ToLower(loc))
+	}
 
-	// Title must match at least one include keyword (if any specified)
+	// Title must match at least one include keyword, or all of them in
+	// match-all mode (if any specified)
 	if len(f.titleKeywords) > 0 {
-		matched := false
+		hits := 0
 		for _, kw := range f.titleKeywords {
 			if strings.Contains(titleLower, strings.ToLower(kw)) {
-				matched = true
-				break
+				hits++
 			}
 		}
-		if !matched {
+		if hits == 0 || (f.titleMatchAll && hits < len(f.titleKeywords)) {
 			return false
 		}
 	}
@@ -62,7 +101,7 @@ func (f *TitleAndLocationFilter) Match(job model.Job) bool {
 	if len(f.locations) > 0 {
 		matched := false
 		for _, loc := range f.locations {
-			if strings.Contains(locationLower, strings.ToLower(loc)) {
+			if matchesLocation(loc) {
 				matched = true
 				break
 			}
@@ -74,10 +113,53 @@ func (f *TitleAndLocationFilter) Match(job model.Job) bool {
 
 	// Location must NOT match any exclude location
 	for _, loc := range f.excludeLocations {
-		if strings.Contains(locationLower, strings.ToLower(loc)) {
+		if matchesLocation(loc) {
 			return false
 		}
 	}
 
 	return true
 }
+
+// matchesPlace reports whether the normalized location keyword loc names the
+// job's structured place: each comma-separated part of loc must equal the
+// job's city, state or country as a whole, and "Remote" requires a remote job.
+func matchesPlace(job model.Job, loc string) bool {
+	for _, part := range strings.Split(loc, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+		case strings.EqualFold(part, "remote"):
+			if !job.IsRemote {
+				return false
+			}
+		case job.City != "" && strings.EqualFold(part, job.City):
+		case job.State != "" && strings.EqualFold(part, normalize.NormalizeLocation(job.State)):
+		case job.Country != "" && strings.EqualFold(normalize.NormalizeCountry(part), normalize.NormalizeCountry(job.Country)):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// AllFilter matches a job only if every one of its filters does.
+type AllFilter []model.JobFilter
+
+// Match returns true if every filter matches. An empty AllFilter matches everything.
+func (a AllFilter) Match(job model.Job) bool {
+	for _, f := range a {
+		if !f.Match(job) {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoteFilter keeps only jobs adapters flagged as remote (Job.IsRemote).
+type RemoteFilter struct{}
+
+// Match returns true if the job is remote.
+func (RemoteFilter) Match(job model.Job) bool {
+	return job.IsRemote
+}
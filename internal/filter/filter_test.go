@@ -69,6 +69,34 @@ func TestTitleAndLocationFilter_Match(t *testing.T) {
 			job:              job("Software Engineer", "Toronto, Canada"),
 			wantMatch:        false,
 		},
+		{
+			name:          "state code keyword matches full state name",
+			titleKeywords: []string{"software engineer"},
+			locations:     []string{"CA"},
+			job:           job("Software Engineer", "Mountain View, California"),
+			wantMatch:     true,
+		},
+		{
+			name:          "state code keyword no longer matches inside city names",
+			titleKeywords: []string{"software engineer"},
+			locations:     []string{"CA"},
+			job:           job("Software Engineer", "Chicago, IL"),
+			wantMatch:     false,
+		},
+		{
+			name:          "US keyword matches USA spelling",
+			titleKeywords: []string{"software engineer"},
+			locations:     []string{"US"},
+			job:           job("Software Engineer", "Seattle, WA, USA"),
+			wantMatch:     true,
+		},
+		{
+			name:          "remote variants collapse",
+			titleKeywords: []string{"software engineer"},
+			locations:     []string{"Remote - US"},
+			job:           job("Software Engineer", "US Remote"),
+			wantMatch:     true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -80,3 +108,78 @@ func TestTitleAndLocationFilter_Match(t *testing.T) {
 		})
 	}
 }
+
+func TestTitleAndLocationFilter_TitleMatchAll(t *testing.T) {
+	keywords := []string{"senior", "backend", "go"}
+	tests := []struct {
+		title            string
+		wantAny, wantAll bool
+	}{
+		{"Senior Backend Engineer (Go)", true, true},
+		{"Senior Backend Engineer (Java)", true, false},
+		{"Backend Engineer", true, false},
+		{"Frontend Engineer", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			f := NewTitleAndLocationFilter(keywords, nil, nil, nil)
+			if got := f.Match(job(tt.title, "Remote")); got != tt.wantAny {
+				t.Errorf("any mode Match() = %v, want %v", got, tt.wantAny)
+			}
+			f.SetTitleMatchAll(true)
+			if got := f.Match(job(tt.title, "Remote")); got != tt.wantAll {
+				t.Errorf("all mode Match() = %v, want %v", got, tt.wantAll)
+			}
+		})
+	}
+}
+
+func TestTitleAndLocationFilter_TitleMatchAllKeepsExcludes(t *testing.T) {
+	f := NewTitleAndLocationFilter([]string{"senior", "backend"}, []string{"manager"}, nil, nil)
+	f.SetTitleMatchAll(true)
+	if f.Match(job("Senior Backend Engineering Manager", "Remote")) {
+		t.Error("expected exclude keyword to reject a title matching every include keyword")
+	}
+}
+
+func TestRemoteFilter_Match(t *testing.T) {
+	f := RemoteFilter{}
+	if !f.Match(model.Job{IsRemote: true}) {
+		t.Error("remote job should match")
+	}
+	if f.Match(model.Job{Location: "Remote"}) {
+		t.Error("job not flagged IsRemote should not match, whatever its location text")
+	}
+}
+
+func TestTitleAndLocationFilter_StructuredLocation(t *testing.T) {
+	indianapolis := model.Job{Title: "Engineer", Location: "Indianapolis, IN", City: "Indianapolis", State: "IN", Country: "United States"}
+	pune := model.Job{Title: "Engineer", Location: "India, Pune", City: "Pune", Country: "India"}
+	sf := model.Job{Title: "Engineer", Location: "San Francisco, CA", City: "San Francisco", State: "CA", Country: "United States"}
+
+	tests := []struct {
+		name             string
+		locations        []string
+		excludeLocations []string
+		job              model.Job
+		wantMatch        bool
+	}{
+		{name: "India does not match Indiana", locations: []string{"India"}, job: indianapolis, wantMatch: false},
+		{name: "India matches structured country", locations: []string{"India"}, job: pune, wantMatch: true},
+		{name: "state code matches structured state", locations: []string{"IN"}, job: indianapolis, wantMatch: true},
+		{name: "state name matches state code", locations: []string{"California"}, job: sf, wantMatch: true},
+		{name: "country alias matches", locations: []string{"USA"}, job: sf, wantMatch: true},
+		{name: "city and state together", locations: []string{"San Francisco, CA"}, job: sf, wantMatch: true},
+		{name: "remote requires remote job", locations: []string{"Remote - US"}, job: sf, wantMatch: false},
+		{name: "exclude India keeps Indiana", excludeLocations: []string{"India"}, job: indianapolis, wantMatch: true},
+		{name: "substring fallback without structured data", locations: []string{"India"}, job: job("Engineer", "Indianapolis, IN"), wantMatch: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewTitleAndLocationFilter(nil, nil, tt.locations, tt.excludeLocations)
+			if got := f.Match(tt.job); got != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", got, tt.wantMatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package filter
+
+import (
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// MaxAgeFilter matches jobs posted within an exact max age. Unlike the
+// poller's freshness check it is a standalone JobFilter, so the audit view can
+// apply a precise cutoff of its own. Jobs without a PostedAt pass.
+type MaxAgeFilter struct {
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewMaxAgeFilter returns a filter that keeps jobs posted no more than maxAge ago.
+// A non-positive maxAge matches everything.
+func NewMaxAgeFilter(maxAge time.Duration) *MaxAgeFilter {
+	return &MaxAgeFilter{maxAge: maxAge, now: time.Now}
+}
+
+// Match returns true if the job was posted within maxAge, or has no PostedAt.
+func (f *MaxAgeFilter) Match(job model.Job) bool {
+	if f.maxAge <= 0 || job.PostedAt == nil {
+		return true
+	}
+	return !job.PostedAt.Before(f.now().Add(-f.maxAge))
+}
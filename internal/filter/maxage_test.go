@@ -0,0 +1,39 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestMaxAgeFilter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		maxAge   time.Duration
+		postedAt *time.Time
+		want     bool
+	}{
+		{"within max age", 3 * time.Hour, at(2 * time.Hour), true},
+		{"exactly at max age", 3 * time.Hour, at(3 * time.Hour), true},
+		{"older than max age", 3 * time.Hour, at(3*time.Hour + time.Minute), false},
+		{"nil PostedAt passes", 3 * time.Hour, nil, true},
+		{"zero max age matches all", 0, at(30 * 24 * time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewMaxAgeFilter(tt.maxAge)
+			f.now = func() time.Time { return now }
+			if got := f.Match(model.Job{PostedAt: tt.postedAt}); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
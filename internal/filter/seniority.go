@@ -0,0 +1,38 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// SeniorityFilter matches jobs whose AI-classified seniority is in an allowed
+// set. It runs after AI analysis, since seniority comes from JobInsights.
+// Jobs without insights (AI disabled, analysis failed, no description) pass,
+// so a missing classification never silently drops a posting.
+// An empty allowed set matches everything.
+type SeniorityFilter struct {
+	allowed map[string]bool
+}
+
+// NewSeniorityFilter returns a filter that keeps jobs whose seniority is one of
+// levels (case-insensitive).
+func NewSeniorityFilter(levels []string) *SeniorityFilter {
+	allowed := make(map[string]bool, len(levels))
+	for _, l := range levels {
+		allowed[strings.ToLower(strings.TrimSpace(l))] = true
+	}
+	return &SeniorityFilter{allowed: allowed}
+}
+
+// Match returns true if the job's analyzed seniority is allowed, or if the job
+// has not been classified.
+func (f *SeniorityFilter) Match(job model.Job) bool {
+	if len(f.allowed) == 0 {
+		return true
+	}
+	if job.Insights == nil || job.Insights.Seniority == "" {
+		return true
+	}
+	return f.allowed[strings.ToLower(job.Insights.Seniority)]
+}
@@ -0,0 +1,64 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func jobWithSeniority(level string) model.Job {
+	return model.Job{Title: "Software Engineer", Insights: &model.JobInsights{Seniority: level}}
+}
+
+func TestSeniorityFilter_Match(t *testing.T) {
+	tests := []struct {
+		name      string
+		levels    []string
+		job       model.Job
+		wantMatch bool
+	}{
+		{
+			name:      "allowed level matches",
+			levels:    []string{"mid", "senior"},
+			job:       jobWithSeniority("senior"),
+			wantMatch: true,
+		},
+		{
+			name:      "disallowed level rejected",
+			levels:    []string{"mid", "senior"},
+			job:       jobWithSeniority("staff"),
+			wantMatch: false,
+		},
+		{
+			name:      "case insensitive",
+			levels:    []string{"Senior"},
+			job:       jobWithSeniority("SENIOR"),
+			wantMatch: true,
+		},
+		{
+			name:      "no insights passes through",
+			levels:    []string{"mid"},
+			job:       model.Job{Title: "Software Engineer"},
+			wantMatch: true,
+		},
+		{
+			name:      "unclassified insights pass through",
+			levels:    []string{"mid"},
+			job:       jobWithSeniority(""),
+			wantMatch: true,
+		},
+		{
+			name:      "empty level list passes all",
+			job:       jobWithSeniority("principal"),
+			wantMatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewSeniorityFilter(tt.levels)
+			if got := f.Match(tt.job); got != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", got, tt.wantMatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Package httpclient builds the *http.Client shared by adapters and notifiers,
+// applying the timeout, proxy and User-Agent from the http config block.
+package httpclient
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// New returns a client with cfg's timeout that sends every request through
+// cfg.ProxyURL (or the HTTP_PROXY/HTTPS_PROXY environment when unset) and
+// with cfg.UserAgent, defaulting to "firstin/<version>". A User-Agent set on
+// an individual request is left alone. Responses are requested gzipped and
+// always reach the caller decompressed (see gzipTransport), and reading more
+// than cfg.MaxResponseBytes of a body fails (see limitTransport).
+func New(cfg config.HTTPConfig, version string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableCompression = false
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse http.proxy_url %q: %w", cfg.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = "firstin/" + version
+	}
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: &userAgentTransport{userAgent: userAgent, next: &limitTransport{max: cfg.MaxResponseBytes, next: &gzipTransport{next: transport}}},
+	}, nil
+}
+
+// WithRequestLogging returns a copy of c that logs each request to logger at
+// debug level (see loggingTransport). It is meant for the adapters' client:
+// notifier URLs, like Slack webhooks, carry secrets and must not be logged.
+func WithRequestLogging(c *http.Client, logger *slog.Logger) *http.Client {
+	next := c.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	logged := *c
+	logged.Transport = &loggingTransport{logger: logger, next: next}
+	return &logged
+}
+
+// loggingTransport logs every request at debug level: method, URL, status,
+// duration and, once the caller closes the body, how many bytes it read
+// (decompressed). Bodies themselves are never logged. It does nothing unless
+// the logger has debug enabled, e.g. with --debug.
+type loggingTransport struct {
+	logger *slog.Logger
+	next   http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !t.logger.Enabled(ctx, slog.LevelDebug) {
+		return t.next.RoundTrip(req)
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.logger.DebugContext(ctx, "http request failed",
+			"method", req.Method,
+			"url", req.URL.Redacted(),
+			"duration", time.Since(start).Round(time.Millisecond).String(),
+			"error", err,
+		)
+		return resp, err
+	}
+	resp.Body = &loggedBody{body: resp.Body, done: func(n int64) {
+		t.logger.DebugContext(ctx, "http request",
+			"method", req.Method,
+			"url", req.URL.Redacted(),
+			"status", resp.StatusCode,
+			"bytes", n,
+			"duration", time.Since(start).Round(time.Millisecond).String(),
+		)
+	}}
+	return resp, nil
+}
+
+// loggedBody counts the bytes read from body and reports the total to done
+// once, on Close.
+type loggedBody struct {
+	body io.ReadCloser
+	n    int64
+	done func(n int64)
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	if b.done != nil {
+		b.done(b.n)
+		b.done = nil
+	}
+	return b.body.Close()
+}
+
+// limitTransport caps how much of each response body can be read, so a huge
+// document (a mis-pointed URL, or a gzip bomb: the limit applies after
+// decompression) fails with model.ErrResponseTooLarge instead of being
+// decoded into memory. A declared Content-Length over the cap fails before
+// any of the body is read. max <= 0 disables the cap.
+type limitTransport struct {
+	max  int64
+	next http.RoundTripper
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || t.max <= 0 {
+		return resp, err
+	}
+	if resp.ContentLength > t.max {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %d bytes: %w (%d)", req.URL.Redacted(), resp.ContentLength, model.ErrResponseTooLarge, t.max)
+	}
+	resp.Body = &limitedBody{body: resp.Body, r: io.LimitReader(resp.Body, t.max+1), max: t.max, url: req.URL.Redacted()}
+	return resp, nil
+}
+
+// limitedBody reads at most max+1 bytes of body and fails once it sees the
+// extra byte.
+type limitedBody struct {
+	body io.ReadCloser
+	r    io.Reader // io.LimitReader(body, max+1)
+	n    int64
+	max  int64
+	url  string
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.n += int64(n)
+	if b.n > b.max {
+		return n - int(b.n-b.max), fmt.Errorf("%s: %w (%d)", b.url, model.ErrResponseTooLarge, b.max)
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// userAgentTransport sets the User-Agent header on requests that lack one.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.next.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// gzipTransport decompresses gzip responses that the underlying transport
+// left encoded. http.Transport only decodes transparently when it added
+// Accept-Encoding itself, so a request that sets its own header, or a server
+// that compresses unprompted, would otherwise hand adapters raw gzip bytes.
+type gzipTransport struct {
+	next http.RoundTripper
+}
+
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp, err
+	}
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// gzipBody opens the gzip stream on first Read, so an empty body (e.g. a
+// 204 or HEAD response) isn't an error until someone reads it.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil && b.err == nil {
+		b.zr, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,259 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/config"
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestNew_SetsUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.HTTPConfig
+		reqUA     string
+		wantAgent string
+	}{
+		{"configured", config.HTTPConfig{UserAgent: "jobwatch/1.0 (me@example.com)"}, "", "jobwatch/1.0 (me@example.com)"},
+		{"default", config.HTTPConfig{}, "", "firstin/v1.2.3"},
+		{"request header wins", config.HTTPConfig{UserAgent: "configured"}, "adapter-set", "adapter-set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+			}))
+			defer srv.Close()
+
+			client, err := New(tt.cfg, "v1.2.3")
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			if tt.reqUA != "" {
+				req.Header.Set("User-Agent", tt.reqUA)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			resp.Body.Close()
+
+			if got != tt.wantAgent {
+				t.Errorf("User-Agent = %q, want %q", got, tt.wantAgent)
+			}
+		})
+	}
+}
+
+func TestNew_RoutesThroughProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	client, err := New(config.HTTPConfig{ProxyURL: proxy.URL}, "dev")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	resp, err := client.Get("http://boards.example.test/v1/jobs")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://boards.example.test/v1/jobs" {
+		t.Errorf("proxy saw %q, want the target URL", proxied)
+	}
+}
+
+func TestNew_AppliesTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client, err := New(config.HTTPConfig{Timeout: 50 * time.Millisecond}, "dev")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if client.Timeout != 50*time.Millisecond {
+		t.Errorf("Timeout = %v, want 50ms", client.Timeout)
+	}
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Error("expected a timeout error from a hung server")
+	}
+}
+
+func TestNew_InvalidProxy(t *testing.T) {
+	if _, err := New(config.HTTPConfig{ProxyURL: "://bad"}, "dev"); err == nil {
+		t.Error("expected an error for an unparseable proxy URL")
+	}
+}
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNew_DecodesGzip(t *testing.T) {
+	const payload = `{"jobs":[{"id":1}]}`
+	tests := []struct {
+		name           string
+		acceptEncoding string // set by the caller; empty leaves it to the transport
+	}{
+		{"transport-negotiated", ""},
+		{"caller set Accept-Encoding", "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAccept string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAccept = r.Header.Get("Accept-Encoding")
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Write(gzipBytes(t, payload))
+			}))
+			defer srv.Close()
+
+			client, err := New(config.HTTPConfig{}, "dev")
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+
+			if !strings.Contains(gotAccept, "gzip") {
+				t.Errorf("Accept-Encoding = %q, want gzip requested", gotAccept)
+			}
+			if string(body) != payload {
+				t.Errorf("body = %q, want decompressed %q", body, payload)
+			}
+		})
+	}
+}
+
+func TestNew_LimitsResponseSize(t *testing.T) {
+	const limit = 1024
+	big := strings.Repeat("x", 10*limit)
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr bool
+	}{
+		{"under the limit", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(big[:limit]))
+		}, false},
+		{"declared length over the limit", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(big))
+		}, true},
+		{"streamed body over the limit", func(w http.ResponseWriter, r *http.Request) {
+			for i := 0; i < 10; i++ {
+				w.Write([]byte(big[:limit]))
+				w.(http.Flusher).Flush() // chunked: no Content-Length
+			}
+		}, true},
+		{"gzip expands over the limit", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gzipBytes(t, big))
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			client, err := New(config.HTTPConfig{MaxResponseBytes: limit}, "dev")
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			var body []byte
+			resp, err := client.Get(srv.URL)
+			if err == nil {
+				body, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+
+			if !tt.wantErr {
+				if err != nil || len(body) != limit {
+					t.Errorf("read %d bytes, err %v; want the whole %d-byte body", len(body), err, limit)
+				}
+				return
+			}
+			if !errors.Is(err, model.ErrResponseTooLarge) {
+				t.Fatalf("err = %v, want ErrResponseTooLarge", err)
+			}
+			if len(body) > limit {
+				t.Errorf("read %d bytes, want at most the %d-byte limit", len(body), limit)
+			}
+		})
+	}
+}
+
+func TestWithRequestLogging_LogsFetchAtDebug(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jobs":[]}`))
+	}))
+	defer srv.Close()
+
+	fetch := func(level slog.Level) string {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: level}))
+		base, err := New(config.HTTPConfig{Timeout: 5 * time.Second}, "test")
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		resp, err := WithRequestLogging(base, logger).Get(srv.URL + "/v1/boards/acme/jobs")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return buf.String()
+	}
+
+	got := fetch(slog.LevelDebug)
+	for _, want := range []string{"level=DEBUG", `msg="http request"`, "method=GET", "url=" + srv.URL + "/v1/boards/acme/jobs", "status=200", "bytes=11"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("debug log %q missing %q", got, want)
+		}
+	}
+	if strings.Contains(got, "jobs\":[]") {
+		t.Errorf("debug log %q contains the response body", got)
+	}
+	if got := fetch(slog.LevelInfo); got != "" {
+		t.Errorf("info-level log = %q, want nothing", got)
+	}
+}
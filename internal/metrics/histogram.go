@@ -0,0 +1,91 @@
+// Package metrics keeps the few metrics firstin exposes and renders them in
+// the Prometheus text exposition format, for scraping from the control
+// server's GET /metrics.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// AlertLagBuckets are upper bounds, in seconds, suited to the lag between a
+// job being posted and us alerting on it: one minute to two days.
+var AlertLagBuckets = []float64{60, 300, 900, 1800, 3600, 7200, 14400, 43200, 86400, 172800}
+
+// Histogram counts observations into buckets with the given upper bounds.
+// Safe for concurrent use.
+type Histogram struct {
+	name   string
+	help   string
+	bounds []float64 // ascending upper bounds; +Inf is implicit
+
+	mu     sync.Mutex
+	counts []uint64 // per bucket, not cumulative; the last is +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram returns an empty histogram. bounds must be ascending.
+func NewHistogram(name, help string, bounds []float64) *Histogram {
+	return &Histogram{
+		name:   name,
+		help:   help,
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+// Observe records one value.
+func (h *Histogram) Observe(v float64) {
+	i := 0
+	for i < len(h.bounds) && v > h.bounds[i] {
+		i++
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[i]++
+	h.sum += v
+	h.count++
+}
+
+// WriteTo writes the histogram in the Prometheus text format.
+func (h *Histogram) WriteTo(w io.Writer) (int64, error) {
+	h.mu.Lock()
+	counts := append([]uint64(nil), h.counts...)
+	sum, count := h.sum, h.count
+	h.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	var cumulative uint64
+	for i, c := range counts {
+		cumulative += c
+		le := "+Inf"
+		if i < len(h.bounds) {
+			le = strconv.FormatFloat(h.bounds[i], 'g', -1, 64)
+		}
+		fmt.Fprintf(cw, "%s_bucket{le=%q} %d\n", h.name, le, cumulative)
+	}
+	fmt.Fprintf(cw, "%s_sum %s\n%s_count %d\n", h.name, strconv.FormatFloat(sum, 'g', -1, 64), h.name, count)
+	return cw.n, cw.err
+}
+
+// countingWriter counts bytes written and keeps the first error, so WriteTo
+// can report both without checking every Fprintf.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
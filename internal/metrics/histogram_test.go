@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHistogram_WriteTo(t *testing.T) {
+	h := NewHistogram("firstin_alert_lag_seconds", "Seconds from posting to alert.", []float64{60, 300})
+	for _, v := range []float64{30, 60, 120, 1000} {
+		h.Observe(v)
+	}
+
+	var b strings.Builder
+	if _, err := h.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	want := `# HELP firstin_alert_lag_seconds Seconds from posting to alert.
+# TYPE firstin_alert_lag_seconds histogram
+firstin_alert_lag_seconds_bucket{le="60"} 2
+firstin_alert_lag_seconds_bucket{le="300"} 3
+firstin_alert_lag_seconds_bucket{le="+Inf"} 4
+firstin_alert_lag_seconds_sum 1210
+firstin_alert_lag_seconds_count 4
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteTo() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -1,10 +1,16 @@
 package model
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrResponseTooLarge is returned while reading a response body longer than
+// http.max_response_bytes. Retrying would fetch the same document, so retry
+// logic treats it as permanent.
+var ErrResponseTooLarge = errors.New("response exceeds http.max_response_bytes")
+
 // HTTPError wraps an HTTP status code so retry logic can inspect it.
 type HTTPError struct {
 	StatusCode int
@@ -22,3 +28,33 @@ func (e *HTTPError) Error() string {
 func (e *HTTPError) Unwrap() error {
 	return e.Err
 }
+
+// NetworkError wraps a failure to reach a board or read its response:
+// DNS, refused or reset connections, timeouts, truncated bodies. Retry logic
+// treats it as transient.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network: %v", e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError wraps a response body that arrived whole but could not be
+// parsed. Fetching it again would return the same document, so retry logic
+// treats it as permanent.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
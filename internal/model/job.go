@@ -10,9 +10,34 @@ type Job struct {
 	ID       string // unique per platform
 	Company  string // company name
 	Title    string // job title
-	Location string // location string
+	Location string // location string, as returned by the ATS
 	URL      string // direct apply link
 
+	// NormalizedLocation is Location canonicalized by normalize.NormalizeLocation
+	// (state codes expanded, US/remote variants collapsed, multi-locations split).
+	// Set by adapters at mapping time; the location filter matches against it.
+	NormalizedLocation string
+
+	// Country is the job's country from a structured ATS field, canonicalized
+	// by normalize.NormalizeCountry. Set by Workday (country.descriptor),
+	// Lever (country) and Amazon (country_code); empty when the ATS gives no
+	// structured country. Used by filter.CountryFilter.
+	Country string
+
+	// City and State are the job's place split out of a single-location
+	// string against the structured Country (see normalize.SplitPlace). Set
+	// by Workday and Lever; empty for multi-location, remote or unparseable
+	// locations. When either is set, TitleAndLocationFilter matches location
+	// keywords against City/State/Country instead of substrings of Location.
+	City  string
+	State string
+
+	// IsRemote reports a remote role, from the location text (see
+	// normalize.IsRemote) or an ATS-specific signal: Lever workplaceType,
+	// Ashby isRemote/workplaceType, Workday remoteType, Microsoft
+	// workLocationOption. Set by adapters at mapping time.
+	IsRemote bool
+
 	// PostedAt is the canonical freshness signal used by the poller and TUI sort.
 	// Each adapter maps its publication timestamp here:
 	//   Greenhouse → first_published (list endpoint)
@@ -22,24 +47,64 @@ type Job struct {
 	// Nil when the source provides no usable timestamp.
 	PostedAt *time.Time
 
-	// FirstSeen is stamped by our clock on the job's first encounter.
-	// Not yet wired — currently remains zero value.
+	// FirstSeen is when we first encountered the job: the store's record for
+	// a job seen before, otherwise the current poll's time. Stamped by the
+	// poller on jobs that pass the filters; zero elsewhere. The freshness
+	// check uses it under filters.freshness_basis: first_seen.
 	FirstSeen time.Time
 
-	Source   string      // ATS name: "greenhouse", "lever", "ashby", "workday"
-	Detail   *JobDetail  // optional enriched metadata; nil until populated
+	// PostingStatus is the posting's state on the board, for ATSs whose
+	// public feed also carries postings that aren't open: one of the Posting*
+	// constants. Ashby maps isListed: false to PostingUnlisted; Lever maps its
+	// state field (see leverPostingStatus). Empty means the ATS doesn't say,
+	// and counts as open. The poller drops non-open postings unless
+	// configured to keep them, and sends a job with PostingClosed to the
+	// notifier to report that a notified role closed.
+	PostingStatus string
+
+	Source   string       // ATS name: "greenhouse", "lever", "ashby", "workday"
+	Detail   *JobDetail   // optional enriched metadata; nil until populated
 	Insights *JobInsights // nil when AI disabled or description unavailable
+
+	// Tags are short labels ("Go", "Remote", "Senior") from the configured
+	// keyword rules (see tagger.Tagger), set on jobs that passed the filters.
+	// Nil when no rule matches.
+	Tags []string
 }
 
+// Job.PostingStatus values.
+const (
+	PostingOpen     = "open"
+	PostingClosed   = "closed"
+	PostingDraft    = "draft"
+	PostingUnlisted = "unlisted"
+)
+
+// PostingStatuses is the closed set of values Job.PostingStatus may take when
+// set. Shared by the adapters and filters.posting_statuses validation.
+var PostingStatuses = []string{PostingOpen, PostingClosed, PostingDraft, PostingUnlisted}
+
 // JobInsights holds LLM-extracted structured information about a job posting.
 // Populated by LLMJobAnalyzer when ai.enabled is true; nil otherwise.
 type JobInsights struct {
-	RoleType  string   // e.g. "backend", "infra", "SRE", "platform", "AI/ML"
-	YearsExp  string   // e.g. "3-5 years" | "5+ years" | "not specified"
-	TechStack []string // up to 8 technologies, e.g. ["Go", "Kubernetes", "PostgreSQL"]
+	RoleType  string    // e.g. "backend", "infra", "SRE", "platform", "AI/ML"
+	Seniority string    // one of SeniorityLevels, inferred from the description
+	YearsExp  string    // e.g. "3-5 years" | "5+ years" | "not specified"
+	TechStack []string  // up to 8 technologies, e.g. ["Go", "Kubernetes", "PostgreSQL"]
 	KeyPoints [3]string // exactly 3 concise bullet points (max 15 words each)
+
+	// Salary range mentioned in the description prose, in whole currency units.
+	// Zero when the description states no salary. Used as a fallback when the
+	// ATS provides no structured JobDetail.PayRanges.
+	SalaryMin      float64
+	SalaryMax      float64
+	SalaryCurrency string // ISO 4217 code, e.g. "USD"; empty when unknown
 }
 
+// SeniorityLevels is the closed set of values JobInsights.Seniority may take.
+// Shared by the LLM output schema and filters.seniority validation.
+var SeniorityLevels = []string{"intern", "junior", "mid", "senior", "staff", "principal", "lead", "manager"}
+
 // JobDetail holds ATS-specific metadata. Fields are populated during FetchJobs
 // (list-level data) or lazily via FetchJobDetail (detail-endpoint data).
 // Not every field applies to every ATS — see inline notes.
@@ -79,6 +144,14 @@ type JobDetail struct {
 
 	RequisitionID string // greenhouse requisition_id
 
+	// Locations lists every location of a multi-location posting; Job.Location
+	// joins them with ", ". Team and Commitment ("Full-time", "Intern") are the
+	// board's own categories.
+	// Set by: Lever (categories.allLocations, team, commitment).
+	Locations  []string
+	Team       string
+	Commitment string
+
 	// Description is the plain-text job description, normalized from HTML or
 	// pre-rendered plain text depending on the ATS.
 	// Set by: Greenhouse (FetchJobDetail), Ashby (FetchJobs), Workday (fetchDetail).
@@ -104,13 +177,23 @@ type JobFetcher interface {
 type JobStore interface {
 	HasSeen(jobID string) (bool, error)
 	MarkSeen(jobID string) error
+	MarkSeenBatch(jobIDs []string) error // all-or-nothing; already-seen IDs are ignored
 	Cleanup(olderThan time.Duration) error
 	IsEmpty() (bool, error)
 }
 
-// Notifier sends notifications for new job matches.
+// Notifier sends notifications for new job matches. delivered[i] reports
+// whether jobs[i] was delivered (or deliberately skipped), so callers can
+// retry only the failures. err is non-nil only when every delivery failed.
 type Notifier interface {
-	Notify(jobs []Job) error
+	Notify(jobs []Job) (delivered []bool, err error)
+}
+
+// DigestSender is implemented by notifiers that can send a batch of jobs as
+// one consolidated digest message instead of one message per job. delivered
+// and err mean the same as for Notify.
+type DigestSender interface {
+	SendDigest(jobs []Job) (delivered []bool, err error)
 }
 
 // JobFilter decides whether a job matches the user's criteria.
@@ -123,3 +206,83 @@ type JobFilter interface {
 type JobDetailFetcher interface {
 	FetchJobDetail(ctx context.Context, job Job) (Job, error)
 }
+
+// FreshnessCutoffSetter is implemented by fetchers that can drop stale jobs
+// while mapping the board, before the full slice is built. Jobs posted more
+// than maxAge ago are skipped; jobs without a timestamp are kept. Zero disables
+// the cutoff.
+type FreshnessCutoffSetter interface {
+	SetFreshnessCutoff(maxAge time.Duration)
+}
+
+// FirstSeenStore is implemented by stores that can report when each seen
+// job was first recorded.
+type FirstSeenStore interface {
+	// FirstSeen returns the first-seen time of each ID that has been seen.
+	FirstSeen(jobIDs []string) (map[string]time.Time, error)
+}
+
+// AuditModeSetter is implemented by fetchers that normally stop at stale
+// listings. In audit mode they return the whole board regardless of
+// freshness, so the audit TUI can browse it.
+type AuditModeSetter interface {
+	SetAuditMode(enabled bool)
+}
+
+// AuditPageCapSetter is implemented by audit-mode fetchers that page through
+// a search API. Audit mode stops after maxPages pages; zero or less keeps
+// the adapter's default.
+type AuditPageCapSetter interface {
+	SetAuditMaxPages(maxPages int)
+}
+
+// SourceRecorder is implemented by stores that can keep each seen job's
+// Source and Company alongside its ID. It behaves like JobStore.MarkSeenBatch.
+type SourceRecorder interface {
+	MarkSeenJobs(jobs []Job) error
+}
+
+// CompanyDeleter is implemented by stores that can forget one company's seen
+// jobs, so its current listings count as new again.
+type CompanyDeleter interface {
+	// DeleteByCompany removes the entries recorded for company
+	// (case-insensitive): seen jobs, recorded matches and cross-company
+	// dedup keys. It returns how many seen jobs were removed.
+	DeleteByCompany(company string) (int, error)
+}
+
+// MatchRecorder is implemented by stores that keep the notified jobs
+// themselves (not just their IDs) so matches can be exported later.
+type MatchRecorder interface {
+	RecordMatches(jobs []Job) error
+}
+
+// OpenJobStore is implemented by stores that keep recorded matches (see
+// MatchRecorder) and track which of them are still listed, so a role that
+// closes is reported once.
+type OpenJobStore interface {
+	// OpenJobs returns company's recorded matches (case-insensitive) that
+	// have not been marked closed.
+	OpenJobs(company string) ([]Job, error)
+	MarkClosed(jobIDs []string) error
+}
+
+// DedupKeyStore is implemented by stores that persist cross-company dedup
+// keys (see poller.CrossCompanyDedup) apart from seen jobs, each with the
+// company that claimed it.
+type DedupKeyStore interface {
+	// DedupClaimant returns the company that recorded key, or "" if none has.
+	DedupClaimant(key string) (string, error)
+	// RecordDedupKeys records keys as claimed by company. A key already
+	// recorded keeps its first company.
+	RecordDedupKeys(company string, keys []string) error
+}
+
+// DescriptionHashStore is implemented by stores that keep a hash of each seen
+// job's description, so a description that changes after the job was first
+// seen can be detected. Hashes are only kept for seen job IDs.
+type DescriptionHashStore interface {
+	// DescriptionHashes returns the stored hash of each ID that has one.
+	DescriptionHashes(jobIDs []string) (map[string]string, error)
+	SetDescriptionHashes(hashes map[string]string) error
+}
@@ -0,0 +1,40 @@
+package normalize
+
+import "strings"
+
+// countryAliases maps lowercase country codes and long-form names seen across
+// ATS feeds to a canonical English name. ISO 3166 alpha-2 and alpha-3 codes are
+// listed for the countries that show up on the boards we poll; anything else
+// is passed through as-is.
+var countryAliases = map[string]string{
+	"us": usToken, "usa": usToken, "u.s.": usToken, "u.s.a.": usToken,
+	"united states": usToken, "united states of america": usToken,
+	"ca": "Canada", "can": "Canada",
+	"gb": "United Kingdom", "gbr": "United Kingdom", "uk": "United Kingdom",
+	"great britain": "United Kingdom", "united kingdom of great britain and northern ireland": "United Kingdom",
+	"ie": "Ireland", "irl": "Ireland",
+	"in": "India", "ind": "India",
+	"de": "Germany", "deu": "Germany",
+	"fr": "France", "fra": "France",
+	"nl": "Netherlands", "nld": "Netherlands", "the netherlands": "Netherlands",
+	"es": "Spain", "esp": "Spain",
+	"pl": "Poland", "pol": "Poland",
+	"il": "Israel", "isr": "Israel",
+	"sg": "Singapore", "sgp": "Singapore",
+	"jp": "Japan", "jpn": "Japan",
+	"au": "Australia", "aus": "Australia",
+	"br": "Brazil", "bra": "Brazil",
+	"mx": "Mexico", "mex": "Mexico",
+}
+
+// NormalizeCountry returns the canonical name for a structured country value
+// from an ATS ("US", "USA", "United States of America" → "United States").
+// Unrecognised values are returned trimmed but otherwise unchanged; empty
+// input returns "".
+func NormalizeCountry(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if name, ok := countryAliases[strings.ToLower(raw)]; ok {
+		return name
+	}
+	return raw
+}
@@ -0,0 +1,181 @@
+package normalize
+
+import (
+	"slices"
+	"strings"
+)
+
+// usStates maps two-letter USPS state codes to their full names.
+var usStates = map[string]string{
+	"AL": "Alabama", "AK": "Alaska", "AZ": "Arizona", "AR": "Arkansas",
+	"CA": "California", "CO": "Colorado", "CT": "Connecticut", "DE": "Delaware",
+	"DC": "District of Columbia", "FL": "Florida", "GA": "Georgia", "HI": "Hawaii",
+	"ID": "Idaho", "IL": "Illinois", "IN": "Indiana", "IA": "Iowa",
+	"KS": "Kansas", "KY": "Kentucky", "LA": "Louisiana", "ME": "Maine",
+	"MD": "Maryland", "MA": "Massachusetts", "MI": "Michigan", "MN": "Minnesota",
+	"MS": "Mississippi", "MO": "Missouri", "MT": "Montana", "NE": "Nebraska",
+	"NV": "Nevada", "NH": "New Hampshire", "NJ": "New Jersey", "NM": "New Mexico",
+	"NY": "New York", "NC": "North Carolina", "ND": "North Dakota", "OH": "Ohio",
+	"OK": "Oklahoma", "OR": "Oregon", "PA": "Pennsylvania", "RI": "Rhode Island",
+	"SC": "South Carolina", "SD": "South Dakota", "TN": "Tennessee", "TX": "Texas",
+	"UT": "Utah", "VT": "Vermont", "VA": "Virginia", "WA": "Washington",
+	"WV": "West Virginia", "WI": "Wisconsin", "WY": "Wyoming",
+}
+
+// usAliases are the spellings of the United States seen across ATS feeds.
+// Matched case-insensitively.
+var usAliases = map[string]bool{
+	"us":                       true,
+	"u.s.":                     true,
+	"usa":                      true,
+	"u.s.a.":                   true,
+	"united states":            true,
+	"united states of america": true,
+}
+
+const (
+	remoteToken = "Remote"
+	usToken     = "United States"
+)
+
+// NormalizeLocation returns a canonical form of a raw ATS location string so
+// keyword matching is stable across sources:
+//   - multi-location strings ("A; B", "A | B") are split and re-joined with "; "
+//   - US spellings ("US", "USA", "United States of America") become "United States"
+//   - two-letter uppercase state codes ("CA") become full names ("California")
+//   - remote variants ("Remote - US", "US Remote", "Remote (US)") become
+//     "Remote, United States"; a bare remote marker becomes "Remote"
+//
+// Tokens that are not recognised are kept verbatim, so the result is always a
+// superset of the information in the input. Empty input returns "".
+func NormalizeLocation(raw string) string {
+	parts := SplitLocations(raw)
+	out := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, p := range parts {
+		n := normalizePart(p)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	return strings.Join(out, "; ")
+}
+
+// SplitLocations splits a multi-location string on ";" and "|" separators and
+// trims each entry. Empty entries are dropped.
+func SplitLocations(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ';' || r == '|'
+	})
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			parts = append(parts, f)
+		}
+	}
+	return parts
+}
+
+// normalizePart canonicalizes a single location (no multi-location separators).
+func normalizePart(part string) string {
+	// Treat parentheses and dash separators like commas so "Remote (US)" and
+	// "Remote - US" tokenize the same way as "Remote, US".
+	r := strings.NewReplacer("(", ",", ")", ",", " - ", ",", " – ", ",", " — ", ",")
+	part = r.Replace(part)
+
+	remote, hasUS := false, false
+	var tokens []string
+	for _, tok := range strings.Split(part, ",") {
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		tok, isRemote := stripRemote(tok)
+		if isRemote {
+			remote = true
+		}
+		if tok == "" {
+			continue
+		}
+		tok = normalizeToken(tok)
+		// Collapse repeated country markers ("United States, US") but keep
+		// legitimate repeats such as "New York, New York".
+		if tok == usToken {
+			if hasUS {
+				continue
+			}
+			hasUS = true
+		}
+		tokens = append(tokens, tok)
+	}
+
+	if remote {
+		tokens = append([]string{remoteToken}, tokens...)
+	}
+	return strings.Join(tokens, ", ")
+}
+
+// stripRemote removes a leading or trailing "remote" word from tok and reports
+// whether one was present. "US Remote" → ("US", true); "Remote" → ("", true).
+func stripRemote(tok string) (string, bool) {
+	lower := strings.ToLower(tok)
+	switch {
+	case lower == "remote":
+		return "", true
+	case strings.HasPrefix(lower, "remote "):
+		return strings.TrimSpace(tok[len("remote "):]), true
+	case strings.HasSuffix(lower, " remote"):
+		return strings.TrimSpace(tok[:len(tok)-len(" remote")]), true
+	}
+	return tok, false
+}
+
+// normalizeToken maps a single comma-separated token to its canonical form.
+// State codes are only expanded when written in uppercase to avoid rewriting
+// ordinary words ("in", "me", "or").
+func normalizeToken(tok string) string {
+	if usAliases[strings.ToLower(tok)] {
+		return usToken
+	}
+	if name, ok := usStates[tok]; ok {
+		return name
+	}
+	return tok
+}
+
+// SplitPlace extracts the city and state from a single ATS location whose
+// country is known from a structured field, in either the "City, State,
+// Country" or the country-first "Country, State, City" order Workday tenants
+// use: "San Francisco, CA" → ("San Francisco", "CA"); "US, CA, Santa Clara" →
+// ("Santa Clara", "CA"); "India, Pune" → ("Pune", ""). It returns empty
+// strings when country is empty or location is remote, lists several
+// locations, or has more parts than city, state and country.
+func SplitPlace(location, country string) (city, state string) {
+	if country == "" || len(SplitLocations(location)) != 1 || IsRemote(location) {
+		return "", ""
+	}
+	var tokens []string
+	for _, tok := range strings.Split(location, ",") {
+		if tok = strings.TrimSpace(tok); tok != "" {
+			tokens = append(tokens, tok)
+		}
+	}
+	isCountry := func(tok string) bool { return strings.EqualFold(NormalizeCountry(tok), country) }
+	switch {
+	case len(tokens) > 1 && isCountry(tokens[0]):
+		// Country first: the rest run from state down to city.
+		tokens = tokens[1:]
+		slices.Reverse(tokens)
+	case len(tokens) > 1 && isCountry(tokens[len(tokens)-1]):
+		tokens = tokens[:len(tokens)-1]
+	}
+	switch len(tokens) {
+	case 1:
+		return tokens[0], ""
+	case 2:
+		return tokens[0], tokens[1]
+	}
+	return "", ""
+}
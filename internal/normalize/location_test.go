@@ -0,0 +1,83 @@
+package normalize
+
+import "testing"
+
+func TestNormalizeLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "empty", in: "", want: ""},
+		{name: "whitespace only", in: "   ", want: ""},
+		{name: "unknown location kept verbatim", in: "London, UK", want: "London, UK"},
+		{name: "state code expanded", in: "San Francisco, CA", want: "San Francisco, California"},
+		{name: "lowercase state code not expanded", in: "Remote, in office", want: "Remote, in office"},
+		{name: "US alias", in: "US", want: "United States"},
+		{name: "USA alias", in: "Seattle, WA, USA", want: "Seattle, Washington, United States"},
+		{name: "dotted alias", in: "Austin, TX, U.S.", want: "Austin, Texas, United States"},
+		{name: "long form alias", in: "United States of America", want: "United States"},
+		{name: "microsoft style", in: "United States, Washington, Redmond", want: "United States, Washington, Redmond"},
+		{name: "workday style", in: "US, CA, Santa Clara", want: "United States, California, Santa Clara"},
+		{name: "bare remote", in: "Remote", want: "Remote"},
+		{name: "remote dash US", in: "Remote - US", want: "Remote, United States"},
+		{name: "US remote", in: "US Remote", want: "Remote, United States"},
+		{name: "remote comma US", in: "Remote, US", want: "Remote, United States"},
+		{name: "remote parenthesised", in: "Remote (United States)", want: "Remote, United States"},
+		{name: "remote trailing dash", in: "USA - Remote", want: "Remote, United States"},
+		{name: "lowercase remote", in: "remote - us", want: "Remote, United States"},
+		{name: "duplicate tokens collapsed", in: "United States, US", want: "United States"},
+		{name: "multi location semicolon", in: "San Francisco, CA; New York, NY", want: "San Francisco, California; New York, New York"},
+		{name: "multi location pipe", in: "Remote - US | Toronto, Canada", want: "Remote, United States; Toronto, Canada"},
+		{name: "multi location duplicate parts", in: "Remote, US; US Remote", want: "Remote, United States"},
+		{name: "ambiguous workday count kept", in: "2 Locations", want: "2 Locations"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeLocation(tt.in); got != tt.want {
+				t.Errorf("NormalizeLocation(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitLocations(t *testing.T) {
+	got := SplitLocations(" San Francisco, CA ;; New York, NY | Remote ")
+	want := []string{"San Francisco, CA", "New York, NY", "Remote"}
+	if len(got) != len(want) {
+		t.Fatalf("SplitLocations len = %d, want %d (%v)", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SplitLocations[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSplitPlace(t *testing.T) {
+	tests := []struct {
+		name      string
+		location  string
+		country   string
+		wantCity  string
+		wantState string
+	}{
+		{name: "city and state", location: "San Francisco, CA", country: "United States", wantCity: "San Francisco", wantState: "CA"},
+		{name: "trailing country dropped", location: "Seattle, WA, USA", country: "United States", wantCity: "Seattle", wantState: "WA"},
+		{name: "workday country first", location: "US, CA, Santa Clara", country: "United States", wantCity: "Santa Clara", wantState: "CA"},
+		{name: "workday country and city", location: "India, Pune", country: "India", wantCity: "Pune"},
+		{name: "city only", location: "London", country: "United Kingdom", wantCity: "London"},
+		{name: "no country", location: "San Francisco, CA", country: ""},
+		{name: "remote", location: "Remote - US", country: "United States"},
+		{name: "multi location", location: "San Francisco, CA; New York, NY", country: "United States"},
+		{name: "too many parts", location: "Building 4, Redmond, WA", country: "United States"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			city, state := SplitPlace(tt.location, tt.country)
+			if city != tt.wantCity || state != tt.wantState {
+				t.Errorf("SplitPlace(%q, %q) = (%q, %q), want (%q, %q)", tt.location, tt.country, city, state, tt.wantCity, tt.wantState)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+)
+
+// remoteMarkers matches the ways ATS feeds spell "remote" in location text.
+var remoteMarkers = regexp.MustCompile(`\b(remote|distributed|anywhere|work[ -]from[ -]home|wfh|telecommut\w*|home[ -]based|virtual)\b`)
+
+// IsRemote reports whether a free-text location describes a remote role
+// ("Remote - US", "Distributed", "Anywhere", "Work from home"). Each entry of a
+// multi-location string is checked separately and any remote entry counts;
+// an entry that also says "hybrid" does not, since hybrid roles still need an
+// office.
+func IsRemote(location string) bool {
+	for _, part := range SplitLocations(location) {
+		lower := strings.ToLower(part)
+		if strings.Contains(lower, "hybrid") {
+			continue
+		}
+		if remoteMarkers.MatchString(lower) {
+			return true
+		}
+	}
+	return false
+}
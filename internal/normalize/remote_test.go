@@ -0,0 +1,36 @@
+package normalize
+
+import "testing"
+
+func TestIsRemote(t *testing.T) {
+	tests := []struct {
+		location string
+		want     bool
+	}{
+		{"Remote", true},
+		{"Remote - US", true},
+		{"US Remote", true},
+		{"Remote (Canada)", true},
+		{"Distributed", true},
+		{"Anywhere in the US", true},
+		{"Work from home", true},
+		{"Work-From-Home, India", true},
+		{"WFH", true},
+		{"Telecommute", true},
+		{"Home Based - UK", true},
+		{"Virtual", true},
+		{"San Francisco, CA; Remote, US", true},
+		{"San Francisco, CA", false},
+		{"New York, NY; Austin, TX", false},
+		{"Hybrid - Remote", false},
+		{"Remoteville, OR", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			if got := IsRemote(tt.location); got != tt.want {
+				t.Errorf("IsRemote(%q) = %v, want %v", tt.location, got, tt.want)
+			}
+		})
+	}
+}
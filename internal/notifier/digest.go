@@ -0,0 +1,239 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure DigestNotifier implements model.Notifier.
+var _ model.Notifier = (*DigestNotifier)(nil)
+
+// DigestSchedule is when a digest is sent: At past midnight in Location, on
+// Days (every day when empty).
+type DigestSchedule struct {
+	At       time.Duration
+	Days     []time.Weekday
+	Location *time.Location
+}
+
+// Next returns the first send time after t.
+func (d DigestSchedule) Next(t time.Time) time.Time {
+	local := t.In(d.Location)
+	y, m, day := local.Date()
+	for i := 0; i <= 7; i++ {
+		at := time.Date(y, m, day+i, 0, 0, 0, 0, d.Location).Add(d.At)
+		if !at.After(local) {
+			continue
+		}
+		if len(d.Days) == 0 || slices.Contains(d.Days, at.Weekday()) {
+			return at
+		}
+	}
+	// Unreachable with at least one valid day; fall back to a day later.
+	return local.Add(24 * time.Hour)
+}
+
+// DigestNotifier wraps another notifier and collects the jobs it is given,
+// sending them as one digest at the next scheduled time: a single message
+// when the wrapped notifier is a model.DigestSender, otherwise one Notify
+// batch.
+//
+// Like QuietHoursNotifier, collected jobs are reported as delivered so the
+// poller marks them seen once. Unlike it, the pending jobs are written to a
+// JSON file on every change, so a restart sends them with the next digest
+// instead of dropping them.
+type DigestNotifier struct {
+	next     model.Notifier
+	schedule DigestSchedule
+	path     string
+	logger   *slog.Logger
+	now      func() time.Time // injectable for tests
+
+	mu      sync.Mutex
+	pending []model.Job
+	sending []model.Job // taken by a Flush in progress; still saved until it ends
+	timer   *time.Timer
+}
+
+// NewDigestNotifier returns a notifier that collects jobs for next and sends
+// them on schedule. Jobs left pending in the file at path by a previous run
+// are loaded and go out with the next digest.
+func NewDigestNotifier(next model.Notifier, schedule DigestSchedule, path string, logger *slog.Logger) (*DigestNotifier, error) {
+	d := &DigestNotifier{
+		next:     next,
+		schedule: schedule,
+		path:     path,
+		logger:   logger,
+		now:      time.Now,
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("reading digest file: %w", err)
+	case len(data) > 0:
+		if err := json.Unmarshal(data, &d.pending); err != nil {
+			return nil, fmt.Errorf("parsing digest file %s: %w", path, err)
+		}
+	}
+	if len(d.pending) > 0 {
+		logger.Info("digest jobs pending from previous run", "jobs", len(d.pending))
+		d.mu.Lock()
+		d.armLocked()
+		d.mu.Unlock()
+	}
+	return d, nil
+}
+
+// Notify adds jobs to the pending digest, arms the timer for the next send
+// time and reports every job as delivered. If the pending jobs cannot be
+// saved, nothing is added and the error is returned, so the poller retries
+// the jobs later.
+func (d *DigestNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	d.mu.Lock()
+	pending := append(slices.Clip(d.pending), jobs...)
+	if err := d.save(slices.Concat(d.sending, pending)); err != nil {
+		d.mu.Unlock()
+		return nil, err
+	}
+	d.pending = pending
+	d.armLocked()
+	total := len(d.pending)
+	d.mu.Unlock()
+
+	d.logger.Info("jobs added to digest", "jobs", len(jobs), "total_pending", total)
+	return allDelivered(len(jobs)), nil
+}
+
+// Flush sends every pending job to the wrapped notifier as one digest. Jobs
+// it fails to deliver stay pending for the next digest. It does nothing while
+// another Flush is sending.
+func (d *DigestNotifier) Flush() {
+	d.mu.Lock()
+	if d.sending != nil || len(d.pending) == 0 {
+		d.mu.Unlock()
+		return
+	}
+	jobs := d.pending
+	d.sending, d.pending = jobs, nil
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+
+	d.logger.Info("sending digest", "jobs", len(jobs))
+	delivered, err := sendDigest(d.next, jobs)
+	var failed []model.Job
+	if err != nil {
+		d.logger.Error("digest failed, keeping jobs for the next one", "jobs", len(jobs), "error", err)
+		failed = jobs
+	} else {
+		for i, ok := range delivered {
+			if !ok {
+				d.logger.Warn("digest job not delivered, keeping it for the next one", "company", jobs[i].Company, "title", jobs[i].Title)
+				failed = append(failed, jobs[i])
+			}
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// Jobs added while the digest was being sent follow the ones that failed.
+	d.pending = append(failed, d.pending...)
+	d.sending = nil
+	if err := d.save(d.pending); err != nil {
+		d.logger.Error("saving digest file failed", "path", d.path, "error", err)
+	}
+	if len(d.pending) > 0 {
+		d.armLocked()
+	}
+}
+
+// sendDigest sends jobs to n as one digest message when n is a
+// model.DigestSender, and through Notify otherwise.
+func sendDigest(n model.Notifier, jobs []model.Job) ([]bool, error) {
+	if ds, ok := n.(model.DigestSender); ok {
+		return ds.SendDigest(jobs)
+	}
+	return n.Notify(jobs)
+}
+
+// digestHeadline titles a digest of n jobs, e.g. "Job digest: 3 roles".
+func digestHeadline(n int) string {
+	if n == 1 {
+		return "Job digest: 1 role"
+	}
+	return fmt.Sprintf("Job digest: %d roles", n)
+}
+
+// digestEntry names j on one line of a digest: closed marker, company, title
+// and, when known, location.
+func digestEntry(j model.Job) string {
+	entry := capitalize(j.Company) + ": " + j.Title
+	if j.PostingStatus == model.PostingClosed {
+		entry = "Closed: " + entry
+	}
+	if j.Location != "" {
+		entry += " · " + j.Location
+	}
+	return entry
+}
+
+// allDelivered reports each of n jobs delivered.
+func allDelivered(n int) []bool {
+	delivered := make([]bool, n)
+	for i := range delivered {
+		delivered[i] = true
+	}
+	return delivered
+}
+
+// armLocked starts the timer for the next send time unless it is running.
+// d.mu must be held.
+func (d *DigestNotifier) armLocked() {
+	if d.timer != nil {
+		return
+	}
+	now := d.now()
+	at := d.schedule.Next(now)
+	d.timer = time.AfterFunc(at.Sub(now), d.Flush)
+	d.logger.Info("digest scheduled", "at", at.Format(time.RFC3339))
+}
+
+// save writes jobs to the digest file, replacing it atomically.
+func (d *DigestNotifier) save(jobs []model.Job) error {
+	if jobs == nil {
+		jobs = []model.Job{}
+	}
+	if err := writeJSONFile(d.path, jobs); err != nil {
+		return fmt.Errorf("writing digest file: %w", err)
+	}
+	return nil
+}
+
+// writeJSONFile writes v as JSON to path through a temporary file, so a
+// crash mid-write leaves the old contents.
+func writeJSONFile(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,142 @@
+package notifier
+
+import (
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestDigestSchedule_Next(t *testing.T) {
+	// 2025-03-14 is a Friday.
+	at := func(d, h, m int) time.Time { return time.Date(2025, 3, d, h, m, 0, 0, time.UTC) }
+	daily := DigestSchedule{At: 8 * time.Hour, Location: time.UTC}
+	weekdays := DigestSchedule{
+		At:       8 * time.Hour,
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Location: time.UTC,
+	}
+
+	tests := []struct {
+		name     string
+		schedule DigestSchedule
+		t        time.Time
+		want     time.Time
+	}{
+		{"before time", daily, at(14, 6, 0), at(14, 8, 0)},
+		{"at time", daily, at(14, 8, 0), at(15, 8, 0)},
+		{"after time", daily, at(14, 21, 0), at(15, 8, 0)},
+		{"friday night waits for monday", weekdays, at(14, 21, 0), at(17, 8, 0)},
+		{"sunday waits for monday", weekdays, at(16, 12, 0), at(17, 8, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.Next(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.t.Format(time.RFC3339), got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestDigestNotifier_AccumulatesThenFlushesAtTime(t *testing.T) {
+	next := &recordingNotifier{}
+	// The digest is due 50ms after the fake clock's "now".
+	now := time.Date(2025, 3, 10, 7, 59, 59, 950_000_000, time.UTC)
+	path := filepath.Join(t.TempDir(), "digest.json")
+	d, err := NewDigestNotifier(next, DigestSchedule{At: 8 * time.Hour, Location: time.UTC}, path, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewDigestNotifier: %v", err)
+	}
+	d.now = func() time.Time { return now }
+
+	delivered, err := d.Notify([]model.Job{{ID: "1", Company: "acme"}, {ID: "2", Company: "acme"}})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	for i, ok := range delivered {
+		if !ok {
+			t.Errorf("delivered[%d] = false, want collected jobs reported delivered", i)
+		}
+	}
+	if _, err := d.Notify([]model.Job{{ID: "3", Company: "beta"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got := next.sent(); got != 0 {
+		t.Fatalf("sent %d jobs before the digest time, want 0", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for next.sent() < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := next.sent(); got != 3 {
+		t.Fatalf("sent %d jobs at the digest time, want 3", got)
+	}
+	next.mu.Lock()
+	batches := len(next.batches)
+	next.mu.Unlock()
+	if batches != 1 {
+		t.Errorf("got %d batches, want one digest", batches)
+	}
+
+	// Sent jobs are cleared from the file.
+	reloaded, err := NewDigestNotifier(next, DigestSchedule{At: 8 * time.Hour, Location: time.UTC}, path, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("reloading: %v", err)
+	}
+	if len(reloaded.pending) != 0 {
+		t.Errorf("pending after digest = %v, want none", reloaded.pending)
+	}
+}
+
+func TestDigestNotifier_PendingJobsSurviveRestart(t *testing.T) {
+	schedule := DigestSchedule{At: 8 * time.Hour, Location: time.UTC}
+	path := filepath.Join(t.TempDir(), "digest.json")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	first, err := NewDigestNotifier(&recordingNotifier{}, schedule, path, logger)
+	if err != nil {
+		t.Fatalf("NewDigestNotifier: %v", err)
+	}
+	if _, err := first.Notify([]model.Job{{ID: "1", Company: "acme", Title: "Engineer"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	first.timer.Stop()
+
+	next := &recordingNotifier{}
+	second, err := NewDigestNotifier(next, schedule, path, logger)
+	if err != nil {
+		t.Fatalf("NewDigestNotifier after restart: %v", err)
+	}
+	second.timer.Stop()
+	second.Flush()
+
+	if len(next.batches) != 1 || len(next.batches[0]) != 1 || next.batches[0][0].Title != "Engineer" {
+		t.Errorf("batches = %v, want the job collected before the restart", next.batches)
+	}
+}
+
+func TestDigestNotifier_FlushSendsOneDigestMessage(t *testing.T) {
+	next := &recordingDigestSender{}
+	path := filepath.Join(t.TempDir(), "digest.json")
+	d, err := NewDigestNotifier(next, DigestSchedule{At: 8 * time.Hour, Location: time.UTC}, path, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewDigestNotifier: %v", err)
+	}
+
+	if _, err := d.Notify([]model.Job{{ID: "1", Company: "acme"}, {ID: "2", Company: "beta"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	d.timer.Stop()
+	d.Flush()
+
+	if len(next.digests) != 1 || len(next.digests[0]) != 2 {
+		t.Errorf("digests = %v, want both jobs in one digest", next.digests)
+	}
+	if len(next.batches) != 0 {
+		t.Errorf("batches = %v, want no per-job notifications", next.batches)
+	}
+}
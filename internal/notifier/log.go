@@ -1,13 +1,18 @@
 package notifier
 
 import (
+	"context"
 	"log/slog"
+	"time"
 
 	"github.com/amishk599/firstin/internal/model"
 )
 
-// Ensure LogNotifier implements model.Notifier.
-var _ model.Notifier = (*LogNotifier)(nil)
+// Ensure LogNotifier implements model.Notifier and model.DigestSender.
+var (
+	_ model.Notifier     = (*LogNotifier)(nil)
+	_ model.DigestSender = (*LogNotifier)(nil)
+)
 
 // LogNotifier writes new job matches to the given logger as structured messages.
 type LogNotifier struct {
@@ -19,15 +24,120 @@ func NewLogNotifier(logger *slog.Logger) *LogNotifier {
 	return &LogNotifier{logger: logger}
 }
 
-// Notify logs each job with company, title, location, URL, and posted_at.
-// Returns nil (stdout logging does not fail).
-func (n *LogNotifier) Notify(jobs []model.Job) error {
-	for _, j := range jobs {
-		args := []any{"company", j.Company, "title", j.Title, "location", j.Location, "url", j.URL}
-		if j.PostedAt != nil {
-			args = append(args, "posted_at", *j.PostedAt)
+// Notify logs each job with every populated field: the listing fields, then
+// "detail" and "insights" groups when present. The description is logged
+// only when the logger is at debug level, since it can run to pages.
+// Every job is reported delivered (stdout logging does not fail).
+func (n *LogNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	withDescription := n.logger.Enabled(context.Background(), slog.LevelDebug)
+	delivered := make([]bool, len(jobs))
+	for i, j := range jobs {
+		msg := "new job"
+		if j.PostingStatus == model.PostingClosed {
+			msg = "job closed"
+		}
+		n.logger.Info(msg, jobAttrs(j, withDescription)...)
+		delivered[i] = true
+	}
+	return delivered, nil
+}
+
+// SendDigest logs jobs as one "job digest" record listing each job and its
+// URL. Every job is reported delivered.
+func (n *LogNotifier) SendDigest(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	entries := make([]string, len(jobs))
+	for i, j := range jobs {
+		entries[i] = digestEntry(j) + " " + j.URL
+	}
+	n.logger.Info("job digest", "count", len(jobs), "jobs", entries)
+	return allDelivered(len(jobs)), nil
+}
+
+// jobAttrs returns j as slog key-value pairs, omitting empty fields.
+func jobAttrs(j model.Job, withDescription bool) []any {
+	args := []any{"company", j.Company, "title", j.Title, "location", j.Location, "url", j.URL}
+	if j.PostedAt != nil {
+		args = append(args, "posted_at", *j.PostedAt)
+	}
+	args = appendNonEmpty(args, "id", j.ID)
+	args = appendNonEmpty(args, "source", j.Source)
+	args = appendNonEmpty(args, "country", j.Country)
+	args = appendNonEmpty(args, "posting_status", j.PostingStatus)
+	if j.IsRemote {
+		args = append(args, "remote", true)
+	}
+	if !j.FirstSeen.IsZero() {
+		args = append(args, "first_seen", j.FirstSeen)
+	}
+
+	if d := j.Detail; d != nil {
+		var detail []any
+		detail = appendNonEmpty(detail, "apply_url", d.ApplyURL)
+		detail = appendNonEmpty(detail, "requisition_id", d.RequisitionID)
+		detail = appendNonEmpty(detail, "posted_on", d.PostedOn)
+		detail = appendNonEmpty(detail, "team", d.Team)
+		detail = appendNonEmpty(detail, "commitment", d.Commitment)
+		if len(d.Locations) > 1 {
+			detail = append(detail, "locations", d.Locations)
+		}
+		for _, t := range []struct {
+			key string
+			at  *time.Time
+		}{
+			{"start_date", d.StartDate},
+			{"updated_at", d.UpdatedAt},
+			{"first_published", d.FirstPublished},
+			{"published_at", d.PublishedAt},
+		} {
+			if t.at != nil {
+				detail = append(detail, t.key, *t.at)
+			}
 		}
-		n.logger.Info("new job", args...)
+		if pay := payLines(j); len(pay) > 0 {
+			detail = append(detail, "pay", pay)
+		}
+		if withDescription {
+			detail = appendNonEmpty(detail, "description", d.Description)
+		}
+		if len(detail) > 0 {
+			args = append(args, slog.Group("detail", detail...))
+		}
+	}
+
+	if ins := j.Insights; ins != nil {
+		var insights []any
+		insights = appendNonEmpty(insights, "role_type", ins.RoleType)
+		insights = appendNonEmpty(insights, "seniority", ins.Seniority)
+		insights = appendNonEmpty(insights, "years_exp", ins.YearsExp)
+		if len(ins.TechStack) > 0 {
+			insights = append(insights, "tech_stack", ins.TechStack)
+		}
+		var points []string
+		for _, pt := range ins.KeyPoints {
+			if pt != "" {
+				points = append(points, pt)
+			}
+		}
+		if len(points) > 0 {
+			insights = append(insights, "key_points", points)
+		}
+		if ins.SalaryMax > 0 {
+			insights = append(insights, "salary_min", ins.SalaryMin, "salary_max", ins.SalaryMax)
+			insights = appendNonEmpty(insights, "salary_currency", ins.SalaryCurrency)
+		}
+		if len(insights) > 0 {
+			args = append(args, slog.Group("insights", insights...))
+		}
+	}
+	return args
+}
+
+func appendNonEmpty(args []any, key, value string) []any {
+	if value == "" {
+		return args
 	}
-	return nil
+	return append(args, key, value)
 }
@@ -1,8 +1,11 @@
 package notifier
 
 import (
+	"bytes"
+	"encoding/json"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,11 +15,11 @@ import (
 func TestLogNotifier_Notify_zeroJobs(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 	n := NewLogNotifier(logger)
-	err := n.Notify(nil)
+	_, err := n.Notify(nil)
 	if err != nil {
 		t.Errorf("Notify(nil) = %v, want nil", err)
 	}
-	err = n.Notify([]model.Job{})
+	_, err = n.Notify([]model.Job{})
 	if err != nil {
 		t.Errorf("Notify([]) = %v, want nil", err)
 	}
@@ -30,8 +33,85 @@ func TestLogNotifier_Notify_multipleJobs_returnsNil(t *testing.T) {
 		{Company: "Acme", Title: "Engineer", Location: "Remote", URL: "https://example.com/1", PostedAt: &posted},
 		{Company: "Beta", Title: "Developer", Location: "US", URL: "https://example.com/2"},
 	}
-	err := n.Notify(jobs)
+	_, err := n.Notify(jobs)
 	if err != nil {
 		t.Errorf("Notify(jobs) = %v, want nil", err)
 	}
 }
+
+func TestLogNotifier_Notify_logsDetailAndInsights(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLogNotifier(slog.New(slog.NewJSONHandler(&buf, nil)))
+	jobs := []model.Job{{
+		ID:      "42",
+		Company: "Acme",
+		Title:   "Backend Engineer",
+		URL:     "https://example.com/42",
+		Source:  "greenhouse",
+		Detail: &model.JobDetail{
+			RequisitionID: "REQ-7",
+			Description:   "A very long description.",
+			PayRanges:     []model.PayRange{{MinCents: 15000000, MaxCents: 20000000, CurrencyType: "USD", Title: "US"}},
+		},
+		Insights: &model.JobInsights{
+			RoleType:  "backend",
+			Seniority: "senior",
+			TechStack: []string{"Go", "Postgres"},
+			KeyPoints: [3]string{"Owns billing", "Small team", ""},
+		},
+	}}
+
+	if _, err := n.Notify(jobs); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	var rec struct {
+		ID     string `json:"id"`
+		Source string `json:"source"`
+		Detail struct {
+			RequisitionID string   `json:"requisition_id"`
+			Pay           []string `json:"pay"`
+			Description   string   `json:"description"`
+		} `json:"detail"`
+		Insights struct {
+			RoleType  string   `json:"role_type"`
+			Seniority string   `json:"seniority"`
+			TechStack []string `json:"tech_stack"`
+			KeyPoints []string `json:"key_points"`
+		} `json:"insights"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("decode log line %q: %v", buf.String(), err)
+	}
+	if rec.ID != "42" || rec.Source != "greenhouse" {
+		t.Errorf("id, source = %q, %q; want 42, greenhouse", rec.ID, rec.Source)
+	}
+	if len(rec.Detail.Pay) != 1 || rec.Detail.Pay[0] != "USD $150000 - $200000 (US)" {
+		t.Errorf("detail.pay = %v, want [USD $150000 - $200000 (US)]", rec.Detail.Pay)
+	}
+	if rec.Detail.RequisitionID != "REQ-7" {
+		t.Errorf("detail.requisition_id = %q, want REQ-7", rec.Detail.RequisitionID)
+	}
+	if rec.Detail.Description != "" {
+		t.Errorf("description logged at info level: %q", rec.Detail.Description)
+	}
+	if rec.Insights.RoleType != "backend" || rec.Insights.Seniority != "senior" || len(rec.Insights.TechStack) != 2 {
+		t.Errorf("insights = %+v, want backend/senior with 2 technologies", rec.Insights)
+	}
+	if len(rec.Insights.KeyPoints) != 2 {
+		t.Errorf("insights.key_points = %v, want the 2 non-empty points", rec.Insights.KeyPoints)
+	}
+}
+
+func TestLogNotifier_Notify_descriptionAtDebug(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLogNotifier(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	jobs := []model.Job{{Company: "Acme", Title: "Engineer", Detail: &model.JobDetail{Description: "Build things."}}}
+
+	if _, err := n.Notify(jobs); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if !strings.Contains(buf.String(), `detail.description="Build things."`) {
+		t.Errorf("debug log missing description: %s", buf.String())
+	}
+}
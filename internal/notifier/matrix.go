@@ -0,0 +1,240 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure MatrixNotifier implements model.Notifier and model.DigestSender.
+var (
+	_ model.Notifier     = (*MatrixNotifier)(nil)
+	_ model.DigestSender = (*MatrixNotifier)(nil)
+)
+
+// MatrixNotifier posts job alerts to a Matrix room via the client-server API.
+type MatrixNotifier struct {
+	homeserverURL string
+	roomID        string
+	accessToken   string
+	httpClient    *http.Client
+	logger        *slog.Logger
+	messageDelay  time.Duration // pause between messages to stay under homeserver rate limits
+
+	txnPrefix string
+	txnSeq    atomic.Int64
+}
+
+// NewMatrixNotifier returns a notifier that sends each job as an m.room.message
+// to roomID on the given homeserver, authenticating with accessToken.
+func NewMatrixNotifier(homeserverURL, roomID, accessToken string, httpClient *http.Client, logger *slog.Logger) *MatrixNotifier {
+	return &MatrixNotifier{
+		homeserverURL: strings.TrimRight(homeserverURL, "/"),
+		roomID:        roomID,
+		accessToken:   accessToken,
+		httpClient:    httpClient,
+		logger:        logger,
+		messageDelay:  500 * time.Millisecond,
+		txnPrefix:     "firstin-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+}
+
+// Notify sends each job as a separate Matrix message.
+// delivered[i] reports whether jobs[i] was sent. Returns an error only if ALL
+// messages fail. Individual failures are logged.
+func (m *MatrixNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	delivered := make([]bool, len(jobs))
+	failures := 0
+	for i, j := range jobs {
+		if i > 0 {
+			time.Sleep(m.messageDelay)
+		}
+
+		if err := m.sendMessage(j); err != nil {
+			m.logger.Error("matrix notification failed", "company", j.Company, "title", j.Title, "error", err)
+			failures++
+			continue
+		}
+		delivered[i] = true
+	}
+
+	sent := len(jobs) - failures
+	if failures == len(jobs) {
+		return delivered, fmt.Errorf("all %d matrix notifications failed", failures)
+	}
+	m.logger.Info("matrix notifications complete", "sent", sent, "failed", failures)
+	return delivered, nil
+}
+
+// SendDigest sends jobs as one Matrix message listing each job as a link.
+func (m *MatrixNotifier) SendDigest(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	if _, err := m.send(buildMatrixDigest(jobs)); err != nil {
+		return make([]bool, len(jobs)), fmt.Errorf("matrix digest: %w", err)
+	}
+	m.logger.Info("matrix digest sent", "jobs", len(jobs))
+	return allDelivered(len(jobs)), nil
+}
+
+// matrixMessage is the m.room.message event content.
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// nextTxnID returns a transaction ID unique to this process. The homeserver
+// deduplicates sends with the same ID, so a retried request is idempotent.
+func (m *MatrixNotifier) nextTxnID() string {
+	return fmt.Sprintf("%s-%d", m.txnPrefix, m.txnSeq.Add(1))
+}
+
+func (m *MatrixNotifier) sendMessage(j model.Job) error {
+	retried, err := m.send(buildMatrixMessage(j))
+	if err != nil {
+		return err
+	}
+	if retried {
+		m.logger.Info("matrix message sent", "company", j.Company, "title", j.Title, "retried", true)
+		return nil
+	}
+	m.logger.Info("matrix message sent", "company", j.Company, "title", j.Title)
+	return nil
+}
+
+// send puts msg into the room, retrying once on 429, and reports whether it
+// retried.
+func (m *MatrixNotifier) send(msg matrixMessage) (retried bool, err error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("marshal matrix message: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		m.homeserverURL, url.PathEscape(m.roomID), url.PathEscape(m.nextTxnID()))
+
+	resp, err := m.put(endpoint, body)
+	if err != nil {
+		return false, fmt.Errorf("send to matrix: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		secs, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if secs <= 0 {
+			secs = 1
+		}
+		m.logger.Warn("matrix rate limited, retrying", "retry_after_secs", secs)
+		time.Sleep(time.Duration(secs) * time.Second)
+
+		// Same txn ID: if the first send actually landed, the homeserver
+		// returns the original event instead of posting a duplicate.
+		resp2, err := m.put(endpoint, body)
+		if err != nil {
+			return true, fmt.Errorf("send to matrix (retry): %w", err)
+		}
+		defer resp2.Body.Close()
+
+		if resp2.StatusCode != http.StatusOK {
+			return true, fmt.Errorf("matrix returned %d on retry", resp2.StatusCode)
+		}
+		return true, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("matrix returned %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+func (m *MatrixNotifier) put(endpoint string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.accessToken)
+	return m.httpClient.Do(req)
+}
+
+// buildMatrixMessage renders a job as a plain-text body with an HTML
+// formatted_body for clients that support it.
+func buildMatrixMessage(j model.Job) matrixMessage {
+	company := capitalize(j.Company)
+	posted := "Just detected"
+	if j.PostedAt != nil {
+		posted = j.PostedAt.UTC().Format(time.RFC1123)
+	}
+
+	var plain strings.Builder
+	fmt.Fprintf(&plain, "%s%s: %s\n", headlinePrefix(j), company, j.Title)
+	fmt.Fprintf(&plain, "Location: %s\nPosted: %s\n", j.Location, posted)
+
+	var h strings.Builder
+	fmt.Fprintf(&h, "<h4>%s%s: %s</h4>", headlinePrefix(j), html.EscapeString(company), html.EscapeString(j.Title))
+	fmt.Fprintf(&h, "<p><b>Location:</b> %s<br><b>Posted:</b> %s<br><b>Source:</b> %s</p>",
+		html.EscapeString(j.Location), html.EscapeString(posted), html.EscapeString(capitalize(j.Source)))
+
+	if j.Insights != nil {
+		role := j.Insights.RoleType
+		if j.Insights.Seniority != "" {
+			role += " (" + j.Insights.Seniority + ")"
+		}
+		fmt.Fprintf(&h, "<p><b>Role:</b> %s &nbsp; <b>Exp:</b> %s &nbsp; <b>Stack:</b> %s</p><ul>",
+			html.EscapeString(role), html.EscapeString(j.Insights.YearsExp), html.EscapeString(strings.Join(j.Insights.TechStack, ", ")))
+		for _, p := range j.Insights.KeyPoints {
+			if p != "" {
+				fmt.Fprintf(&h, "<li>%s</li>", html.EscapeString(p))
+			}
+		}
+		h.WriteString("</ul>")
+	}
+
+	fmt.Fprintf(&plain, "Apply: %s", j.URL)
+	fmt.Fprintf(&h, `<p><a href="%s">Apply Now</a></p>`, html.EscapeString(j.URL))
+
+	return matrixMessage{
+		MsgType:       "m.text",
+		Body:          plain.String(),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: h.String(),
+	}
+}
+
+// buildMatrixDigest renders a digest as a headline and one line per job,
+// with an HTML list of links for clients that support it.
+func buildMatrixDigest(jobs []model.Job) matrixMessage {
+	headline := digestHeadline(len(jobs))
+	var plain, h strings.Builder
+	plain.WriteString(headline)
+	fmt.Fprintf(&h, "<h4>%s</h4><ul>", html.EscapeString(headline))
+	for _, j := range jobs {
+		fmt.Fprintf(&plain, "\n• %s\n  %s", digestEntry(j), j.URL)
+		fmt.Fprintf(&h, `<li><a href="%s">%s</a></li>`, html.EscapeString(j.URL), html.EscapeString(digestEntry(j)))
+	}
+	h.WriteString("</ul>")
+
+	return matrixMessage{
+		MsgType:       "m.text",
+		Body:          plain.String(),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: h.String(),
+	}
+}
@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestMatrixNotifier_SendsHTMLMessages(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var bodies []matrixMessage
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var msg matrixMessage
+		json.Unmarshal(raw, &msg)
+
+		mu.Lock()
+		paths = append(paths, r.URL.EscapedPath())
+		bodies = append(bodies, msg)
+		auth = r.Header.Get("Authorization")
+		mu.Unlock()
+
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte(`{"event_id":"$abc"}`))
+	}))
+	defer srv.Close()
+
+	n := NewMatrixNotifier(srv.URL+"/", "!room:example.org", "secret-token", srv.Client(), discardLogger())
+	n.messageDelay = 0
+
+	jobs := []model.Job{
+		sampleJob("Backend Engineer", "Acme Corp"),
+		sampleJob("Platform <Engineer>", "Globex"),
+	}
+	if _, err := n.Notify(jobs); err != nil {
+		t.Fatalf("Notify() = %v, want nil", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(bodies))
+	}
+	if auth != "Bearer secret-token" {
+		t.Errorf("Authorization = %q", auth)
+	}
+
+	first := bodies[0]
+	if first.MsgType != "m.text" || first.Format != "org.matrix.custom.html" {
+		t.Errorf("msgtype/format = %q/%q", first.MsgType, first.Format)
+	}
+	for _, want := range []string{"Acme Corp", "Backend Engineer", `href="https://example.com/apply"`} {
+		if !strings.Contains(first.FormattedBody, want) {
+			t.Errorf("formatted_body missing %q: %s", want, first.FormattedBody)
+		}
+	}
+	if !strings.Contains(first.Body, "Backend Engineer") {
+		t.Errorf("plain body missing title: %s", first.Body)
+	}
+	if !strings.Contains(bodies[1].FormattedBody, "Platform &lt;Engineer&gt;") {
+		t.Errorf("title should be HTML-escaped: %s", bodies[1].FormattedBody)
+	}
+
+	prefix := "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/"
+	txnIDs := make(map[string]bool)
+	for _, p := range paths {
+		if !strings.HasPrefix(p, prefix) {
+			t.Fatalf("unexpected path %q", p)
+		}
+		txnIDs[strings.TrimPrefix(p, prefix)] = true
+	}
+	if len(txnIDs) != 2 {
+		t.Errorf("expected 2 unique txn IDs, got %v", paths)
+	}
+}
+
+func TestMatrixNotifier_AllFail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	n := NewMatrixNotifier(srv.URL, "!room:example.org", "bad", srv.Client(), discardLogger())
+	n.messageDelay = 0
+
+	if _, err := n.Notify([]model.Job{sampleJob("Engineer", "Acme")}); err == nil {
+		t.Fatal("expected error when all sends fail")
+	}
+}
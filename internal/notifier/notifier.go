@@ -1 +1,34 @@
 package notifier
+
+import (
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// headlinePrefix opens a notification's headline: a rocket for a new role,
+// or a closed marker for a notified role the poller reports closed.
+func headlinePrefix(j model.Job) string {
+	if j.PostingStatus == model.PostingClosed {
+		return "🔒 Closed: "
+	}
+	return "🚀 "
+}
+
+// SendTestMessage sends a dummy job notification through n, whichever
+// notifier type it is, to verify the integration works.
+func SendTestMessage(n model.Notifier) error {
+	now := time.Now()
+	testJob := model.Job{
+		ID:        "test-001",
+		Company:   "FirstIn Test",
+		Title:     "Test Notification — Integration Verified",
+		Location:  "Everywhere",
+		URL:       "https://www.ycombinator.com/jobs",
+		PostedAt:  &now,
+		FirstSeen: now,
+		Source:    "test",
+	}
+	_, err := n.Notify([]model.Job{testJob})
+	return err
+}
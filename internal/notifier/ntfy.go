@@ -0,0 +1,193 @@
+package notifier
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure NtfyNotifier implements model.Notifier and model.DigestSender.
+var (
+	_ model.Notifier     = (*NtfyNotifier)(nil)
+	_ model.DigestSender = (*NtfyNotifier)(nil)
+)
+
+// NtfyNotifier publishes job alerts as ntfy push notifications.
+type NtfyNotifier struct {
+	topicURL     string
+	httpClient   *http.Client
+	logger       *slog.Logger
+	messageDelay time.Duration // pause between messages to stay under ntfy rate limits
+}
+
+// NewNtfyNotifier returns a notifier that POSTs each job to topicURL,
+// e.g. "https://ntfy.sh/my-job-alerts".
+func NewNtfyNotifier(topicURL string, httpClient *http.Client, logger *slog.Logger) *NtfyNotifier {
+	return &NtfyNotifier{
+		topicURL:     topicURL,
+		httpClient:   httpClient,
+		logger:       logger,
+		messageDelay: 500 * time.Millisecond,
+	}
+}
+
+// Notify publishes each job as a separate ntfy message.
+// delivered[i] reports whether jobs[i] was sent. Returns an error only if ALL
+// messages fail. Individual failures are logged.
+func (n *NtfyNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	delivered := make([]bool, len(jobs))
+	failures := 0
+	for i, j := range jobs {
+		if i > 0 {
+			time.Sleep(n.messageDelay)
+		}
+
+		if err := n.sendMessage(j); err != nil {
+			n.logger.Error("ntfy notification failed", "company", j.Company, "title", j.Title, "error", err)
+			failures++
+			continue
+		}
+		delivered[i] = true
+	}
+
+	sent := len(jobs) - failures
+	if failures == len(jobs) {
+		return delivered, fmt.Errorf("all %d ntfy notifications failed", failures)
+	}
+	n.logger.Info("ntfy notifications complete", "sent", sent, "failed", failures)
+	return delivered, nil
+}
+
+// SendDigest publishes jobs as one ntfy message listing each job with its
+// link.
+func (n *NtfyNotifier) SendDigest(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	if _, err := n.send(func() (*http.Request, error) { return n.digestRequest(jobs) }); err != nil {
+		return make([]bool, len(jobs)), fmt.Errorf("ntfy digest: %w", err)
+	}
+	n.logger.Info("ntfy digest sent", "jobs", len(jobs))
+	return allDelivered(len(jobs)), nil
+}
+
+func (n *NtfyNotifier) sendMessage(j model.Job) error {
+	retried, err := n.send(func() (*http.Request, error) { return n.jobRequest(j) })
+	if err != nil {
+		return err
+	}
+	if retried {
+		n.logger.Info("ntfy message sent", "company", j.Company, "title", j.Title, "retried", true)
+		return nil
+	}
+	n.logger.Info("ntfy message sent", "company", j.Company, "title", j.Title)
+	return nil
+}
+
+// send posts the request built by newReq, retrying once on 429 with a fresh
+// request (the body reader can't be reused), and reports whether it retried.
+func (n *NtfyNotifier) send(newReq func() (*http.Request, error)) (retried bool, err error) {
+	resp, err := n.do(newReq)
+	if err != nil {
+		return false, fmt.Errorf("post to ntfy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		secs, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if secs <= 0 {
+			secs = 1
+		}
+		n.logger.Warn("ntfy rate limited, retrying", "retry_after_secs", secs)
+		time.Sleep(time.Duration(secs) * time.Second)
+
+		resp2, err := n.do(newReq)
+		if err != nil {
+			return true, fmt.Errorf("post to ntfy (retry): %w", err)
+		}
+		defer resp2.Body.Close()
+
+		if resp2.StatusCode != http.StatusOK {
+			return true, fmt.Errorf("ntfy returned %d on retry", resp2.StatusCode)
+		}
+		return true, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("ntfy returned %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+func (n *NtfyNotifier) do(newReq func() (*http.Request, error)) (*http.Response, error) {
+	req, err := newReq()
+	if err != nil {
+		return nil, err
+	}
+	return n.httpClient.Do(req)
+}
+
+// jobRequest builds the request publishing j.
+func (n *NtfyNotifier) jobRequest(j model.Job) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodPost, n.topicURL, strings.NewReader(ntfyBody(j)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Click", j.URL)
+	if j.PostingStatus == model.PostingClosed {
+		req.Header.Set("Title", "Closed: "+j.Title)
+		req.Header.Set("Priority", "default")
+		req.Header.Set("Tags", "lock,"+strings.ToLower(j.Source))
+		return req, nil
+	}
+	req.Header.Set("Title", j.Title)
+	req.Header.Set("Priority", "high")
+	req.Header.Set("Tags", "rocket,"+strings.ToLower(j.Source))
+	return req, nil
+}
+
+// digestRequest builds the request publishing a digest of jobs, one job and
+// its link per entry. A digest has many links, so it sets no Click action.
+func (n *NtfyNotifier) digestRequest(jobs []model.Job) (*http.Request, error) {
+	var body strings.Builder
+	for i, j := range jobs {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+		fmt.Fprintf(&body, "• %s\n  %s", digestEntry(j), j.URL)
+	}
+	req, err := http.NewRequest(http.MethodPost, n.topicURL, strings.NewReader(body.String()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Title", digestHeadline(len(jobs)))
+	req.Header.Set("Priority", "default")
+	req.Header.Set("Tags", "mailbox_with_mail")
+	return req, nil
+}
+
+// ntfyBody renders the plain-text message body: company and location, plus
+// the AI role summary when available.
+func ntfyBody(j model.Job) string {
+	body := capitalize(j.Company)
+	if j.Location != "" {
+		body += " · " + j.Location
+	}
+	if j.Insights != nil && j.Insights.RoleType != "" {
+		role := j.Insights.RoleType
+		if j.Insights.Seniority != "" {
+			role += " (" + j.Insights.Seniority + ")"
+		}
+		body += "\n" + role
+	}
+	return body
+}
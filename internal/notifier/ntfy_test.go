@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestNtfyNotifier_HeadersAndBody(t *testing.T) {
+	var got *http.Request
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got, body = r, string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewNtfyNotifier(srv.URL+"/job-alerts", srv.Client(), discardLogger())
+	if _, err := n.Notify([]model.Job{sampleJob("Backend Engineer", "acme")}); err != nil {
+		t.Fatalf("Notify() = %v, want nil", err)
+	}
+
+	if got.Method != http.MethodPost || got.URL.Path != "/job-alerts" {
+		t.Errorf("request = %s %s, want POST /job-alerts", got.Method, got.URL.Path)
+	}
+	headers := map[string]string{
+		"Title":    "Backend Engineer",
+		"Click":    "https://example.com/apply",
+		"Priority": "high",
+		"Tags":     "rocket,greenhouse",
+	}
+	for k, want := range headers {
+		if v := got.Header.Get(k); v != want {
+			t.Errorf("header %s = %q, want %q", k, v, want)
+		}
+	}
+	if body != "Acme · Remote, US" {
+		t.Errorf("body = %q, want %q", body, "Acme · Remote, US")
+	}
+}
+
+func TestNtfyNotifier_RetriesOnceOn429(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewNtfyNotifier(srv.URL, srv.Client(), discardLogger())
+	if _, err := n.Notify([]model.Job{sampleJob("Engineer", "Acme")}); err != nil {
+		t.Fatalf("Notify() = %v, want nil after retry", err)
+	}
+	if c := calls.Load(); c != 2 {
+		t.Errorf("expected 2 calls (429 + retry), got %d", c)
+	}
+}
@@ -0,0 +1,167 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure PagerDutyNotifier implements model.Notifier.
+var _ model.Notifier = (*PagerDutyNotifier)(nil)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// DefaultPagerDutySeverity is the event severity unless set otherwise. It is
+// high enough that default urgency rules page rather than suppress.
+const DefaultPagerDutySeverity = "warning"
+
+// PagerDutyNotifier triggers a PagerDuty Events API v2 alert for each
+// high-priority job. Jobs not matched by the priority filter are skipped, so
+// routine matches never page anyone.
+type PagerDutyNotifier struct {
+	routingKey string
+	eventsURL  string
+	severity   string
+	priority   model.JobFilter
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewPagerDutyNotifier returns a notifier that pages via the integration's
+// routingKey for jobs matched by priority. A nil priority filter pages for every job.
+func NewPagerDutyNotifier(routingKey string, priority model.JobFilter, httpClient *http.Client, logger *slog.Logger) *PagerDutyNotifier {
+	return &PagerDutyNotifier{
+		routingKey: routingKey,
+		eventsURL:  pagerDutyEventsURL,
+		severity:   DefaultPagerDutySeverity,
+		priority:   priority,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// SetSeverity sets the payload severity of triggered events: "critical",
+// "error", "warning" or "info". Empty restores DefaultPagerDutySeverity.
+func (p *PagerDutyNotifier) SetSeverity(severity string) {
+	if severity == "" {
+		severity = DefaultPagerDutySeverity
+	}
+	p.severity = severity
+}
+
+// Notify triggers one event per high-priority job. delivered[i] reports
+// whether jobs[i] was handled: skipped non-priority jobs count as delivered,
+// since there is nothing to resend.
+// Returns an error only if ALL triggered events fail. Individual failures are logged.
+func (p *PagerDutyNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	delivered := make([]bool, len(jobs))
+	urgent := 0
+	failures := 0
+	for i, j := range jobs {
+		if p.priority != nil && !p.priority.Match(j) {
+			delivered[i] = true
+			continue
+		}
+		urgent++
+		if err := p.sendEvent(j); err != nil {
+			p.logger.Error("pagerduty event failed", "company", j.Company, "title", j.Title, "error", err)
+			failures++
+			continue
+		}
+		delivered[i] = true
+	}
+	if urgent == 0 {
+		return delivered, nil
+	}
+
+	sent := urgent - failures
+	if failures == urgent {
+		return delivered, fmt.Errorf("all %d pagerduty events failed", failures)
+	}
+	p.logger.Info("pagerduty events complete", "sent", sent, "failed", failures, "skipped", len(jobs)-urgent)
+	return delivered, nil
+}
+
+// Events API v2 payload types.
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+	Links       []pagerDutyLink  `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// pagerDutyDedupKey derives a stable dedup key from the job, so PagerDuty
+// folds repeat triggers for the same posting into one incident.
+func pagerDutyDedupKey(j model.Job) string {
+	return fmt.Sprintf("firstin-%s-%s", j.Source, j.ID)
+}
+
+func buildPagerDutyEvent(routingKey, severity string, j model.Job) pagerDutyEvent {
+	company := capitalize(j.Company)
+	details := map[string]string{
+		"company":  company,
+		"title":    j.Title,
+		"location": j.Location,
+		"url":      j.URL,
+	}
+	if j.PostedAt != nil {
+		details["posted_at"] = j.PostedAt.UTC().Format("2006-01-02 15:04 MST")
+	}
+
+	// A closed role resolves the incident its trigger opened.
+	action, summary := "trigger", "New role at %s: %s (%s)"
+	if j.PostingStatus == model.PostingClosed {
+		action, summary = "resolve", "Role closed at %s: %s (%s)"
+	}
+	return pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: action,
+		DedupKey:    pagerDutyDedupKey(j),
+		Payload: pagerDutyPayload{
+			Summary:       fmt.Sprintf(summary, company, j.Title, j.Location),
+			Source:        "firstin",
+			Severity:      severity,
+			Component:     j.Source,
+			CustomDetails: details,
+		},
+		Links: []pagerDutyLink{{Href: j.URL, Text: "Apply"}},
+	}
+}
+
+func (p *PagerDutyNotifier) sendEvent(j model.Job) error {
+	body, err := json.Marshal(buildPagerDutyEvent(p.routingKey, p.severity, j))
+	if err != nil {
+		return fmt.Errorf("marshal pagerduty event: %w", err)
+	}
+
+	resp, err := p.httpClient.Post(p.eventsURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("post to pagerduty: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("pagerduty returned %d", resp.StatusCode)
+	}
+	p.logger.Info("pagerduty event sent", "company", j.Company, "title", j.Title, "dedup_key", pagerDutyDedupKey(j))
+	return nil
+}
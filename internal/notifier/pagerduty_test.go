@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// titleContains is a minimal priority filter for tests.
+type titleContains string
+
+func (f titleContains) Match(j model.Job) bool { return strings.Contains(j.Title, string(f)) }
+
+func TestPagerDutyNotifier_TriggersPriorityJobsOnly(t *testing.T) {
+	var events []pagerDutyEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var ev pagerDutyEvent
+		if err := json.Unmarshal(raw, &ev); err != nil {
+			t.Errorf("unmarshal event: %v", err)
+		}
+		events = append(events, ev)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	n := NewPagerDutyNotifier("rk-123", titleContains("Staff"), srv.Client(), discardLogger())
+	n.eventsURL = srv.URL
+
+	staff := sampleJob("Staff Engineer", "acme")
+	staff.ID = "gh-42"
+	jobs := []model.Job{sampleJob("Backend Engineer", "acme"), staff}
+	if _, err := n.Notify(jobs); err != nil {
+		t.Fatalf("Notify() = %v, want nil", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event (priority job only), got %d", len(events))
+	}
+	ev := events[0]
+	if ev.RoutingKey != "rk-123" || ev.EventAction != "trigger" {
+		t.Errorf("routing_key/event_action = %q/%q", ev.RoutingKey, ev.EventAction)
+	}
+	if ev.DedupKey != "firstin-greenhouse-gh-42" {
+		t.Errorf("dedup_key = %q, want firstin-greenhouse-gh-42", ev.DedupKey)
+	}
+	if !strings.Contains(ev.Payload.Summary, "Acme") || !strings.Contains(ev.Payload.Summary, "Staff Engineer") {
+		t.Errorf("summary = %q", ev.Payload.Summary)
+	}
+	if ev.Payload.Source != "firstin" || ev.Payload.Severity != DefaultPagerDutySeverity {
+		t.Errorf("payload source/severity = %q/%q", ev.Payload.Source, ev.Payload.Severity)
+	}
+	if len(ev.Links) != 1 || ev.Links[0].Href != "https://example.com/apply" {
+		t.Errorf("links = %+v", ev.Links)
+	}
+}
+
+func TestPagerDutyNotifier_NoPriorityMatchesSendsNothing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	}))
+	defer srv.Close()
+
+	n := NewPagerDutyNotifier("rk-123", titleContains("Principal"), srv.Client(), discardLogger())
+	n.eventsURL = srv.URL
+
+	if _, err := n.Notify([]model.Job{sampleJob("Backend Engineer", "acme")}); err != nil {
+		t.Fatalf("Notify() = %v, want nil", err)
+	}
+}
+
+func TestPagerDutyNotifier_SetSeverity(t *testing.T) {
+	n := NewPagerDutyNotifier("rk-123", nil, http.DefaultClient, discardLogger())
+	n.SetSeverity("critical")
+	if ev := buildPagerDutyEvent(n.routingKey, n.severity, sampleJob("Staff Engineer", "acme")); ev.Payload.Severity != "critical" {
+		t.Errorf("severity = %q, want critical", ev.Payload.Severity)
+	}
+	n.SetSeverity("")
+	if n.severity != DefaultPagerDutySeverity {
+		t.Errorf("severity = %q, want the default %q after SetSeverity(\"\")", n.severity, DefaultPagerDutySeverity)
+	}
+}
@@ -0,0 +1,296 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure QuietHoursNotifier implements model.Notifier and model.DigestSender.
+var (
+	_ model.Notifier     = (*QuietHoursNotifier)(nil)
+	_ model.DigestSender = (*QuietHoursNotifier)(nil)
+)
+
+// QuietHours is a daily window, as offsets from midnight in Location. A
+// window whose End is before its Start wraps past midnight (e.g. 22:00–07:00).
+type QuietHours struct {
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+// Contains reports whether t falls inside the window. Start is inclusive,
+// End exclusive.
+func (q QuietHours) Contains(t time.Time) bool {
+	offset := sinceMidnight(t.In(q.Location))
+	if q.Start <= q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// NextEnd returns the first end of the window after t.
+func (q QuietHours) NextEnd(t time.Time) time.Time {
+	local := t.In(q.Location)
+	y, m, d := local.Date()
+	end := time.Date(y, m, d, 0, 0, 0, 0, q.Location).Add(q.End)
+	if !end.After(local) {
+		end = time.Date(y, m, d+1, 0, 0, 0, 0, q.Location).Add(q.End)
+	}
+	return end
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+}
+
+// DeadLetterSink records jobs whose notification failed for good (see
+// poller.DeadLetter).
+type DeadLetterSink interface {
+	Write(company string, jobs []model.Job, notifyErr error) error
+}
+
+// QuietHoursNotifier wraps another notifier and holds jobs notified during
+// quiet hours, sending them in one batch when the window ends. Digests sent
+// during quiet hours are held the same way and still go out as digests.
+//
+// Held jobs are reported as delivered so the poller marks them seen once,
+// when they are queued, rather than offering them again every poll. Like
+// DigestNotifier's buffer, the queue is written to a JSON file on every
+// change, so a restart sends it instead of dropping it. Jobs a flush fails
+// to deliver go to the dead-letter sink, if one is set.
+type QuietHoursNotifier struct {
+	next       model.Notifier
+	hours      QuietHours
+	path       string
+	deadLetter DeadLetterSink // optional: records jobs a flush failed to deliver
+	logger     *slog.Logger
+	now        func() time.Time // injectable for tests
+
+	mu      sync.Mutex
+	held    heldQueue
+	sending heldQueue // taken by a Flush in progress; still saved until it ends
+	timer   *time.Timer
+}
+
+// heldQueue is what quiet hours are holding, as saved in the queue file.
+type heldQueue struct {
+	Jobs    []model.Job   `json:"jobs"`
+	Digests [][]model.Job `json:"digests,omitempty"` // held SendDigest batches, sent whole
+}
+
+func (h heldQueue) empty() bool {
+	return len(h.Jobs) == 0 && len(h.Digests) == 0
+}
+
+func (h heldQueue) concat(o heldQueue) heldQueue {
+	return heldQueue{Jobs: slices.Concat(h.Jobs, o.Jobs), Digests: slices.Concat(h.Digests, o.Digests)}
+}
+
+// NewQuietHoursNotifier returns a notifier that forwards to next outside
+// hours and holds jobs inside them, keeping the held queue in the file at
+// path. A queue left there by a previous run is sent when the window ends,
+// or straight away outside it.
+func NewQuietHoursNotifier(next model.Notifier, hours QuietHours, path string, logger *slog.Logger) (*QuietHoursNotifier, error) {
+	q := &QuietHoursNotifier{
+		next:   next,
+		hours:  hours,
+		path:   path,
+		logger: logger,
+		now:    time.Now,
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("reading quiet hours file: %w", err)
+	case len(data) > 0:
+		if err := json.Unmarshal(data, &q.held); err != nil {
+			return nil, fmt.Errorf("parsing quiet hours file %s: %w", path, err)
+		}
+	}
+	if !q.held.empty() {
+		logger.Info("notifications held from previous run", "jobs", len(q.held.Jobs), "digests", len(q.held.Digests))
+		q.mu.Lock()
+		q.armLocked(q.now())
+		q.mu.Unlock()
+	}
+	return q, nil
+}
+
+// SetDeadLetter sends jobs a flush fails to deliver to sink. They are
+// already marked seen, so without one they are only logged.
+func (q *QuietHoursNotifier) SetDeadLetter(sink DeadLetterSink) {
+	q.deadLetter = sink
+}
+
+// Notify forwards jobs to the wrapped notifier, first flushing anything still
+// held. During quiet hours it queues them instead, arms a timer for the end
+// of the window and reports every job as delivered. If the queue cannot be
+// saved, nothing is held and the error is returned, so the poller retries
+// the jobs later.
+func (q *QuietHoursNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	now := q.now()
+	if !q.hours.Contains(now) {
+		q.Flush()
+		return q.next.Notify(jobs)
+	}
+
+	q.mu.Lock()
+	held := heldQueue{Jobs: append(slices.Clip(q.held.Jobs), jobs...), Digests: q.held.Digests}
+	if err := q.holdLocked(held, now); err != nil {
+		q.mu.Unlock()
+		return nil, err
+	}
+	total := len(q.held.Jobs)
+	q.mu.Unlock()
+
+	q.logger.Info("notifications held", "jobs", len(jobs), "total_held", total)
+	return allDelivered(len(jobs)), nil
+}
+
+// SendDigest forwards a digest to the wrapped notifier outside hours, first
+// flushing anything held. During quiet hours it holds the digest until the
+// window ends and reports every job as delivered.
+func (q *QuietHoursNotifier) SendDigest(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	now := q.now()
+	if !q.hours.Contains(now) {
+		q.Flush()
+		return sendDigest(q.next, jobs)
+	}
+
+	q.mu.Lock()
+	held := heldQueue{Jobs: q.held.Jobs, Digests: append(slices.Clip(q.held.Digests), jobs)}
+	if err := q.holdLocked(held, now); err != nil {
+		q.mu.Unlock()
+		return nil, err
+	}
+	q.mu.Unlock()
+
+	q.logger.Info("digest held", "jobs", len(jobs))
+	return allDelivered(len(jobs)), nil
+}
+
+// holdLocked saves held as the queue and arms the timer. q.mu must be held.
+func (q *QuietHoursNotifier) holdLocked(held heldQueue, now time.Time) error {
+	if err := q.save(q.sending.concat(held)); err != nil {
+		return err
+	}
+	q.held = held
+	q.armLocked(now)
+	return nil
+}
+
+// armLocked starts the timer for the end of the window, or for right away
+// when outside it, unless it is running. q.mu must be held.
+func (q *QuietHoursNotifier) armLocked(now time.Time) {
+	if q.timer != nil {
+		return
+	}
+	if !q.hours.Contains(now) {
+		q.timer = time.AfterFunc(0, q.Flush)
+		return
+	}
+	end := q.hours.NextEnd(now)
+	q.timer = time.AfterFunc(end.Sub(now), q.Flush)
+	q.logger.Info("quiet hours, holding notifications", "until", end.Format(time.Kitchen))
+}
+
+// Flush sends every held job to the wrapped notifier, then every held
+// digest. The jobs are already marked seen, so those not delivered go to the
+// dead-letter sink rather than being retried. It does nothing while another
+// Flush is sending.
+func (q *QuietHoursNotifier) Flush() {
+	q.mu.Lock()
+	if !q.sending.empty() || q.held.empty() {
+		q.mu.Unlock()
+		return
+	}
+	held := q.held
+	q.sending, q.held = held, heldQueue{}
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	q.mu.Unlock()
+
+	if len(held.Jobs) > 0 {
+		q.logger.Info("quiet hours over, sending held notifications", "jobs", len(held.Jobs))
+		delivered, err := q.next.Notify(held.Jobs)
+		q.settle(held.Jobs, delivered, err)
+	}
+	for _, d := range held.Digests {
+		q.logger.Info("quiet hours over, sending held digest", "jobs", len(d))
+		delivered, err := sendDigest(q.next, d)
+		q.settle(d, delivered, err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.sending = heldQueue{}
+	// Jobs held while the flush was sending stay queued.
+	if err := q.save(q.held); err != nil {
+		q.logger.Error("saving quiet hours file failed", "path", q.path, "error", err)
+	}
+	if !q.held.empty() {
+		q.armLocked(q.now())
+	}
+}
+
+// settle logs the jobs of a held batch that were not delivered and writes
+// them to the dead-letter sink, per company.
+func (q *QuietHoursNotifier) settle(jobs []model.Job, delivered []bool, err error) {
+	var failed []model.Job
+	if err != nil {
+		q.logger.Error("held notifications failed", "jobs", len(jobs), "error", err)
+		failed = jobs
+	} else {
+		for i, ok := range delivered {
+			if !ok {
+				q.logger.Warn("held notification not delivered", "company", jobs[i].Company, "title", jobs[i].Title)
+				failed = append(failed, jobs[i])
+			}
+		}
+	}
+	if len(failed) == 0 || q.deadLetter == nil {
+		return
+	}
+	for _, group := range groupByCompany(failed) {
+		batch := make([]model.Job, len(group))
+		for k, i := range group {
+			batch[k] = failed[i]
+		}
+		if dlErr := q.deadLetter.Write(batch[0].Company, batch, err); dlErr != nil {
+			q.logger.Error("dead-letter write failed", "company", batch[0].Company, "jobs", len(batch), "error", dlErr)
+		}
+	}
+}
+
+// save writes the held queue to the quiet hours file, replacing it
+// atomically.
+func (q *QuietHoursNotifier) save(held heldQueue) error {
+	if held.Jobs == nil {
+		held.Jobs = []model.Job{}
+	}
+	if err := writeJSONFile(q.path, held); err != nil {
+		return fmt.Errorf("writing quiet hours file: %w", err)
+	}
+	return nil
+}
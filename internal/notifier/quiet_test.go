@@ -0,0 +1,270 @@
+package notifier
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// recordingNotifier records every batch it is asked to send.
+type recordingNotifier struct {
+	mu      sync.Mutex
+	batches [][]model.Job
+}
+
+func (r *recordingNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, jobs)
+	delivered := make([]bool, len(jobs))
+	for i := range delivered {
+		delivered[i] = true
+	}
+	return delivered, nil
+}
+
+func (r *recordingNotifier) sent() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, b := range r.batches {
+		n += len(b)
+	}
+	return n
+}
+
+// recordingDigestSender records digests separately from Notify batches.
+type recordingDigestSender struct {
+	recordingNotifier
+	digests [][]model.Job
+}
+
+func (r *recordingDigestSender) SendDigest(jobs []model.Job) ([]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.digests = append(r.digests, jobs)
+	return allDelivered(len(jobs)), nil
+}
+
+// newTestQuietHours returns a quiet hours notifier keeping its queue at path.
+func newTestQuietHours(t *testing.T, next model.Notifier, hours QuietHours, path string) *QuietHoursNotifier {
+	t.Helper()
+	q, err := NewQuietHoursNotifier(next, hours, path, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewQuietHoursNotifier: %v", err)
+	}
+	return q
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2025, 3, 10, h, m, 0, 0, time.UTC) }
+	overnight := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, Location: time.UTC}
+	daytime := QuietHours{Start: 12 * time.Hour, End: 13 * time.Hour, Location: time.UTC}
+
+	tests := []struct {
+		name  string
+		hours QuietHours
+		t     time.Time
+		want  bool
+	}{
+		{"overnight before start", overnight, at(21, 59), false},
+		{"overnight at start", overnight, at(22, 0), true},
+		{"overnight after midnight", overnight, at(3, 0), true},
+		{"overnight at end", overnight, at(7, 0), false},
+		{"daytime inside", daytime, at(12, 30), true},
+		{"daytime outside", daytime, at(13, 30), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hours.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.t.Format(time.Kitchen), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHours_NextEnd(t *testing.T) {
+	hours := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, Location: time.UTC}
+
+	before := time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC)
+	if got, want := hours.NextEnd(before), time.Date(2025, 3, 11, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("NextEnd(23:00) = %v, want %v", got, want)
+	}
+	early := time.Date(2025, 3, 10, 3, 0, 0, 0, time.UTC)
+	if got, want := hours.NextEnd(early), time.Date(2025, 3, 10, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("NextEnd(03:00) = %v, want %v", got, want)
+	}
+}
+
+func TestQuietHoursNotifier_HoldsThenFlushes(t *testing.T) {
+	next := &recordingNotifier{}
+	// Quiet hours end 50ms after the fake clock's "now".
+	now := time.Date(2025, 3, 10, 6, 59, 59, 950_000_000, time.UTC)
+	hours := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, Location: time.UTC}
+	q := newTestQuietHours(t, next, hours, filepath.Join(t.TempDir(), "quiet.json"))
+	q.now = func() time.Time { return now }
+
+	jobs := []model.Job{{ID: "1", Company: "acme"}, {ID: "2", Company: "acme"}}
+	delivered, err := q.Notify(jobs)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	for i, ok := range delivered {
+		if !ok {
+			t.Errorf("delivered[%d] = false, want held jobs reported delivered", i)
+		}
+	}
+	if _, err := q.Notify([]model.Job{{ID: "3", Company: "beta"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got := next.sent(); got != 0 {
+		t.Fatalf("sent %d jobs during quiet hours, want 0", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for next.sent() < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := next.sent(); got != 3 {
+		t.Fatalf("sent %d jobs after quiet hours, want 3", got)
+	}
+	if len(next.batches) != 1 {
+		t.Errorf("got %d batches, want held jobs flushed in 1", len(next.batches))
+	}
+}
+
+func TestQuietHoursNotifier_OutsideHoursFlushesHeldFirst(t *testing.T) {
+	next := &recordingNotifier{}
+	now := time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC)
+	hours := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, Location: time.UTC}
+	q := newTestQuietHours(t, next, hours, filepath.Join(t.TempDir(), "quiet.json"))
+	q.now = func() time.Time { return now }
+
+	if _, err := q.Notify([]model.Job{{ID: "held"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	// The clock jumps past the end before the timer fires.
+	now = time.Date(2025, 3, 11, 8, 0, 0, 0, time.UTC)
+	if _, err := q.Notify([]model.Job{{ID: "live"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	if len(next.batches) != 2 {
+		t.Fatalf("got %d batches, want 2", len(next.batches))
+	}
+	if next.batches[0][0].ID != "held" || next.batches[1][0].ID != "live" {
+		t.Errorf("batches = %v, want held job sent before live job", next.batches)
+	}
+
+	// The stopped timer must not send the held job a second time.
+	q.Flush()
+	if got := next.sent(); got != 2 {
+		t.Errorf("sent %d jobs, want 2", got)
+	}
+}
+
+func TestQuietHoursNotifier_HeldDigestSentAsDigest(t *testing.T) {
+	next := &recordingDigestSender{}
+	now := time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC)
+	hours := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, Location: time.UTC}
+	q := newTestQuietHours(t, next, hours, filepath.Join(t.TempDir(), "quiet.json"))
+	q.now = func() time.Time { return now }
+
+	if _, err := q.SendDigest([]model.Job{{ID: "1"}, {ID: "2"}}); err != nil {
+		t.Fatalf("SendDigest: %v", err)
+	}
+	if len(next.digests) != 0 {
+		t.Fatalf("sent %d digests during quiet hours, want 0", len(next.digests))
+	}
+	q.Flush()
+
+	if len(next.digests) != 1 || len(next.digests[0]) != 2 {
+		t.Errorf("digests = %v, want the held digest sent whole", next.digests)
+	}
+	if len(next.batches) != 0 {
+		t.Errorf("batches = %v, want no per-job notifications", next.batches)
+	}
+}
+
+func TestQuietHoursNotifier_HeldJobsSurviveRestart(t *testing.T) {
+	hours := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, Location: time.UTC}
+	path := filepath.Join(t.TempDir(), "quiet.json")
+
+	first := newTestQuietHours(t, &recordingNotifier{}, hours, path)
+	first.now = func() time.Time { return time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC) }
+	if _, err := first.Notify([]model.Job{{ID: "1", Title: "Engineer"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if _, err := first.SendDigest([]model.Job{{ID: "2"}, {ID: "3"}}); err != nil {
+		t.Fatalf("SendDigest: %v", err)
+	}
+	first.timer.Stop()
+
+	// Restarted outside quiet hours, the queue left by the first run is sent
+	// straight away.
+	next := &recordingDigestSender{}
+	second := newTestQuietHours(t, next, hours, path)
+	flushed := func() bool {
+		second.mu.Lock()
+		defer second.mu.Unlock()
+		return second.held.empty() && second.sending.empty()
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !flushed() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	next.mu.Lock()
+	defer next.mu.Unlock()
+	if len(next.batches) != 1 || next.batches[0][0].Title != "Engineer" {
+		t.Errorf("batches = %v, want the job held before the restart", next.batches)
+	}
+	if len(next.digests) != 1 || len(next.digests[0]) != 2 {
+		t.Errorf("digests = %v, want the digest held before the restart", next.digests)
+	}
+}
+
+// failingNotifier fails every delivery.
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	return make([]bool, len(jobs)), errors.New("webhook returned 500")
+}
+
+// recordingDeadLetter records what it is asked to write, by company.
+type recordingDeadLetter struct {
+	jobs map[string][]model.Job
+}
+
+func (r *recordingDeadLetter) Write(company string, jobs []model.Job, _ error) error {
+	r.jobs[company] = append(r.jobs[company], jobs...)
+	return nil
+}
+
+func TestQuietHoursNotifier_FailedFlushDeadLettered(t *testing.T) {
+	hours := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, Location: time.UTC}
+	path := filepath.Join(t.TempDir(), "quiet.json")
+	q := newTestQuietHours(t, failingNotifier{}, hours, path)
+	q.now = func() time.Time { return time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC) }
+	dl := &recordingDeadLetter{jobs: make(map[string][]model.Job)}
+	q.SetDeadLetter(dl)
+
+	if _, err := q.Notify([]model.Job{{ID: "1", Company: "acme"}, {ID: "2", Company: "beta"}}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	q.Flush()
+
+	if len(dl.jobs["acme"]) != 1 || len(dl.jobs["beta"]) != 1 {
+		t.Errorf("dead-lettered %v, want each company's failed job", dl.jobs)
+	}
+	// The queue file no longer holds the flushed jobs.
+	reloaded := newTestQuietHours(t, failingNotifier{}, hours, path)
+	if !reloaded.held.empty() {
+		t.Errorf("held after flush = %+v, want nothing", reloaded.held)
+	}
+}
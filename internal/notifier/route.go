@@ -0,0 +1,93 @@
+package notifier
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure RoleRouter implements model.Notifier and model.DigestSender.
+var (
+	_ model.Notifier     = (*RoleRouter)(nil)
+	_ model.DigestSender = (*RoleRouter)(nil)
+)
+
+// RoleRouter sends each job to the notifier routed for its AI role type
+// (Job.Insights.RoleType, matched case-insensitively). Jobs without insights
+// or with an unrouted role go to the default notifier.
+type RoleRouter struct {
+	def    model.Notifier
+	routes map[string]model.Notifier // keyed by lowercased role type
+}
+
+// NewRoleRouter returns a router over def and routes, keyed by role type.
+func NewRoleRouter(def model.Notifier, routes map[string]model.Notifier) *RoleRouter {
+	r := &RoleRouter{def: def, routes: make(map[string]model.Notifier, len(routes))}
+	for role, n := range routes {
+		r.routes[strings.ToLower(strings.TrimSpace(role))] = n
+	}
+	return r
+}
+
+func (r *RoleRouter) route(j model.Job) model.Notifier {
+	if j.Insights != nil {
+		if n, ok := r.routes[strings.ToLower(strings.TrimSpace(j.Insights.RoleType))]; ok {
+			return n
+		}
+	}
+	return r.def
+}
+
+// Notify splits jobs by destination and sends each group in one call, keeping
+// the jobs' order within a group. It returns an error only when every
+// delivery failed.
+func (r *RoleRouter) Notify(jobs []model.Job) ([]bool, error) {
+	return r.dispatch(jobs, func(n model.Notifier, batch []model.Job) ([]bool, error) {
+		return n.Notify(batch)
+	})
+}
+
+// SendDigest splits jobs by destination like Notify and sends each group as
+// its own digest.
+func (r *RoleRouter) SendDigest(jobs []model.Job) ([]bool, error) {
+	return r.dispatch(jobs, sendDigest)
+}
+
+// dispatch groups jobs by destination and hands each group to send.
+func (r *RoleRouter) dispatch(jobs []model.Job, send func(model.Notifier, []model.Job) ([]bool, error)) ([]bool, error) {
+	var order []model.Notifier
+	groups := make(map[model.Notifier][]int)
+	for i, j := range jobs {
+		n := r.route(j)
+		if _, ok := groups[n]; !ok {
+			order = append(order, n)
+		}
+		groups[n] = append(groups[n], i)
+	}
+
+	delivered := make([]bool, len(jobs))
+	anyDelivered := false
+	var errs []error
+	for _, n := range order {
+		idx := groups[n]
+		batch := make([]model.Job, len(idx))
+		for k, i := range idx {
+			batch[k] = jobs[i]
+		}
+		ok, err := send(n, batch)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for k, i := range idx {
+			if k < len(ok) && ok[k] {
+				delivered[i] = true
+				anyDelivered = true
+			}
+		}
+	}
+	if !anyDelivered && len(errs) > 0 {
+		return delivered, errors.Join(errs...)
+	}
+	return delivered, nil
+}
@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestRoleRouter_RoutesByRoleType(t *testing.T) {
+	def, ml, backend := &recordingNotifier{}, &recordingNotifier{}, &recordingNotifier{}
+	r := NewRoleRouter(def, map[string]model.Notifier{"AI/ML": ml, "backend": backend})
+
+	jobs := []model.Job{
+		{ID: "ml", Insights: &model.JobInsights{RoleType: "ai/ml"}},
+		{ID: "be", Insights: &model.JobInsights{RoleType: "Backend"}},
+		{ID: "infra", Insights: &model.JobInsights{RoleType: "infra"}},
+		{ID: "none"},
+	}
+	delivered, err := r.Notify(jobs)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	for i, ok := range delivered {
+		if !ok {
+			t.Errorf("delivered[%d] = false, want true", i)
+		}
+	}
+
+	ids := func(n *recordingNotifier) []string {
+		var out []string
+		for _, b := range n.batches {
+			for _, j := range b {
+				out = append(out, j.ID)
+			}
+		}
+		return out
+	}
+	tests := []struct {
+		name string
+		n    *recordingNotifier
+		want []string
+	}{
+		{"AI/ML route", ml, []string{"ml"}},
+		{"backend route", backend, []string{"be"}},
+		{"default", def, []string{"infra", "none"}},
+	}
+	for _, tt := range tests {
+		if got := ids(tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("%s got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
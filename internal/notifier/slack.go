@@ -8,19 +8,32 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
 )
 
-// Ensure SlackNotifier implements model.Notifier.
-var _ model.Notifier = (*SlackNotifier)(nil)
+// Ensure SlackNotifier implements model.Notifier and model.DigestSender.
+var (
+	_ model.Notifier     = (*SlackNotifier)(nil)
+	_ model.DigestSender = (*SlackNotifier)(nil)
+)
+
+// DefaultMessageDelay is the pause between Slack messages when
+// notification.message_delay is unset.
+const DefaultMessageDelay = 500 * time.Millisecond
 
 // SlackNotifier sends job alerts to a Slack channel via Incoming Webhooks.
 type SlackNotifier struct {
 	webhookURL string
 	httpClient *http.Client
+	template   *template.Template // optional: renders the payload instead of the built-in blocks
 	logger     *slog.Logger
+
+	insightsFirst bool                // lead with the AI insights block (built-in layout only)
+	messageDelay  time.Duration       // pause between messages to stay under Slack's webhook rate limit
+	sleep         func(time.Duration) // time.Sleep; replaced in tests
 }
 
 // NewSlackNotifier returns a notifier that posts each job to Slack via webhook.
@@ -29,47 +42,111 @@ func NewSlackNotifier(webhookURL string, httpClient *http.Client, logger *slog.L
 		webhookURL: webhookURL,
 		httpClient: httpClient,
 		logger:     logger,
+
+		messageDelay: DefaultMessageDelay,
+		sleep:        time.Sleep,
+	}
+}
+
+// SetTemplate replaces the built-in Block Kit layout with tmpl (see
+// LoadSlackTemplate). A job whose template fails to render falls back to the
+// built-in layout, so a template bug never drops an alert.
+func (s *SlackNotifier) SetTemplate(tmpl *template.Template) {
+	s.template = tmpl
+}
+
+// SetInsightsFirst moves the AI insights block of the built-in layout up to
+// just below the header, ahead of company, location and pay. Jobs without
+// insights are unaffected; a custom template ignores it.
+func (s *SlackNotifier) SetInsightsFirst(enabled bool) {
+	s.insightsFirst = enabled
+}
+
+// SetMessageDelay sets the pause between messages. Zero sends them back to
+// back; negative values are ignored. A digest is one message and never waits.
+func (s *SlackNotifier) SetMessageDelay(d time.Duration) {
+	if d >= 0 {
+		s.messageDelay = d
 	}
 }
 
 // Notify sends each job as a separate Slack message using Block Kit.
-// Returns an error only if ALL messages fail. Individual failures are logged.
-func (s *SlackNotifier) Notify(jobs []model.Job) error {
+// delivered[i] reports whether jobs[i] was sent. Returns an error only if ALL
+// messages fail. Individual failures are logged.
+func (s *SlackNotifier) Notify(jobs []model.Job) ([]bool, error) {
 	if len(jobs) == 0 {
-		return nil
+		return nil, nil
 	}
 
+	delivered := make([]bool, len(jobs))
 	failures := 0
 	for i, j := range jobs {
-		if i > 0 {
-			time.Sleep(500 * time.Millisecond)
+		if i > 0 && s.messageDelay > 0 {
+			s.sleep(s.messageDelay)
 		}
 
 		if err := s.sendMessage(j); err != nil {
 			s.logger.Error("slack notification failed", "company", j.Company, "title", j.Title, "error", err)
 			failures++
+			continue
 		}
+		delivered[i] = true
 	}
 
 	sent := len(jobs) - failures
 	if failures == len(jobs) {
-		return fmt.Errorf("all %d slack notifications failed", failures)
+		return delivered, fmt.Errorf("all %d slack notifications failed", failures)
 	}
 	s.logger.Info("slack notifications complete", "sent", sent, "failed", failures)
-	return nil
+	return delivered, nil
+}
+
+// SendDigest posts jobs as one message listing each job as a link. It is a
+// single message, so the message delay does not apply.
+func (s *SlackNotifier) SendDigest(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	body, err := json.Marshal(slackMessage{Text: slackDigestText(jobs)})
+	if err != nil {
+		return make([]bool, len(jobs)), fmt.Errorf("marshal slack digest: %w", err)
+	}
+	if _, err := s.post(body); err != nil {
+		return make([]bool, len(jobs)), fmt.Errorf("slack digest: %w", err)
+	}
+	s.logger.Info("slack digest sent", "jobs", len(jobs))
+	return allDelivered(len(jobs)), nil
 }
 
 func (s *SlackNotifier) sendMessage(j model.Job) error {
-	payload := buildPayload(j)
+	blocks, err := renderBlocks(s.template, j, s.insightsFirst, s.logger)
+	if err != nil {
+		return fmt.Errorf("render slack blocks: %w", err)
+	}
 
-	body, err := json.Marshal(payload)
+	body, err := json.Marshal(slackMessage{Blocks: blocks})
 	if err != nil {
 		return fmt.Errorf("marshal slack payload: %w", err)
 	}
 
+	retried, err := s.post(body)
+	if err != nil {
+		return err
+	}
+	if retried {
+		s.logger.Info("slack message sent", "company", j.Company, "title", j.Title, "retried", true)
+		return nil
+	}
+	s.logger.Info("slack message sent", "company", j.Company, "title", j.Title)
+	return nil
+}
+
+// post sends body to the webhook, retrying once on 429, and reports whether
+// it retried.
+func (s *SlackNotifier) post(body []byte) (retried bool, err error) {
 	resp, err := s.httpClient.Post(s.webhookURL, "application/json", bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("post to slack: %w", err)
+		return false, fmt.Errorf("post to slack: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -84,22 +161,51 @@ func (s *SlackNotifier) sendMessage(j model.Job) error {
 
 		resp2, err := s.httpClient.Post(s.webhookURL, "application/json", bytes.NewReader(body))
 		if err != nil {
-			return fmt.Errorf("post to slack (retry): %w", err)
+			return true, fmt.Errorf("post to slack (retry): %w", err)
 		}
 		defer resp2.Body.Close()
 
 		if resp2.StatusCode != http.StatusOK {
-			return fmt.Errorf("slack returned %d on retry", resp2.StatusCode)
+			return true, fmt.Errorf("slack returned %d on retry", resp2.StatusCode)
 		}
-		s.logger.Info("slack message sent", "company", j.Company, "title", j.Title, "retried", true)
-		return nil
+		return true, nil
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("slack returned %d", resp.StatusCode)
+		return false, fmt.Errorf("slack returned %d", resp.StatusCode)
 	}
-	s.logger.Info("slack message sent", "company", j.Company, "title", j.Title)
-	return nil
+	return false, nil
+}
+
+// slackDigestText renders a digest as mrkdwn: a bold headline, then one
+// bullet per job linking to its posting.
+func slackDigestText(jobs []model.Job) string {
+	var b strings.Builder
+	b.WriteString("*" + digestHeadline(len(jobs)) + "*")
+	for _, j := range jobs {
+		fmt.Fprintf(&b, "\n• <%s|%s>", j.URL, escapeText(digestEntry(j)))
+	}
+	return b.String()
+}
+
+// slackMessage is the webhook request body: Blocks for a job alert, Text for
+// a digest. Unfurling is always off so the apply link doesn't expand into a
+// preview card under every alert.
+type slackMessage struct {
+	Text        string          `json:"text,omitempty"`
+	Blocks      json.RawMessage `json:"blocks,omitempty"`
+	UnfurlLinks bool            `json:"unfurl_links"`
+	UnfurlMedia bool            `json:"unfurl_media"`
+}
+
+// mrkdwnEscaper escapes the three characters Slack treats as control
+// sequences in message text; everything else is sent as-is.
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeText escapes s for a Slack text field, so a title like
+// "C++ & Systems <Core>" isn't read as a link or entity.
+func escapeText(s string) string {
+	return mrkdwnEscaper.Replace(s)
 }
 
 // Block Kit payload types.
@@ -127,22 +233,6 @@ type slackElement struct {
 	Style string    `json:"style"`
 }
 
-// SendTestMessage sends a dummy job notification to verify the integration works.
-func SendTestMessage(n model.Notifier) error {
-	now := time.Now()
-	testJob := model.Job{
-		ID:        "test-001",
-		Company:   "FirstIn Test",
-		Title:     "Test Notification — Integration Verified",
-		Location:  "Everywhere",
-		URL:       "https://www.ycombinator.com/jobs",
-		PostedAt:  &now,
-		FirstSeen: now,
-		Source:    "test",
-	}
-	return n.Notify([]model.Job{testJob})
-}
-
 func capitalize(s string) string {
 	if s == "" {
 		return s
@@ -150,57 +240,99 @@ func capitalize(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
-func buildPayload(j model.Job) slackPayload {
-	postedText := "Just detected"
-	if j.PostedAt != nil {
-		pst, err := time.LoadLocation("America/Los_Angeles")
-		if err == nil {
-			postedText = j.PostedAt.In(pst).Format(time.RFC1123)
-		} else {
-			postedText = j.PostedAt.Format(time.RFC1123)
+// postedText formats a job's posting time for display in Pacific time, or
+// "Just detected" when the ATS gave no timestamp.
+func postedText(j model.Job) string {
+	if j.PostedAt == nil {
+		return "Just detected"
+	}
+	pst, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		return j.PostedAt.Format(time.RFC1123)
+	}
+	return j.PostedAt.In(pst).Format(time.RFC1123)
+}
+
+// payText lists a job's structured pay ranges one per line, e.g.
+// "USD $120000 - $150000", or "" when the ATS gave none.
+func payText(j model.Job) string {
+	return strings.Join(payLines(j), "\n")
+}
+
+// payLines formats each of a job's structured pay ranges, titled ranges
+// suffixed with their title in parentheses.
+func payLines(j model.Job) []string {
+	if j.Detail == nil {
+		return nil
+	}
+	lines := make([]string, 0, len(j.Detail.PayRanges))
+	for _, pr := range j.Detail.PayRanges {
+		currency := pr.CurrencyType
+		if currency == "" {
+			currency = "USD"
+		}
+		line := fmt.Sprintf("%s $%.0f - $%.0f", currency, float64(pr.MinCents)/100, float64(pr.MaxCents)/100)
+		if pr.Title != "" {
+			line += " (" + pr.Title + ")"
 		}
+		lines = append(lines, line)
 	}
+	return lines
+}
 
-	company := capitalize(j.Company)
-	source := capitalize(j.Source)
+// buildPayload builds the built-in Block Kit layout for j: header, company
+// and location, posted and source, pay, tags, AI insights, then the apply
+// button. With insightsFirst the insights block follows the header instead.
+func buildPayload(j model.Job, insightsFirst bool) slackPayload {
+	company := escapeText(capitalize(j.Company))
+	source := escapeText(capitalize(j.Source))
+	title := escapeText(j.Title)
 
-	blocks := []slackBlock{
-		{
-			Type: "header",
-			Text: &slackText{Type: "plain_text", Text: "🚀 " + company + ": " + j.Title},
-		},
-		{
+	header := slackBlock{
+		Type: "header",
+		Text: &slackText{Type: "plain_text", Text: headlinePrefix(j) + company + ": " + title},
+	}
+	insights, hasInsights := insightsBlock(j)
+
+	blocks := []slackBlock{header}
+	if insightsFirst && hasInsights {
+		blocks = append(blocks, insights)
+	}
+	blocks = append(blocks,
+		slackBlock{
 			Type: "section",
 			Fields: []slackText{
 				{Type: "mrkdwn", Text: "*Company:*\n" + company},
-				{Type: "mrkdwn", Text: "*Location:*\n" + j.Location},
+				{Type: "mrkdwn", Text: "*Location:*\n" + escapeText(j.Location)},
 			},
 		},
-		{
+		slackBlock{
 			Type: "section",
 			Fields: []slackText{
-				{Type: "mrkdwn", Text: "*Posted:*\n" + postedText},
+				{Type: "mrkdwn", Text: "*Posted:*\n" + postedText(j)},
 				{Type: "mrkdwn", Text: "*Source:*\n" + source},
 			},
 		},
+	)
+
+	if pay := payText(j); pay != "" {
+		blocks = append(blocks, slackBlock{
+			Type:   "section",
+			Fields: []slackText{{Type: "mrkdwn", Text: "*Pay:*\n" + escapeText(pay)}},
+		})
 	}
 
-	if j.Insights != nil {
-		stack := strings.Join(j.Insights.TechStack, ", ")
-		insightsText := fmt.Sprintf("*Role:* %s   *Exp:* %s   *Stack:* %s\n• %s\n• %s\n• %s",
-			j.Insights.RoleType,
-			j.Insights.YearsExp,
-			stack,
-			j.Insights.KeyPoints[0],
-			j.Insights.KeyPoints[1],
-			j.Insights.KeyPoints[2],
-		)
+	if len(j.Tags) > 0 {
 		blocks = append(blocks, slackBlock{
-			Type: "section",
-			Text: &slackText{Type: "mrkdwn", Text: insightsText},
+			Type:   "section",
+			Fields: []slackText{{Type: "mrkdwn", Text: "*Tags:*\n" + escapeText(strings.Join(j.Tags, ", "))}},
 		})
 	}
 
+	if !insightsFirst && hasInsights {
+		blocks = append(blocks, insights)
+	}
+
 	blocks = append(blocks,
 		slackBlock{
 			Type: "actions",
@@ -218,3 +350,27 @@ func buildPayload(j model.Job) slackPayload {
 
 	return slackPayload{Blocks: blocks}
 }
+
+// insightsBlock renders j's AI insights as one section, one bullet per
+// non-empty key point, reporting false when the job has none.
+func insightsBlock(j model.Job) (slackBlock, bool) {
+	if j.Insights == nil {
+		return slackBlock{}, false
+	}
+	stack := strings.Join(j.Insights.TechStack, ", ")
+	role := j.Insights.RoleType
+	if j.Insights.Seniority != "" {
+		role += " (" + j.Insights.Seniority + ")"
+	}
+	text := fmt.Sprintf("*Role:* %s   *Exp:* %s   *Stack:* %s", role, j.Insights.YearsExp, stack)
+	for _, pt := range j.Insights.KeyPoints {
+		if pt = strings.TrimSpace(pt); pt != "" {
+			text += "\n• " + pt
+		}
+	}
+	insightsText := escapeText(text)
+	return slackBlock{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: insightsText},
+	}, true
+}
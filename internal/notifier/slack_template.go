@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// slackTemplateFuncs are available to notification.slack_template templates.
+var slackTemplateFuncs = template.FuncMap{
+	// json encodes a value as a JSON literal, so `"text": {{json .Title}}`
+	// stays valid whatever the title contains.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// escape applies Slack's &, <, > escaping for text fields; combine as
+	// {{json (escape .Title)}}.
+	"escape":     escapeText,
+	"capitalize": capitalize,
+	"posted":     postedText,
+}
+
+// LoadSlackTemplate parses a Slack payload template from path. The template
+// is executed with the model.Job as data and must produce a Slack message
+// body, i.e. a JSON object with a "blocks" array. Besides the text/template
+// builtins it may call json, escape, capitalize and posted (the job's posting
+// time as shown in the default layout).
+func LoadSlackTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read slack template: %w", err)
+	}
+	return ParseSlackTemplate(filepath.Base(path), string(data))
+}
+
+// ParseSlackTemplate parses a Slack payload template from text. See LoadSlackTemplate.
+func ParseSlackTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(slackTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse slack template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// executeSlackTemplate renders tmpl for j and returns the payload's blocks
+// verbatim, so templates may use any Block Kit element.
+func executeSlackTemplate(tmpl *template.Template, j model.Job) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, j); err != nil {
+		return nil, fmt.Errorf("execute slack template: %w", err)
+	}
+	var payload struct {
+		Blocks []json.RawMessage `json:"blocks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		return nil, fmt.Errorf("slack template output is not valid JSON: %w", err)
+	}
+	if len(payload.Blocks) == 0 {
+		return nil, fmt.Errorf("slack template produced no blocks")
+	}
+	return json.Marshal(payload.Blocks)
+}
+
+// renderBlocks returns the Block Kit blocks for j, rendered from tmpl or, when
+// tmpl is nil or fails to render, from the built-in layout (see buildPayload
+// for insightsFirst).
+func renderBlocks(tmpl *template.Template, j model.Job, insightsFirst bool, logger *slog.Logger) (json.RawMessage, error) {
+	if tmpl != nil {
+		blocks, err := executeSlackTemplate(tmpl, j)
+		if err == nil {
+			return blocks, nil
+		}
+		logger.Warn("slack template failed, using default layout", "company", j.Company, "title", j.Title, "error", err)
+	}
+	return json.Marshal(buildPayload(j, insightsFirst).Blocks)
+}
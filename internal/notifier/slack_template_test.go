@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+const customSlackTemplate = `{"blocks": [
+	{"type": "section", "text": {"type": "mrkdwn", "text": {{json (printf ":sparkles: *%s* at %s" .Title (capitalize .Company))}}}},
+	{"type": "context", "elements": [{"type": "mrkdwn", "text": {{json (printf "Req %s · %s" .Detail.RequisitionID .Location)}}}]}
+]}`
+
+func TestSlackNotifier_CustomTemplate(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "slack.json.tmpl")
+	if err := os.WriteFile(path, []byte(customSlackTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := LoadSlackTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadSlackTemplate: %v", err)
+	}
+
+	n := NewSlackNotifier(srv.URL, srv.Client(), discardLogger())
+	n.SetTemplate(tmpl)
+	job := sampleJob(`Backend "Go" Engineer`, "acme")
+	job.Detail = &model.JobDetail{RequisitionID: "R-42"}
+
+	if _, err := n.Notify([]model.Job{job}); err != nil {
+		t.Fatalf("Notify() = %v, want nil", err)
+	}
+
+	var payload struct {
+		Blocks []struct {
+			Type string `json:"type"`
+			Text struct {
+				Text string `json:"text"`
+			} `json:"text"`
+			Elements []struct {
+				Text string `json:"text"`
+			} `json:"elements"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unmarshal payload: %v\n%s", err, body)
+	}
+	if len(payload.Blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(payload.Blocks))
+	}
+	if got := payload.Blocks[0].Text.Text; got != `:sparkles: *Backend "Go" Engineer* at Acme` {
+		t.Errorf("section text = %q", got)
+	}
+	if payload.Blocks[1].Type != "context" || payload.Blocks[1].Elements[0].Text != "Req R-42 · Remote, US" {
+		t.Errorf("context block = %+v", payload.Blocks[1])
+	}
+}
+
+func TestSlackTemplate_FallsBackOnRenderError(t *testing.T) {
+	// .Detail is nil for this job, so the template fails to execute.
+	tmpl, err := ParseSlackTemplate("broken", customSlackTemplate)
+	if err != nil {
+		t.Fatalf("ParseSlackTemplate: %v", err)
+	}
+	blocks, err := renderBlocks(tmpl, sampleJob("Backend Engineer", "Acme Corp"), false, discardLogger())
+	if err != nil {
+		t.Fatalf("renderBlocks: %v", err)
+	}
+	var got []slackBlock
+	if err := json.Unmarshal(blocks, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Type != "header" || got[0].Text.Text != "🚀 Acme Corp: Backend Engineer" {
+		t.Errorf("expected built-in header block, got %+v", got[0])
+	}
+}
+
+func TestParseSlackTemplate_Invalid(t *testing.T) {
+	if _, err := ParseSlackTemplate("bad", `{"blocks": [{{.Title}]}`); err == nil {
+		t.Error("expected parse error for malformed template")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -41,10 +42,10 @@ func TestSlackNotifier_EmptyJobs(t *testing.T) {
 
 	n := NewSlackNotifier(srv.URL, srv.Client(), discardLogger())
 
-	if err := n.Notify(nil); err != nil {
+	if _, err := n.Notify(nil); err != nil {
 		t.Errorf("Notify(nil) = %v, want nil", err)
 	}
-	if err := n.Notify([]model.Job{}); err != nil {
+	if _, err := n.Notify([]model.Job{}); err != nil {
 		t.Errorf("Notify([]) = %v, want nil", err)
 	}
 	if c := calls.Load(); c != 0 {
@@ -63,7 +64,7 @@ func TestSlackNotifier_SingleJob(t *testing.T) {
 	n := NewSlackNotifier(srv.URL, srv.Client(), discardLogger())
 	job := sampleJob("Backend Engineer", "Acme Corp")
 
-	if err := n.Notify([]model.Job{job}); err != nil {
+	if _, err := n.Notify([]model.Job{job}); err != nil {
 		t.Fatalf("Notify() = %v, want nil", err)
 	}
 
@@ -103,7 +104,7 @@ func TestSlackNotifier_MultipleJobs(t *testing.T) {
 		sampleJob("Engineer 3", "C"),
 	}
 
-	if err := n.Notify(jobs); err != nil {
+	if _, err := n.Notify(jobs); err != nil {
 		t.Fatalf("Notify() = %v, want nil", err)
 	}
 	if c := calls.Load(); c != 3 {
@@ -111,6 +112,104 @@ func TestSlackNotifier_MultipleJobs(t *testing.T) {
 	}
 }
 
+func TestSlackNotifier_MessageDelay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	jobs := []model.Job{
+		sampleJob("Engineer 1", "A"),
+		sampleJob("Engineer 2", "B"),
+		sampleJob("Engineer 3", "C"),
+	}
+	tests := []struct {
+		name  string
+		delay *time.Duration
+		want  []time.Duration
+	}{
+		{"default", nil, []time.Duration{DefaultMessageDelay, DefaultMessageDelay}},
+		{"configured", durationPtr(2 * time.Second), []time.Duration{2 * time.Second, 2 * time.Second}},
+		{"zero sends back to back", durationPtr(0), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NewSlackNotifier(srv.URL, srv.Client(), discardLogger())
+			if tt.delay != nil {
+				n.SetMessageDelay(*tt.delay)
+			}
+			var slept []time.Duration
+			n.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+			if _, err := n.Notify(jobs); err != nil {
+				t.Fatalf("Notify() = %v, want nil", err)
+			}
+			if len(slept) != len(tt.want) {
+				t.Fatalf("slept %v, want %v", slept, tt.want)
+			}
+			for i := range slept {
+				if slept[i] != tt.want[i] {
+					t.Errorf("slept %v, want %v", slept, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration { return &d }
+
+func TestSlackNotifier_SendDigest(t *testing.T) {
+	var bodies [][]byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, srv.Client(), discardLogger())
+	n.SetMessageDelay(2 * time.Second)
+	var slept []time.Duration
+	n.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	a := sampleJob("Engineer 1", "acme")
+	b := sampleJob("Engineer 2", "beta")
+	b.URL = "https://example.com/other"
+	delivered, err := n.SendDigest([]model.Job{a, b})
+	if err != nil {
+		t.Fatalf("SendDigest() = %v, want nil", err)
+	}
+	if len(delivered) != 2 || !delivered[0] || !delivered[1] {
+		t.Errorf("delivered = %v, want both jobs", delivered)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("sent %d messages, want one digest", len(bodies))
+	}
+	if len(slept) != 0 {
+		t.Errorf("slept %v, want no message delay for a digest", slept)
+	}
+
+	var msg struct {
+		Text   string          `json:"text"`
+		Blocks json.RawMessage `json:"blocks"`
+	}
+	if err := json.Unmarshal(bodies[0], &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, want := range []string{
+		"*Job digest: 2 roles*",
+		"<https://example.com/apply|Acme: Engineer 1 · Remote, US>",
+		"<https://example.com/other|Beta: Engineer 2 · Remote, US>",
+	} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text = %q, want it to contain %q", msg.Text, want)
+		}
+	}
+	if msg.Blocks != nil {
+		t.Errorf("blocks = %s, want a text-only digest", msg.Blocks)
+	}
+}
+
 func TestSlackNotifier_SlackReturnsError(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -126,7 +225,7 @@ func TestSlackNotifier_SlackReturnsError(t *testing.T) {
 	// Mock returns 500 for all — but we send 2, let's test partial by having a mix.
 	// For this test, all fail so Notify should return error.
 	// Use a separate test for partial failure.
-	err := n.Notify(jobs)
+	_, err := n.Notify(jobs)
 	if err == nil {
 		t.Error("expected error when all messages fail, got nil")
 	}
@@ -145,7 +244,7 @@ func TestSlackNotifier_AllFail(t *testing.T) {
 		sampleJob("C", "Z"),
 	}
 
-	err := n.Notify(jobs)
+	_, err := n.Notify(jobs)
 	if err == nil {
 		t.Error("expected error when all messages fail, got nil")
 	}
@@ -169,7 +268,7 @@ func TestSlackNotifier_PartialFailure(t *testing.T) {
 		sampleJob("Succeeds", "B"),
 	}
 
-	if err := n.Notify(jobs); err != nil {
+	if _, err := n.Notify(jobs); err != nil {
 		t.Errorf("expected nil (partial success), got %v", err)
 	}
 }
@@ -188,7 +287,7 @@ func TestSlackNotifier_RateLimited(t *testing.T) {
 	defer srv.Close()
 
 	n := NewSlackNotifier(srv.URL, srv.Client(), discardLogger())
-	err := n.Notify([]model.Job{sampleJob("Rate Limited Job", "Test")})
+	_, err := n.Notify([]model.Job{sampleJob("Rate Limited Job", "Test")})
 	if err != nil {
 		t.Fatalf("expected nil after retry, got %v", err)
 	}
@@ -216,7 +315,7 @@ func TestSlackNotifier_PayloadFormat(t *testing.T) {
 		// PostedAt is nil — should display "Just detected"
 	}
 
-	if err := n.Notify([]model.Job{job}); err != nil {
+	if _, err := n.Notify([]model.Job{job}); err != nil {
 		t.Fatalf("Notify() = %v", err)
 	}
 
@@ -257,3 +356,173 @@ func TestSlackNotifier_PayloadFormat(t *testing.T) {
 		t.Errorf("block[4] type = %q, want divider", payload.Blocks[4].Type)
 	}
 }
+
+func TestBuildPayload_InsightsIncludeSeniority(t *testing.T) {
+	job := sampleJob("Backend Engineer", "Acme")
+	job.Insights = &model.JobInsights{
+		RoleType:  "backend",
+		Seniority: "senior",
+		YearsExp:  "5+ years",
+		TechStack: []string{"Go"},
+		KeyPoints: [3]string{"a", "b", "c"},
+	}
+
+	payload := buildPayload(job, false)
+	insights := payload.Blocks[3].Text.Text
+	if !strings.Contains(insights, "*Role:* backend (senior)") {
+		t.Errorf("insights block = %q, want role with seniority", insights)
+	}
+}
+
+func TestBuildPayload_FewerKeyPoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		points [3]string
+		want   string
+	}{
+		{"three", [3]string{"a", "b", "c"}, "\n• a\n• b\n• c"},
+		{"two", [3]string{"a", "b"}, "\n• a\n• b"},
+		{"one", [3]string{"a"}, "\n• a"},
+		{"gap", [3]string{"a", " ", "c"}, "\n• a\n• c"},
+		{"none", [3]string{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := sampleJob("Backend Engineer", "Acme")
+			job.Insights = &model.JobInsights{RoleType: "backend", YearsExp: "3+ years", TechStack: []string{"Go"}, KeyPoints: tt.points}
+
+			insights := buildPayload(job, false).Blocks[3].Text.Text
+			want := "*Role:* backend   *Exp:* 3+ years   *Stack:* Go" + tt.want
+			if insights != want {
+				t.Errorf("insights block = %q, want %q", insights, want)
+			}
+		})
+	}
+}
+
+func TestBuildPayload_PayRanges(t *testing.T) {
+	job := model.Job{
+		Company: "TestCo",
+		Title:   "SRE",
+		URL:     "https://example.com/sre",
+		Detail: &model.JobDetail{PayRanges: []model.PayRange{
+			{MinCents: 12000000, MaxCents: 15000000, CurrencyType: "USD", Title: "NYC"},
+			{MinCents: 10000000, MaxCents: 13000000, CurrencyType: "EUR"},
+		}},
+	}
+
+	payload := buildPayload(job, false)
+
+	if len(payload.Blocks) != 6 {
+		t.Fatalf("expected 6 blocks with a pay section, got %d", len(payload.Blocks))
+	}
+	pay := payload.Blocks[3]
+	want := "*Pay:*\nUSD $120000 - $150000 (NYC)\nEUR $100000 - $130000"
+	if pay.Type != "section" || len(pay.Fields) != 1 || pay.Fields[0].Text != want {
+		t.Errorf("pay block = %+v, want field %q", pay, want)
+	}
+}
+
+func TestBuildPayload_Tags(t *testing.T) {
+	job := model.Job{Company: "TestCo", Title: "SRE", URL: "https://example.com/sre", Tags: []string{"Go", "R&D"}}
+
+	payload := buildPayload(job, false)
+
+	if len(payload.Blocks) != 6 {
+		t.Fatalf("expected 6 blocks with a tags section, got %d", len(payload.Blocks))
+	}
+	tags := payload.Blocks[3]
+	want := "*Tags:*\nGo, R&amp;D"
+	if tags.Type != "section" || len(tags.Fields) != 1 || tags.Fields[0].Text != want {
+		t.Errorf("tags block = %+v, want field %q", tags, want)
+	}
+}
+
+func TestBuildPayload_InsightsFirst(t *testing.T) {
+	withInsights := sampleJob("Backend Engineer", "Acme")
+	withInsights.Insights = &model.JobInsights{
+		RoleType:  "backend",
+		YearsExp:  "3-5 years",
+		TechStack: []string{"Go"},
+		KeyPoints: [3]string{"a", "b", "c"},
+	}
+
+	tests := []struct {
+		name          string
+		job           model.Job
+		insightsFirst bool
+		want          []string // block kinds, see kind
+	}{
+		{"default puts insights last", withInsights, false, []string{"header", "company", "posted", "insights", "actions", "divider"}},
+		{"insights first", withInsights, true, []string{"header", "insights", "company", "posted", "actions", "divider"}},
+		{"insights first without insights", sampleJob("Backend Engineer", "Acme"), true, []string{"header", "company", "posted", "actions", "divider"}},
+	}
+	// kind names a block by its type or, for sections, by what it holds.
+	kind := func(b slackBlock) string {
+		switch {
+		case b.Type != "section":
+			return b.Type
+		case b.Text != nil && strings.HasPrefix(b.Text.Text, "*Role:*"):
+			return "insights"
+		case len(b.Fields) > 0 && strings.HasPrefix(b.Fields[0].Text, "*Company:*"):
+			return "company"
+		case len(b.Fields) > 0 && strings.HasPrefix(b.Fields[0].Text, "*Posted:*"):
+			return "posted"
+		}
+		return "section"
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, b := range buildPayload(tt.job, tt.insightsFirst).Blocks {
+				got = append(got, kind(b))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("blocks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlackNotifier_EscapesTextAndDisablesUnfurl(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, srv.Client(), discardLogger())
+	job := model.Job{
+		ID:       "789",
+		Company:  "AT&T",
+		Title:    "C++ & Systems <Core>",
+		Location: "Dallas, TX",
+		URL:      "https://example.com/apply?a=1&b=2",
+		Source:   "workday",
+	}
+	if _, err := n.Notify([]model.Job{job}); err != nil {
+		t.Fatalf("Notify() = %v", err)
+	}
+
+	var msg struct {
+		Blocks      []slackBlock `json:"blocks"`
+		UnfurlLinks *bool        `json:"unfurl_links"`
+		UnfurlMedia *bool        `json:"unfurl_media"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if msg.UnfurlLinks == nil || *msg.UnfurlLinks || msg.UnfurlMedia == nil || *msg.UnfurlMedia {
+		t.Errorf("unfurl_links/unfurl_media = %v/%v, want explicit false", msg.UnfurlLinks, msg.UnfurlMedia)
+	}
+	if got, want := msg.Blocks[0].Text.Text, "🚀 AT&amp;T: C++ &amp; Systems &lt;Core&gt;"; got != want {
+		t.Errorf("header = %q, want %q", got, want)
+	}
+	if got := msg.Blocks[1].Fields[0].Text; got != "*Company:*\nAT&amp;T" {
+		t.Errorf("company field = %q, want escaped", got)
+	}
+	if got := msg.Blocks[len(msg.Blocks)-2].Elements[0].URL; got != job.URL {
+		t.Errorf("button url = %q, want it unescaped", got)
+	}
+}
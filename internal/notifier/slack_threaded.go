@@ -0,0 +1,243 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure SlackThreadedNotifier implements model.Notifier and model.DigestSender.
+var (
+	_ model.Notifier     = (*SlackThreadedNotifier)(nil)
+	_ model.DigestSender = (*SlackThreadedNotifier)(nil)
+)
+
+const slackPostMessageURL = "https://slack.com/api/chat.postMessage"
+
+// SlackThreadedNotifier posts one "New roles at {company}" parent message per
+// company and threads that company's jobs under it, so alerts from different
+// boards don't interleave in the channel. Incoming webhooks can't thread, so
+// it uses chat.postMessage with a bot token.
+type SlackThreadedNotifier struct {
+	botToken     string
+	channel      string
+	apiURL       string
+	httpClient   *http.Client
+	template     *template.Template // optional: renders job messages instead of the built-in blocks
+	logger       *slog.Logger
+	messageDelay time.Duration // pause between messages to stay under Slack's per-channel rate limit
+
+	insightsFirst bool // lead job messages with the AI insights block (built-in layout only)
+}
+
+// NewSlackThreadedNotifier returns a notifier that posts to channel using the
+// bot token (which needs the chat:write scope).
+func NewSlackThreadedNotifier(botToken, channel string, httpClient *http.Client, logger *slog.Logger) *SlackThreadedNotifier {
+	return &SlackThreadedNotifier{
+		botToken:     botToken,
+		channel:      channel,
+		apiURL:       slackPostMessageURL,
+		httpClient:   httpClient,
+		logger:       logger,
+		messageDelay: DefaultMessageDelay,
+	}
+}
+
+// SetTemplate replaces the built-in Block Kit layout of job messages with tmpl
+// (see LoadSlackTemplate). Thread parents are unaffected.
+func (s *SlackThreadedNotifier) SetTemplate(tmpl *template.Template) {
+	s.template = tmpl
+}
+
+// SetInsightsFirst moves the AI insights block of job messages up to just
+// below the header (see SlackNotifier.SetInsightsFirst).
+func (s *SlackThreadedNotifier) SetInsightsFirst(enabled bool) {
+	s.insightsFirst = enabled
+}
+
+// SetMessageDelay sets the pause between messages. Zero sends them back to
+// back; negative values are ignored. A digest is one message and never waits.
+func (s *SlackThreadedNotifier) SetMessageDelay(d time.Duration) {
+	if d >= 0 {
+		s.messageDelay = d
+	}
+}
+
+// Notify groups jobs by company, posts a parent message for each company and
+// replies with one Block Kit message per job in that thread. If a parent
+// fails to post, the company's jobs are sent unthreaded rather than dropped.
+// delivered[i] reports whether jobs[i] was sent. Returns an error only if ALL
+// job messages fail. Individual failures are logged.
+func (s *SlackThreadedNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	delivered := make([]bool, len(jobs))
+	failures := 0
+	for g, group := range groupByCompany(jobs) {
+		company := capitalize(jobs[group[0]].Company)
+		if g > 0 {
+			time.Sleep(s.messageDelay)
+		}
+		kind := "New"
+		if jobs[group[0]].PostingStatus == model.PostingClosed {
+			kind = "Closed" // closures are notified in their own batch
+		}
+		threadTS, err := s.post(slackChatMessage{
+			Channel: s.channel,
+			Text:    fmt.Sprintf("%s roles at %s (%d)", kind, escapeText(company), len(group)),
+		})
+		if err != nil {
+			s.logger.Error("slack thread parent failed, sending unthreaded", "company", jobs[group[0]].Company, "error", err)
+			threadTS = ""
+		}
+
+		for _, i := range group {
+			j := jobs[i]
+			time.Sleep(s.messageDelay)
+			blocks, err := renderBlocks(s.template, j, s.insightsFirst, s.logger)
+			if err != nil {
+				s.logger.Error("slack notification failed", "company", j.Company, "title", j.Title, "error", err)
+				failures++
+				continue
+			}
+			msg := slackChatMessage{
+				Channel:  s.channel,
+				ThreadTS: threadTS,
+				Text:     escapeText(headlinePrefix(j) + company + ": " + j.Title),
+				Blocks:   blocks,
+			}
+			if _, err := s.post(msg); err != nil {
+				s.logger.Error("slack notification failed", "company", j.Company, "title", j.Title, "error", err)
+				failures++
+				continue
+			}
+			delivered[i] = true
+			s.logger.Info("slack message sent", "company", j.Company, "title", j.Title, "thread_ts", threadTS)
+		}
+	}
+
+	sent := len(jobs) - failures
+	if failures == len(jobs) {
+		return delivered, fmt.Errorf("all %d slack notifications failed", failures)
+	}
+	s.logger.Info("slack notifications complete", "sent", sent, "failed", failures)
+	return delivered, nil
+}
+
+// SendDigest posts jobs as one unthreaded channel message listing each job as
+// a link (see SlackNotifier.SendDigest).
+func (s *SlackThreadedNotifier) SendDigest(jobs []model.Job) ([]bool, error) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	if _, err := s.post(slackChatMessage{Channel: s.channel, Text: slackDigestText(jobs)}); err != nil {
+		return make([]bool, len(jobs)), fmt.Errorf("slack digest: %w", err)
+	}
+	s.logger.Info("slack digest sent", "jobs", len(jobs))
+	return allDelivered(len(jobs)), nil
+}
+
+// groupByCompany splits job indexes into per-company groups, ordered by each
+// company's first appearance.
+func groupByCompany(jobs []model.Job) [][]int {
+	index := make(map[string]int)
+	var groups [][]int
+	for i, j := range jobs {
+		g, ok := index[j.Company]
+		if !ok {
+			g = len(groups)
+			index[j.Company] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// slackChatMessage is the chat.postMessage request body. Unfurling is always
+// off, as for webhook messages.
+type slackChatMessage struct {
+	Channel     string          `json:"channel"`
+	ThreadTS    string          `json:"thread_ts,omitempty"`
+	Text        string          `json:"text"` // fallback for notifications and clients without Block Kit
+	Blocks      json.RawMessage `json:"blocks,omitempty"`
+	UnfurlLinks bool            `json:"unfurl_links"`
+	UnfurlMedia bool            `json:"unfurl_media"`
+}
+
+// slackChatResponse is the subset of the chat.postMessage response we use.
+// Slack reports most failures with HTTP 200 and ok=false.
+type slackChatResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	TS    string `json:"ts"`
+}
+
+// post sends msg and returns the new message's ts, retrying once on 429.
+func (s *SlackThreadedNotifier) post(msg slackChatMessage) (string, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("marshal slack message: %w", err)
+	}
+
+	resp, err := s.do(body)
+	if err != nil {
+		return "", fmt.Errorf("post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		secs, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if secs <= 0 {
+			secs = 1
+		}
+		s.logger.Warn("slack rate limited, retrying", "retry_after_secs", secs)
+		time.Sleep(time.Duration(secs) * time.Second)
+
+		resp2, err := s.do(body)
+		if err != nil {
+			return "", fmt.Errorf("post to slack (retry): %w", err)
+		}
+		defer resp2.Body.Close()
+		return decodeChatResponse(resp2)
+	}
+	return decodeChatResponse(resp)
+}
+
+func (s *SlackThreadedNotifier) do(body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, s.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+s.botToken)
+	return s.httpClient.Do(req)
+}
+
+func decodeChatResponse(resp *http.Response) (string, error) {
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("slack returned %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read slack response: %w", err)
+	}
+	var r slackChatResponse
+	if err := json.Unmarshal(data, &r); err != nil {
+		return "", fmt.Errorf("decode slack response: %w", err)
+	}
+	if !r.OK {
+		return "", fmt.Errorf("slack api error: %s", r.Error)
+	}
+	return r.TS, nil
+}
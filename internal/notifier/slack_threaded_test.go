@@ -0,0 +1,126 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// fakeSlackAPI records chat.postMessage calls and answers each with a unique ts.
+type fakeSlackAPI struct {
+	mu       sync.Mutex
+	messages []slackChatMessage
+	auth     []string
+	failText string // parent text that should get ok=false
+}
+
+func (f *fakeSlackAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var msg slackChatMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.messages = append(f.messages, msg)
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	n := len(f.messages)
+	f.mu.Unlock()
+
+	if f.failText != "" && msg.Text == f.failText {
+		json.NewEncoder(w).Encode(slackChatResponse{OK: false, Error: "channel_not_found"})
+		return
+	}
+	json.NewEncoder(w).Encode(slackChatResponse{OK: true, TS: fmt.Sprintf("1700000000.%06d", n)})
+}
+
+func newTestThreadedNotifier(t *testing.T, api *fakeSlackAPI) *SlackThreadedNotifier {
+	t.Helper()
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	n := NewSlackThreadedNotifier("xoxb-test", "C123", srv.Client(), discardLogger())
+	n.apiURL = srv.URL
+	n.messageDelay = 0
+	return n
+}
+
+func TestSlackThreadedNotifier_ReusesThreadTS(t *testing.T) {
+	api := &fakeSlackAPI{}
+	n := newTestThreadedNotifier(t, api)
+
+	jobs := []model.Job{
+		sampleJob("Backend Engineer", "acme"),
+		sampleJob("Platform Engineer", "globex"),
+		sampleJob("Frontend Engineer", "acme"),
+	}
+	if _, err := n.Notify(jobs); err != nil {
+		t.Fatalf("Notify() = %v, want nil", err)
+	}
+
+	// acme parent, 2 acme jobs, globex parent, 1 globex job.
+	if len(api.messages) != 5 {
+		t.Fatalf("got %d messages, want 5", len(api.messages))
+	}
+	tests := []struct {
+		text     string
+		threadTS string
+	}{
+		{"New roles at Acme (2)", ""},
+		{"🚀 Acme: Backend Engineer", "1700000000.000001"},
+		{"🚀 Acme: Frontend Engineer", "1700000000.000001"},
+		{"New roles at Globex (1)", ""},
+		{"🚀 Globex: Platform Engineer", "1700000000.000004"},
+	}
+	for i, tt := range tests {
+		got := api.messages[i]
+		if got.Text != tt.text {
+			t.Errorf("message %d text = %q, want %q", i, got.Text, tt.text)
+		}
+		if got.ThreadTS != tt.threadTS {
+			t.Errorf("message %d thread_ts = %q, want %q", i, got.ThreadTS, tt.threadTS)
+		}
+		if got.Channel != "C123" {
+			t.Errorf("message %d channel = %q, want C123", i, got.Channel)
+		}
+		if api.auth[i] != "Bearer xoxb-test" {
+			t.Errorf("message %d Authorization = %q", i, api.auth[i])
+		}
+	}
+	if len(api.messages[1].Blocks) == 0 {
+		t.Error("job message has no blocks")
+	}
+}
+
+func TestSlackThreadedNotifier_ParentFailureSendsUnthreaded(t *testing.T) {
+	api := &fakeSlackAPI{failText: "New roles at Acme (1)"}
+	n := newTestThreadedNotifier(t, api)
+
+	if _, err := n.Notify([]model.Job{sampleJob("Backend Engineer", "acme")}); err != nil {
+		t.Fatalf("Notify() = %v, want nil", err)
+	}
+	if len(api.messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(api.messages))
+	}
+	if api.messages[1].ThreadTS != "" {
+		t.Errorf("thread_ts = %q, want unthreaded", api.messages[1].ThreadTS)
+	}
+}
+
+func TestSlackThreadedNotifier_AllFail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(slackChatResponse{OK: false, Error: "invalid_auth"})
+	}))
+	defer srv.Close()
+
+	n := NewSlackThreadedNotifier("bad", "C123", srv.Client(), discardLogger())
+	n.apiURL = srv.URL
+	n.messageDelay = 0
+
+	if _, err := n.Notify([]model.Job{sampleJob("Backend Engineer", "acme")}); err == nil {
+		t.Error("Notify() = nil, want error when every message fails")
+	}
+}
@@ -11,3 +11,16 @@ import (
 type JobAnalyzer interface {
 	Analyze(ctx context.Context, job model.Job) (model.Job, error)
 }
+
+// DisabledAnalyzer is implemented by a JobAnalyzer that stands in while AI
+// enrichment is off, so interactive callers can say so up front rather than
+// run an analysis that can only come back empty.
+type DisabledAnalyzer interface {
+	Disabled() bool
+}
+
+// JobTagger labels a job with short tags derived from its text (see
+// tagger.Tagger).
+type JobTagger interface {
+	Tag(job model.Job) []string
+}
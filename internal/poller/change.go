@@ -0,0 +1,137 @@
+package poller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// DefaultChangeChecks is how many detail requests change detection makes per
+// poll unless SetChangeChecks says otherwise.
+const DefaultChangeChecks = 5
+
+// SetNotifyOnChange makes the poller re-notify an already-seen job when its
+// description changes materially (new pay, new requirements). Descriptions
+// are hashed in the store, which must implement model.DescriptionHashStore;
+// the first poll that sees a job's description only records its hash. df,
+// when non-nil, fetches the description of listings that lack one, for a
+// few fresh seen jobs per poll (see SetChangeChecks).
+func (p *CompanyPoller) SetNotifyOnChange(enabled bool, df model.JobDetailFetcher) {
+	p.notifyOnChange = enabled
+	p.changeFetcher = df
+}
+
+// SetChangeChecks caps the detail requests change detection makes per poll
+// at n. Each poll checks the next n seen jobs in ID order, wrapping around, so
+// every job is still rechecked every few polls. n <= 0 restores
+// DefaultChangeChecks.
+func (p *CompanyPoller) SetChangeChecks(n int) {
+	if n <= 0 {
+		n = DefaultChangeChecks
+	}
+	p.changeChecks = n
+}
+
+// descriptionHash returns a hash of job's description with whitespace
+// collapsed, or "" when it has none.
+func descriptionHash(job model.Job) string {
+	if job.Detail == nil {
+		return ""
+	}
+	desc := strings.Join(strings.Fields(job.Detail.Description), " ")
+	if desc == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(desc))
+	return hex.EncodeToString(sum[:])
+}
+
+// detectChanges compares the descriptions of already-seen jobs with their
+// stored hashes. It returns the jobs whose description changed, carrying the
+// fetched description, and their new hashes, which the caller stores once
+// they are delivered. Jobs with no stored hash get one now as a baseline.
+// Of the jobs listed without a description, only this poll's share (see
+// changeSample) is fetched and compared.
+func (p *CompanyPoller) detectChanges(ctx context.Context, seen []model.Job) ([]model.Job, map[string]string, error) {
+	hs, ok := p.store.(model.DescriptionHashStore)
+	if !ok || len(seen) == 0 {
+		return nil, nil, nil
+	}
+	stored, err := hs.DescriptionHashes(jobIDs(seen))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var listed, bare []model.Job
+	for _, job := range seen {
+		if descriptionHash(job) != "" {
+			listed = append(listed, job)
+		} else if p.changeFetcher != nil {
+			bare = append(bare, job)
+		}
+	}
+	for _, job := range p.changeSample(bare) {
+		detailed, err := p.fetchDetail(ctx, p.changeFetcher, job)
+		if err != nil {
+			p.logger.Debug("detail fetch for change detection failed", "company", p.Name, "job_id", job.ID, "error", err)
+			continue
+		}
+		listed = append(listed, detailed)
+	}
+
+	var changed []model.Job
+	newHashes := make(map[string]string)
+	baseline := make(map[string]string)
+	for _, job := range listed {
+		hash := descriptionHash(job)
+		switch old, ok := stored[job.ID]; {
+		case hash == "" || hash == old:
+		case !ok:
+			baseline[job.ID] = hash
+		default:
+			changed = append(changed, job)
+			newHashes[job.ID] = hash
+		}
+	}
+	if err := hs.SetDescriptionHashes(baseline); err != nil {
+		return nil, nil, err
+	}
+	return changed, newHashes, nil
+}
+
+// changeSample returns the jobs whose detail this poll fetches for change
+// detection: up to changeChecks of them, in ID order from just after the
+// last job checked, wrapping around.
+func (p *CompanyPoller) changeSample(jobs []model.Job) []model.Job {
+	if len(jobs) <= p.changeChecks {
+		return jobs
+	}
+	sorted := slices.SortedFunc(slices.Values(jobs), func(a, b model.Job) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	start := sort.Search(len(sorted), func(i int) bool { return sorted[i].ID > p.changeAfter })
+	sample := make([]model.Job, p.changeChecks)
+	for i := range sample {
+		sample[i] = sorted[(start+i)%len(sorted)]
+	}
+	p.changeAfter = sample[len(sample)-1].ID
+	return sample
+}
+
+// storeChangedHashes stores the new description hashes, except for jobs in
+// undelivered, so a change that failed to notify is retried next poll. Changes
+// dropped by the insights filter or the per-poll cap are stored, like new jobs
+// in those cases are marked seen.
+func (p *CompanyPoller) storeChangedHashes(hashes map[string]string, undelivered []model.Job) {
+	for _, job := range undelivered {
+		delete(hashes, job.ID)
+	}
+	if err := p.store.(model.DescriptionHashStore).SetDescriptionHashes(hashes); err != nil {
+		p.logger.Warn("storing description hashes failed", "company", p.Name, "error", err)
+	}
+}
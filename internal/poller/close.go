@@ -0,0 +1,70 @@
+package poller
+
+import (
+	"context"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// SetNotifyOnClose makes the poller report a notified role that is no longer
+// listed (filled or pulled): the job is sent to the notifier once more with
+// PostingStatus closed. The store must implement model.OpenJobStore. Absence
+// is judged against everything the board returned, so it only means closed
+// for fetchers that return the whole board, not ones that stop at stale
+// listings.
+func (p *CompanyPoller) SetNotifyOnClose(enabled bool) {
+	p.notifyOnClose = enabled
+}
+
+// notifyClosed notifies the company's open tracked jobs missing from listed,
+// or listed with a status other than open, and marks the delivered ones
+// closed. Undelivered ones stay open and are retried next poll. Failures
+// are logged rather than failing the poll, whose new jobs already went out.
+func (p *CompanyPoller) notifyClosed(ctx context.Context, listed []model.Job) {
+	ts, ok := p.store.(model.OpenJobStore)
+	// An empty board is likelier an outage than every role closing at once.
+	if !ok || len(listed) == 0 {
+		return
+	}
+	tracked, err := ts.OpenJobs(p.Name)
+	if err != nil {
+		p.logger.Warn("reading open jobs failed", "company", p.Name, "error", err)
+		return
+	}
+
+	open := make(map[string]bool, len(listed))
+	for _, job := range listed {
+		if KeepsPostingStatus(job, nil) {
+			open[job.ID] = true
+		}
+	}
+	var closed []model.Job
+	for _, job := range tracked {
+		if !open[job.ID] {
+			job.PostingStatus = model.PostingClosed
+			if job.Source == "" {
+				// Matches are stored without it; notifiers key on it (pagerduty).
+				job.Source = p.ATS
+			}
+			closed = append(closed, job)
+		}
+	}
+	if len(closed) == 0 {
+		return
+	}
+
+	sent, undelivered, err := p.notify(ctx, closed)
+	if err != nil {
+		p.logger.Warn("notifying closed roles failed, will retry next poll", "company", p.Name, "closed", len(closed), "error", err)
+		return
+	}
+	if err := ts.MarkClosed(jobIDs(sent)); err != nil {
+		p.logger.Warn("marking jobs closed failed", "company", p.Name, "error", err)
+		return
+	}
+	p.logger.Info("tracked roles closed",
+		"company", p.Name,
+		"closed", len(sent),
+		"undelivered", len(undelivered),
+	)
+}
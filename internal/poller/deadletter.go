@@ -0,0 +1,61 @@
+package poller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// DeadLetter appends jobs whose notification failed to a JSONL file, one
+// record per job, so they can be recovered by hand. Failed jobs are also left
+// unseen and retried on the next poll; the file is a record, not a queue.
+// Safe for use by pollers on different ATS goroutines.
+type DeadLetter struct {
+	path string
+	mu   sync.Mutex
+}
+
+// deadLetterRecord is one line of the dead-letter file.
+type deadLetterRecord struct {
+	Time    time.Time `json:"time"`
+	Company string    `json:"company"`
+	Error   string    `json:"error"`
+	Job     model.Job `json:"job"`
+}
+
+// NewDeadLetter returns a sink appending to path, created on first write.
+func NewDeadLetter(path string) *DeadLetter {
+	return &DeadLetter{path: path}
+}
+
+// Write appends one record per job with the notification error.
+func (d *DeadLetter) Write(company string, jobs []model.Job, notifyErr error) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	reason := "notifier reported job undelivered"
+	if notifyErr != nil {
+		reason = notifyErr.Error()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f, err := os.OpenFile(d.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening dead-letter file: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	now := time.Now().UTC()
+	for _, j := range jobs {
+		if err := enc.Encode(deadLetterRecord{Time: now, Company: company, Error: reason, Job: j}); err != nil {
+			f.Close()
+			return fmt.Errorf("writing dead-letter record for %s: %w", j.ID, err)
+		}
+	}
+	return f.Close()
+}
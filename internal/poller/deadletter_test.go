@@ -0,0 +1,91 @@
+package poller
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readDeadLetters(t *testing.T, path string) []deadLetterRecord {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open dead-letter file: %v", err)
+	}
+	defer f.Close()
+	var records []deadLetterRecord
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var r deadLetterRecord
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("decode line %q: %v", sc.Text(), err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestPoll_DeadLetterRecordsFailedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead_letter.jsonl")
+	notifier := &FlakyNotifier{Fail: map[string]bool{"2": true}}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2", "3")},
+		&AcceptAllFilter{},
+		nonEmptyStore(),
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.SetDeadLetter(NewDeadLetter(path))
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := readDeadLetters(t, path)
+	if len(records) != 1 {
+		t.Fatalf("dead letters = %d, want 1", len(records))
+	}
+	r := records[0]
+	if r.Job.ID != "2" || r.Company != "testco" || r.Error == "" || r.Time.IsZero() {
+		t.Errorf("record = %+v, want job 2 with company, error and time", r)
+	}
+}
+
+func TestPoll_DeadLetterKeepsNotifierError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead_letter.jsonl")
+	notifier := &FlakyNotifier{Fail: map[string]bool{"1": true, "2": true}}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2")},
+		&AcceptAllFilter{},
+		nonEmptyStore(),
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.SetDeadLetter(NewDeadLetter(path))
+
+	if err := poller.Poll(context.Background()); err == nil {
+		t.Fatal("expected an error when every notification fails")
+	}
+
+	records := readDeadLetters(t, path)
+	if len(records) != 2 {
+		t.Fatalf("dead letters = %d, want 2", len(records))
+	}
+	for _, r := range records {
+		if r.Error != "all notifications failed" {
+			t.Errorf("record error = %q, want the notifier's error", r.Error)
+		}
+	}
+}
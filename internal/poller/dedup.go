@@ -0,0 +1,105 @@
+package poller
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/normalize"
+)
+
+// CrossCompanyDedup suppresses a job whose normalized title and location were
+// already claimed by a different company, e.g. a staffing agency posting one
+// role under two company names. Keys are remembered in memory for window
+// (one poll pass) and, with SetStore, across passes once delivered, each with
+// the company that claimed it. Safe for use by pollers on different ATS
+// goroutines.
+type CrossCompanyDedup struct {
+	window time.Duration
+	store  model.DedupKeyStore // optional: remembers delivered keys across passes
+	now    func() time.Time
+
+	mu     sync.Mutex
+	claims map[string]dedupClaim
+}
+
+type dedupClaim struct {
+	company string
+	at      time.Time
+}
+
+// NewCrossCompanyDedup returns a dedup that remembers keys for window.
+func NewCrossCompanyDedup(window time.Duration) *CrossCompanyDedup {
+	return &CrossCompanyDedup{
+		window: window,
+		now:    time.Now,
+		claims: make(map[string]dedupClaim),
+	}
+}
+
+// SetStore also records delivered keys in store, so a role notified for one
+// company stays suppressed for others in later passes and after restarts.
+func (d *CrossCompanyDedup) SetStore(store model.DedupKeyStore) {
+	d.store = store
+}
+
+// dedupKey is the lowercased, whitespace-collapsed title and normalized
+// location of job.
+func dedupKey(job model.Job) string {
+	location := job.NormalizedLocation
+	if location == "" {
+		location = normalize.NormalizeLocation(job.Location)
+	}
+	title := strings.Join(strings.Fields(strings.ToLower(job.Title)), " ")
+	return title + "|" + strings.ToLower(location)
+}
+
+// Claim splits jobs into those company may notify and duplicates of a role
+// another company already claimed, in this pass or (with a store) an earlier
+// one. A company re-claiming its own key (a notification retry, a re-post or
+// a changed description) is allowed.
+func (d *CrossCompanyDedup) Claim(company string, jobs []model.Job) (keep, dups []model.Job, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	for key, c := range d.claims {
+		if now.Sub(c.at) >= d.window {
+			delete(d.claims, key)
+		}
+	}
+
+	for _, job := range jobs {
+		key := dedupKey(job)
+		if c, ok := d.claims[key]; ok && c.company != company {
+			dups = append(dups, job)
+			continue
+		}
+		if d.store != nil {
+			claimant, err := d.store.DedupClaimant(key)
+			if err != nil {
+				return nil, nil, err
+			}
+			if claimant != "" && !strings.EqualFold(claimant, company) {
+				dups = append(dups, job)
+				continue
+			}
+		}
+		d.claims[key] = dedupClaim{company: company, at: now}
+		keep = append(keep, job)
+	}
+	return keep, dups, nil
+}
+
+// Record stores the keys of jobs delivered for company when a store is set.
+func (d *CrossCompanyDedup) Record(company string, jobs []model.Job) error {
+	if d.store == nil || len(jobs) == 0 {
+		return nil
+	}
+	keys := make([]string, len(jobs))
+	for i, job := range jobs {
+		keys[i] = dedupKey(job)
+	}
+	return d.store.RecordDedupKeys(company, keys)
+}
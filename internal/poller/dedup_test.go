@@ -0,0 +1,101 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/store"
+)
+
+// agencyJob is the same role as posted under a given company name.
+func agencyJob(id, company string) model.Job {
+	return model.Job{
+		ID:       id,
+		Company:  company,
+		Title:    "Senior  Backend Engineer",
+		Location: "Remote - US",
+		URL:      "https://example.com/" + id,
+		PostedAt: timePtr(time.Now()),
+	}
+}
+
+func TestPoll_CrossCompanyDedupNotifiesOnce(t *testing.T) {
+	tests := []struct {
+		name    string
+		persist bool
+		window  time.Duration
+		want    int // notifications after both companies poll twice
+	}{
+		{"within one pass", false, time.Hour, 1},
+		// A zero window forgets claims between polls, so only the store
+		// suppresses the duplicate on later passes.
+		{"across passes via store", true, 0, 1},
+		{"window expired without store", false, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobStore := nonEmptyStore()
+			notifier := &RecordingNotifier{}
+			dedup := NewCrossCompanyDedup(tt.window)
+			if tt.persist {
+				dedup.SetStore(jobStore)
+			}
+
+			newPoller := func(company string, job model.Job) *CompanyPoller {
+				p := NewCompanyPoller(company, "greenhouse", &MockFetcher{Jobs: []model.Job{job}},
+					&AcceptAllFilter{}, jobStore, notifier, &NopAnalyzer{}, 24*time.Hour, discardLogger())
+				p.SetCrossCompanyDedup(dedup)
+				return p
+			}
+			acme := newPoller("acme", agencyJob("a-1", "acme"))
+			// Same role, different ID, spacing and location spelling.
+			other := agencyJob("b-7", "beta staffing")
+			other.Title = "senior backend engineer"
+			other.Location = "US Remote"
+			beta := newPoller("beta staffing", other)
+
+			for _, p := range []*CompanyPoller{acme, beta, beta} {
+				if err := p.Poll(context.Background()); err != nil {
+					t.Fatalf("Poll %s: %v", p.Name, err)
+				}
+			}
+
+			if len(notifier.Notified) != tt.want {
+				t.Fatalf("notified %d jobs, want %d: %v", len(notifier.Notified), tt.want, notifier.Notified)
+			}
+			if notifier.Notified[0].Company != "acme" {
+				t.Errorf("notified %q first, want the first company to claim the role", notifier.Notified[0].Company)
+			}
+			if seen, _ := jobStore.HasSeen("b-7"); !seen {
+				t.Error("expected the skipped duplicate to be marked seen")
+			}
+		})
+	}
+}
+
+func TestCrossCompanyDedup_StoreKeepsClaimingCompany(t *testing.T) {
+	// A zero window forgets in-memory claims, so only the store decides.
+	dedup := NewCrossCompanyDedup(0)
+	dedup.SetStore(store.NewInMemoryStore())
+	job := agencyJob("a-1", "acme")
+
+	keep, _, err := dedup.Claim("acme", []model.Job{job})
+	if err != nil || len(keep) != 1 {
+		t.Fatalf("first Claim: keep=%d err=%v, want 1 kept", len(keep), err)
+	}
+	if err := dedup.Record("acme", keep); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	// The claiming company's own re-post is not a cross-company duplicate.
+	keep, dups, err := dedup.Claim("ACME", []model.Job{job})
+	if err != nil || len(keep) != 1 || len(dups) != 0 {
+		t.Errorf("Claim by claimant: keep=%d dups=%d err=%v, want 1 kept", len(keep), len(dups), err)
+	}
+	keep, dups, err = dedup.Claim("beta staffing", []model.Job{agencyJob("b-7", "beta staffing")})
+	if err != nil || len(keep) != 0 || len(dups) != 1 {
+		t.Errorf("Claim by other company: keep=%d dups=%d err=%v, want 1 dup", len(keep), len(dups), err)
+	}
+}
@@ -0,0 +1,53 @@
+package poller
+
+import (
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// AlertLagObserver records the time from a job's posting to its alert, in
+// seconds (see metrics.Histogram).
+type AlertLagObserver interface {
+	Observe(seconds float64)
+}
+
+// SetAlertLagObserver records the lag of every new job handed to the
+// notifier with a posting date, besides logging it. Re-notified description
+// changes are left out: their posting date is long past.
+func (p *CompanyPoller) SetAlertLagObserver(o AlertLagObserver) {
+	p.lagObserver = o
+}
+
+// alertLag returns how long after its posting job is alerted at now. It is
+// false when the board gave no posting date. A date in the future (clock
+// skew, or a board stamping the end of the day) counts as no lag.
+func alertLag(job model.Job, now time.Time) (time.Duration, bool) {
+	if job.PostedAt == nil {
+		return 0, false
+	}
+	return max(now.Sub(*job.PostedAt), 0), true
+}
+
+// recordAlertLag logs and observes the alert lag of each sent job not in
+// changed.
+func (p *CompanyPoller) recordAlertLag(sent, changed []model.Job) {
+	now := p.now()
+	skip := make(map[string]bool, len(changed))
+	for _, job := range changed {
+		skip[job.ID] = true
+	}
+	for _, job := range sent {
+		if skip[job.ID] {
+			continue
+		}
+		lag, ok := alertLag(job, now)
+		if !ok {
+			continue
+		}
+		p.logger.Info("alert lag", "company", p.Name, "job_id", job.ID, "lag", lag.Round(time.Second).String())
+		if p.lagObserver != nil {
+			p.lagObserver.Observe(lag.Seconds())
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package poller
+
+import (
+	"context"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// RequestPacer spaces out the requests a poll makes beyond its board fetch,
+// such as detail fetches, on the same per-ATS schedule as the board fetches
+// (see scheduler.Scheduler).
+type RequestPacer interface {
+	// Wait blocks until the next request to ats may start. It returns an
+	// error, without claiming a slot, if ctx ends first or the request
+	// could not start before ctx's deadline.
+	Wait(ctx context.Context, ats string) error
+	// Record reports the outcome of a request to ats, so rate limiting
+	// slows the requests after it.
+	Record(ats string, err error)
+}
+
+// SetRequestPacer paces every detail request, for enrichment and change
+// detection, through r. Without one they are sent back to back.
+func (p *CompanyPoller) SetRequestPacer(r RequestPacer) {
+	p.pacer = r
+}
+
+// fetchDetail calls df for job, first waiting for the pacer and then holding
+// a fetch semaphore slot when they are set.
+func (p *CompanyPoller) fetchDetail(ctx context.Context, df model.JobDetailFetcher, job model.Job) (model.Job, error) {
+	if p.pacer != nil {
+		if err := p.pacer.Wait(ctx, p.ATS); err != nil {
+			return job, err
+		}
+	}
+	if p.fetchSem != nil {
+		select {
+		case p.fetchSem <- struct{}{}:
+		case <-ctx.Done():
+			return job, ctx.Err()
+		}
+		defer func() { <-p.fetchSem }()
+	}
+	detailed, err := df.FetchJobDetail(ctx, job)
+	if p.pacer != nil {
+		p.pacer.Record(p.ATS, err)
+	}
+	return detailed, err
+}
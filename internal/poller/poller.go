@@ -4,23 +4,64 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/amishk599/firstin/internal/model"
 )
 
+// tracerName identifies spans created by the poller. Spans go to the global
+// provider, which is a no-op unless tracing is enabled.
+const tracerName = "github.com/amishk599/firstin/internal/poller"
+
+// FreshnessBasis is the time the freshness check measures a job's age from.
+type FreshnessBasis string
+
+const (
+	FreshnessPosted    FreshnessBasis = "posted"     // Job.PostedAt; jobs without one are kept
+	FreshnessFirstSeen FreshnessBasis = "first_seen" // Job.FirstSeen, when the store first saw the job
+)
+
 // CompanyPoller owns the full poll pipeline for a single company:
-// fetch → filter → dedup → [AI analyze] → notify → mark seen.
+// fetch → filter → dedup → [AI analyze] → [insights filter] → notify → mark seen.
 type CompanyPoller struct {
-	Name     string
-	ATS      string
-	fetcher  model.JobFetcher
-	filter   model.JobFilter
-	store    model.JobStore
-	notifier model.Notifier
-	analyzer JobAnalyzer
-	maxAge   time.Duration
-	logger   *slog.Logger
+	Name           string
+	ATS            string
+	fetcher        model.JobFetcher
+	filter         model.JobFilter
+	insightsFilter model.JobFilter        // optional: applied after AI analysis; nil keeps all
+	fetchSem       chan struct{}          // optional: shared cap on concurrent fetches; nil is unlimited
+	pacer          RequestPacer           // optional: spaces detail requests per ATS; nil sends them back to back
+	seedOnFirstRun bool                   // on an empty store, mark matches seen without notifying
+	maxNewPerPoll  int                    // optional: cap on jobs notified per poll; 0 is unlimited
+	detailFetcher  model.JobDetailFetcher // optional: enriches new jobs before analysis and notify
+	blockedIDs     map[string]bool        // job IDs dropped even when they match
+	deadLetter     *DeadLetter            // optional: records jobs whose notification failed
+	crossDedup     *CrossCompanyDedup     // optional: drops roles another company already notified
+	notifyOnChange bool                   // re-notify seen jobs whose description changed
+	notifyOnClose  bool                   // notify tracked jobs that are no longer listed
+	changeFetcher  model.JobDetailFetcher // optional: fetches descriptions for change detection
+	changeChecks   int                    // detail fetches per poll for change detection
+	changeAfter    string                 // ID of the last job change detection fetched; the next poll continues after it
+	allowedIDs     map[string]bool        // job IDs that bypass filter
+	tagger         JobTagger              // optional: labels jobs about to be notified
+	keepStatuses   []string               // posting statuses kept besides open; see KeepsPostingStatus
+	lagObserver    AlertLagObserver       // optional: records posting-to-alert lag
+	store          model.JobStore
+	notifier       model.Notifier
+	analyzer       JobAnalyzer
+	maxAge         time.Duration
+	freshnessBasis FreshnessBasis
+	lastNew        int              // unseen matches found by the most recent poll
+	lastFetched    int              // jobs the board returned on the most recent poll
+	now            func() time.Time // clock for the freshness check; time.Now outside tests
+	logger         *slog.Logger
 }
 
 // NewCompanyPoller creates a poller wired with all its dependencies.
@@ -44,41 +85,297 @@ func NewCompanyPoller(
 		notifier: notifier,
 		analyzer: analyzer,
 		maxAge:   maxAge,
+		now:      time.Now,
 		logger:   logger,
+
+		seedOnFirstRun: true,
+		freshnessBasis: FreshnessPosted,
+		changeChecks:   DefaultChangeChecks,
+	}
+}
+
+// SetSeedOnFirstRun controls first-run behaviour on an empty store. When true
+// (the default) existing matches are seeded as seen without notifying; when
+// false they go through the normal freshness → notify → mark seen path.
+func (p *CompanyPoller) SetSeedOnFirstRun(enabled bool) {
+	p.seedOnFirstRun = enabled
+}
+
+// SetFreshnessBasis sets what maxAge is measured from. FreshnessFirstSeen
+// suits boards with approximate posting dates: a job is fresh until maxAge
+// after the store first saw it, so an unseen job is always fresh and only
+// seen jobs (re-checked by notify_on_change) age out. With a store that
+// doesn't implement model.FirstSeenStore, every job counts as first seen
+// now.
+func (p *CompanyPoller) SetFreshnessBasis(b FreshnessBasis) {
+	p.freshnessBasis = b
+}
+
+// SetMaxNewPerPoll caps how many new jobs one poll analyzes and notifies, as a
+// guard against a misconfigured filter flooding the notifier. Jobs beyond the
+// cap are still marked seen so the flood does not repeat next cycle. Zero
+// disables the cap.
+func (p *CompanyPoller) SetMaxNewPerPoll(n int) {
+	p.maxNewPerPoll = n
+}
+
+// SetPostingStatuses sets the Job.PostingStatus values whose jobs are kept.
+// By default only open postings (and jobs without a status) are; a dropped
+// job is never filtered, notified or marked seen.
+func (p *CompanyPoller) SetPostingStatuses(keep []string) {
+	p.keepStatuses = keep
+}
+
+// KeepsPostingStatus reports whether job's posting status is open or in keep
+// (case-insensitive). An empty status counts as open.
+func KeepsPostingStatus(job model.Job, keep []string) bool {
+	if job.PostingStatus == "" || strings.EqualFold(job.PostingStatus, model.PostingOpen) {
+		return true
+	}
+	return slices.ContainsFunc(keep, func(s string) bool { return strings.EqualFold(s, job.PostingStatus) })
+}
+
+// SetJobIDLists sets job IDs to always drop (block) and job IDs that skip the
+// title/location filter (allow). Allowed jobs still go through freshness and
+// dedup; block wins when an ID is in both.
+func (p *CompanyPoller) SetJobIDLists(allow, block []string) {
+	p.allowedIDs = idSet(allow)
+	p.blockedIDs = idSet(block)
+}
+
+func idSet(ids []string) map[string]bool {
+	if len(ids) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// SetDeadLetter records jobs whose notification fails in dl, in addition to
+// leaving them unseen for the next poll.
+func (p *CompanyPoller) SetDeadLetter(dl *DeadLetter) {
+	p.deadLetter = dl
+}
+
+// SetCrossCompanyDedup makes the poller skip new jobs whose title and
+// location another company's poller already claimed. Skipped jobs are still
+// marked seen.
+func (p *CompanyPoller) SetCrossCompanyDedup(d *CrossCompanyDedup) {
+	p.crossDedup = d
+}
+
+// SetDetailFetcher makes the poller fetch full detail (description, pay
+// ranges) for each job it is about to notify, so alerts and AI analysis see
+// more than the listing. Each call holds a fetch semaphore slot like FetchJobs
+// does; a failed call is logged and the job is notified with what it has.
+func (p *CompanyPoller) SetDetailFetcher(df model.JobDetailFetcher) {
+	p.detailFetcher = df
+}
+
+// SetInsightsFilter installs a filter that runs on new jobs after AI analysis,
+// for criteria that depend on JobInsights (e.g. seniority). Jobs it rejects are
+// still marked seen so they are not re-analyzed on the next poll.
+func (p *CompanyPoller) SetInsightsFilter(f model.JobFilter) {
+	p.insightsFilter = f
+}
+
+// SetTagger labels each job about to be notified with t's tags, after
+// enrichment and the filters so descriptions are available and dropped jobs
+// cost nothing.
+func (p *CompanyPoller) SetTagger(t JobTagger) {
+	p.tagger = t
+}
+
+// SetFetchSemaphore installs a semaphore shared across pollers that caps how
+// many fetches run at once. Poll holds one slot for the duration of FetchJobs.
+func (p *CompanyPoller) SetFetchSemaphore(sem chan struct{}) {
+	p.fetchSem = sem
+}
+
+// fetch calls the fetcher, holding a fetch semaphore slot if one is set.
+func (p *CompanyPoller) fetch(ctx context.Context) (jobs []model.Job, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "fetch")
+	defer func() {
+		span.SetAttributes(attribute.Int("jobs.fetched", len(jobs)))
+		endSpan(span, err)
+	}()
+
+	if p.fetchSem != nil {
+		select {
+		case p.fetchSem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-p.fetchSem }()
+	}
+	return p.fetcher.FetchJobs(ctx)
+}
+
+// enrich replaces each job with its detail-endpoint version when a detail
+// fetcher is set. Jobs whose detail fetch fails are kept as they are.
+func (p *CompanyPoller) enrich(ctx context.Context, jobs []model.Job) []model.Job {
+	if p.detailFetcher == nil {
+		return jobs
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "enrich",
+		trace.WithAttributes(attribute.Int("jobs.enriching", len(jobs))))
+	defer span.End()
+
+	out := make([]model.Job, len(jobs))
+	for i, job := range jobs {
+		out[i] = job
+		detailed, err := p.fetchDetail(ctx, p.detailFetcher, job)
+		if err != nil {
+			p.logger.Warn("detail fetch failed, notifying without it", "company", p.Name, "job_id", job.ID, "error", err)
+			continue
+		}
+		out[i] = detailed
+	}
+	return out
+}
+
+// notify sends jobs inside a "notify" span and splits them into delivered
+// and undelivered by the notifier's per-job results.
+func (p *CompanyPoller) notify(ctx context.Context, jobs []model.Job) (sent, failed []model.Job, err error) {
+	_, span := otel.Tracer(tracerName).Start(ctx, "notify",
+		trace.WithAttributes(attribute.Int("jobs.notified", len(jobs))))
+	delivered, err := p.notifier.Notify(jobs)
+	for i, job := range jobs {
+		if i < len(delivered) && delivered[i] {
+			sent = append(sent, job)
+		} else {
+			failed = append(failed, job)
+		}
+	}
+	span.SetAttributes(attribute.Int("jobs.delivered", len(sent)))
+	endSpan(span, err)
+	return sent, failed, err
+}
+
+// endSpan marks span failed when err is non-nil and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
+	span.End()
 }
 
 // Poll runs one poll cycle: fetch → filter → freshness → dedup → notify → mark seen.
-// On the very first run (empty store), jobs are seeded as seen without notifying.
+// Only jobs the notifier reports delivered are marked seen; failed ones are
+// retried on the next poll.
+// On the very first run (empty store), jobs are seeded as seen without notifying
+// unless seeding is disabled via SetSeedOnFirstRun.
 func (p *CompanyPoller) Poll(ctx context.Context) error {
-	firstRun, err := p.store.IsEmpty()
-	if err != nil {
-		return fmt.Errorf("polling %s: checking if first run: %w", p.Name, err)
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "poll", trace.WithAttributes(
+		attribute.String("company", p.Name),
+		attribute.String("ats", p.ATS),
+	))
+	err := p.poll(ctx, span)
+	endSpan(span, err)
+	return err
+}
+
+// stampFirstSeen sets each job's FirstSeen to when the store first saw it,
+// or to now for a job it hasn't seen.
+func (p *CompanyPoller) stampFirstSeen(jobs []model.Job, now time.Time) error {
+	var seen map[string]time.Time
+	if fs, ok := p.store.(model.FirstSeenStore); ok && len(jobs) > 0 {
+		ids := make([]string, len(jobs))
+		for i, job := range jobs {
+			ids[i] = job.ID
+		}
+		var err error
+		if seen, err = fs.FirstSeen(ids); err != nil {
+			return err
+		}
+	}
+	for i := range jobs {
+		if t, ok := seen[jobs[i].ID]; ok {
+			jobs[i].FirstSeen = t
+		} else {
+			jobs[i].FirstSeen = now
+		}
+	}
+	return nil
+}
+
+// stale reports whether job is older than maxAge at now, measured from the
+// freshness basis. Under FreshnessPosted a job without PostedAt is never
+// stale.
+func (p *CompanyPoller) stale(job model.Job, now time.Time) bool {
+	cutoff := now.Add(-p.maxAge)
+	if p.freshnessBasis == FreshnessFirstSeen {
+		return job.FirstSeen.Before(cutoff)
+	}
+	return job.PostedAt != nil && job.PostedAt.Before(cutoff)
+}
+
+// LastNewCount returns how many unseen matching jobs the most recent Poll
+// found; a first-run seed counts as zero. Call it from the goroutine that
+// runs Poll.
+func (p *CompanyPoller) LastNewCount() int {
+	return p.lastNew
+}
+
+// LastFetchedCount returns how many jobs the board returned on the most
+// recent Poll, before any filtering; zero when the fetch failed. Call it from
+// the goroutine that runs Poll.
+func (p *CompanyPoller) LastFetchedCount() int {
+	return p.lastFetched
+}
+
+func (p *CompanyPoller) poll(ctx context.Context, span trace.Span) error {
+	p.lastNew = 0
+	p.lastFetched = 0
+	firstRun := false
+	if p.seedOnFirstRun {
+		empty, err := p.store.IsEmpty()
+		if err != nil {
+			return fmt.Errorf("polling %s: checking if first run: %w", p.Name, err)
+		}
+		firstRun = empty
 	}
 
-	jobs, err := p.fetcher.FetchJobs(ctx)
+	jobs, err := p.fetch(ctx)
 	if err != nil {
 		return fmt.Errorf("polling %s: %w", p.Name, err)
 	}
+	p.lastFetched = len(jobs)
 
 	p.logger.Debug("fetched jobs from API",
 		"company", p.Name,
 		"total", len(jobs),
 	)
 
-	now := time.Now()
+	now := p.now()
 
-	var matched []model.Job
-	var filteredOut, staleOut int
+	var candidates []model.Job
+	var closedOut, filteredOut, staleOut int
 	for _, job := range jobs {
-		if !p.filter.Match(job) {
+		if !KeepsPostingStatus(job, p.keepStatuses) {
+			closedOut++
+			continue
+		}
+		if p.blockedIDs[job.ID] || (!p.allowedIDs[job.ID] && !p.filter.Match(job)) {
 			filteredOut++
 			continue
 		}
-		// Freshness check: skip jobs posted more than maxAge ago.
+		candidates = append(candidates, job)
+	}
+	if err := p.stampFirstSeen(candidates, now); err != nil {
+		return fmt.Errorf("polling %s: reading first seen: %w", p.Name, err)
+	}
+
+	var matched []model.Job
+	for _, job := range candidates {
+		// Freshness check: skip jobs older than maxAge.
 		// Skip on first run — we need to seed all matching jobs so future
 		// polls can detect new ones by comparison.
-		if !firstRun && job.PostedAt != nil && job.PostedAt.Before(now.Add(-p.maxAge)) {
+		if !firstRun && p.stale(job, now) {
 			staleOut++
 			continue
 		}
@@ -88,12 +385,13 @@ func (p *CompanyPoller) Poll(ctx context.Context) error {
 	p.logger.Debug("filter pipeline results",
 		"company", p.Name,
 		"fetched", len(jobs),
+		"not_open", closedOut,
 		"filtered_out", filteredOut,
 		"stale_out", staleOut,
 		"matched", len(matched),
 	)
 
-	var newJobs []model.Job
+	var newJobs, seenJobs []model.Job
 	for _, job := range matched {
 		seen, err := p.store.HasSeen(job.ID)
 		if err != nil {
@@ -101,15 +399,22 @@ func (p *CompanyPoller) Poll(ctx context.Context) error {
 		}
 		if !seen {
 			newJobs = append(newJobs, job)
+		} else if p.notifyOnChange {
+			seenJobs = append(seenJobs, job)
 		}
 	}
 
+	span.SetAttributes(
+		attribute.Int("jobs.fetched", len(jobs)),
+		attribute.Int("jobs.matched", len(matched)),
+		attribute.Int("jobs.new", len(newJobs)),
+		attribute.Bool("first_run", firstRun),
+	)
+
 	// First-run suppression: seed the store without notifying.
 	if firstRun {
-		for _, job := range newJobs {
-			if err := p.store.MarkSeen(job.ID); err != nil {
-				return fmt.Errorf("polling %s: seeding seen: %w", p.Name, err)
-			}
+		if err := p.markSeen(newJobs); err != nil {
+			return fmt.Errorf("polling %s: seeding seen: %w", p.Name, err)
 		}
 		p.logger.Info("initial seed: marked existing jobs as seen",
 			"company", p.Name,
@@ -118,9 +423,56 @@ func (p *CompanyPoller) Poll(ctx context.Context) error {
 		return nil
 	}
 
-	if len(newJobs) > 0 {
-		enriched := make([]model.Job, 0, len(newJobs))
-		for _, job := range newJobs {
+	p.lastNew = len(newJobs)
+	toNotify := newJobs
+	if p.crossDedup != nil && len(toNotify) > 0 {
+		var dups []model.Job
+		toNotify, dups, err = p.crossDedup.Claim(p.Name, toNotify)
+		if err != nil {
+			return fmt.Errorf("polling %s: cross-company dedup: %w", p.Name, err)
+		}
+		if len(dups) > 0 {
+			p.logger.Info("skipped roles already notified for another company",
+				"company", p.Name,
+				"duplicates", len(dups),
+			)
+		}
+	}
+	if p.maxNewPerPoll > 0 && len(toNotify) > p.maxNewPerPoll {
+		p.logger.Warn("new jobs exceed max_new_per_poll, notifying only the first batch",
+			"company", p.Name,
+			"new", len(toNotify),
+			"max_new_per_poll", p.maxNewPerPoll,
+			"suppressed", len(toNotify)-p.maxNewPerPoll,
+		)
+		toNotify = toNotify[:p.maxNewPerPoll]
+	}
+
+	var changed []model.Job
+	var changedHashes map[string]string
+	if p.notifyOnChange {
+		changed, changedHashes, err = p.detectChanges(ctx, seenJobs)
+		if err != nil {
+			return fmt.Errorf("polling %s: detecting description changes: %w", p.Name, err)
+		}
+		if len(changed) > 0 {
+			p.logger.Info("job descriptions changed, re-notifying",
+				"company", p.Name,
+				"changed", len(changed),
+			)
+		}
+		// Re-notifications share the per-poll cap with new jobs.
+		if p.maxNewPerPoll > 0 && len(toNotify)+len(changed) > p.maxNewPerPoll {
+			changed = changed[:max(p.maxNewPerPoll-len(toNotify), 0)]
+		}
+	}
+
+	var undelivered []model.Job
+	var notifyErr error
+	if len(toNotify) > 0 || len(changed) > 0 {
+		// Changed jobs already carry the description they were compared on.
+		enriched := make([]model.Job, 0, len(toNotify)+len(changed))
+		for _, job := range append(p.enrich(ctx, toNotify), changed...) {
 			analysed, err := p.analyzer.Analyze(ctx, job)
 			if err != nil {
 				p.logger.Warn("ai analysis failed", "company", p.Name, "job_id", job.ID, "error", err)
@@ -129,16 +481,66 @@ func (p *CompanyPoller) Poll(ctx context.Context) error {
 				enriched = append(enriched, analysed)
 			}
 		}
-		if err := p.notifier.Notify(enriched); err != nil {
-			return fmt.Errorf("polling %s: notifying: %w", p.Name, err)
+		if p.insightsFilter != nil {
+			kept := enriched[:0]
+			for _, job := range enriched {
+				if p.insightsFilter.Match(job) {
+					kept = append(kept, job)
+				}
+			}
+			if dropped := len(enriched) - len(kept); dropped > 0 {
+				p.logger.Debug("insights filter dropped jobs", "company", p.Name, "dropped", dropped)
+			}
+			enriched = kept
+		}
+		if p.tagger != nil {
+			for i := range enriched {
+				enriched[i].Tags = p.tagger.Tag(enriched[i])
+			}
+		}
+		if len(enriched) > 0 {
+			var sent []model.Job
+			sent, undelivered, notifyErr = p.notify(ctx, enriched)
+			p.recordAlertLag(sent, changed)
+			if p.crossDedup != nil {
+				if err := p.crossDedup.Record(p.Name, sent); err != nil {
+					p.logger.Warn("recording cross-company dedup keys failed", "company", p.Name, "error", err)
+				}
+			}
+			if rec, ok := p.store.(model.MatchRecorder); ok {
+				if err := rec.RecordMatches(sent); err != nil {
+					p.logger.Warn("recording matches failed", "company", p.Name, "error", err)
+				}
+			}
 		}
 	}
 
-	for _, job := range newJobs {
-		if err := p.store.MarkSeen(job.ID); err != nil {
-			return fmt.Errorf("polling %s: marking seen: %w", p.Name, err)
+	// Jobs the notifier failed to deliver stay unseen so the next poll retries
+	// them; everything else (delivered, filtered by insights, over the cap) is done.
+	if err := p.markSeen(without(newJobs, undelivered)); err != nil {
+		return fmt.Errorf("polling %s: marking seen: %w", p.Name, err)
+	}
+	// Likewise an undelivered description change keeps its old hash.
+	if len(changedHashes) > 0 {
+		p.storeChangedHashes(changedHashes, undelivered)
+	}
+	if p.deadLetter != nil {
+		if err := p.deadLetter.Write(p.Name, undelivered, notifyErr); err != nil {
+			p.logger.Warn("dead-letter write failed", "company", p.Name, "error", err)
 		}
 	}
+	if notifyErr != nil {
+		return fmt.Errorf("polling %s: notifying: %w", p.Name, notifyErr)
+	}
+	if p.notifyOnClose {
+		p.notifyClosed(ctx, jobs)
+	}
+	if len(undelivered) > 0 {
+		p.logger.Warn("some notifications failed, will retry next poll",
+			"company", p.Name,
+			"undelivered", len(undelivered),
+		)
+	}
 
 	p.logger.Info("polled company",
 		"ats", p.ATS,
@@ -150,3 +552,38 @@ func (p *CompanyPoller) Poll(ctx context.Context) error {
 
 	return nil
 }
+
+// without returns jobs minus those whose ID appears in drop, preserving order.
+func without(jobs, drop []model.Job) []model.Job {
+	if len(drop) == 0 {
+		return jobs
+	}
+	skip := make(map[string]bool, len(drop))
+	for _, j := range drop {
+		skip[j.ID] = true
+	}
+	var kept []model.Job
+	for _, j := range jobs {
+		if !skip[j.ID] {
+			kept = append(kept, j)
+		}
+	}
+	return kept
+}
+
+// markSeen records jobs as seen, keeping their source when the store supports it.
+func (p *CompanyPoller) markSeen(jobs []model.Job) error {
+	if rec, ok := p.store.(model.SourceRecorder); ok {
+		return rec.MarkSeenJobs(jobs)
+	}
+	return p.store.MarkSeenBatch(jobIDs(jobs))
+}
+
+// jobIDs returns the IDs of jobs, in order.
+func jobIDs(jobs []model.Job) []string {
+	ids := make([]string, len(jobs))
+	for i, j := range jobs {
+		ids[i] = j.ID
+	}
+	return ids
+}
@@ -3,12 +3,15 @@ package poller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/store"
 )
 
 // --- Mock/Fake Implementations ---
@@ -23,38 +26,58 @@ func (m *MockFetcher) FetchJobs(_ context.Context) ([]model.Job, error) {
 	return m.Jobs, m.Err
 }
 
-// InMemoryStore is a map-based store for testing dedup.
-type InMemoryStore struct {
-	seen map[string]bool
-}
-
-func NewInMemoryStore() *InMemoryStore {
-	return &InMemoryStore{seen: make(map[string]bool)}
+// RecordingNotifier records which jobs were sent to Notify.
+type RecordingNotifier struct {
+	Notified []model.Job
 }
 
-func (s *InMemoryStore) HasSeen(jobID string) (bool, error) {
-	return s.seen[jobID], nil
+func (n *RecordingNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	n.Notified = append(n.Notified, jobs...)
+	delivered := make([]bool, len(jobs))
+	for i := range delivered {
+		delivered[i] = true
+	}
+	return delivered, nil
 }
 
-func (s *InMemoryStore) MarkSeen(jobID string) error {
-	s.seen[jobID] = true
-	return nil
+// FlakyNotifier fails delivery for the job IDs in Fail and records the rest.
+// Like the real notifiers it errors only when every delivery fails.
+type FlakyNotifier struct {
+	Fail      map[string]bool
+	Delivered []model.Job
 }
 
-func (s *InMemoryStore) Cleanup(_ time.Duration) error { return nil }
-
-func (s *InMemoryStore) IsEmpty() (bool, error) {
-	return len(s.seen) == 0, nil
+func (n *FlakyNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	delivered := make([]bool, len(jobs))
+	for i, j := range jobs {
+		if !n.Fail[j.ID] {
+			delivered[i] = true
+			n.Delivered = append(n.Delivered, j)
+		}
+	}
+	if len(n.Delivered) == 0 {
+		return delivered, errors.New("all notifications failed")
+	}
+	return delivered, nil
 }
 
-// RecordingNotifier records which jobs were sent to Notify.
-type RecordingNotifier struct {
-	Notified []model.Job
+// DetailFetcher adds Pay to each job's detail, or fails for IDs in Fail.
+// IDs records the jobs asked for, in order.
+type DetailFetcher struct {
+	Pay   model.PayRange
+	Fail  map[string]bool
+	Calls int
+	IDs   []string
 }
 
-func (n *RecordingNotifier) Notify(jobs []model.Job) error {
-	n.Notified = append(n.Notified, jobs...)
-	return nil
+func (d *DetailFetcher) FetchJobDetail(_ context.Context, job model.Job) (model.Job, error) {
+	d.Calls++
+	d.IDs = append(d.IDs, job.ID)
+	if d.Fail[job.ID] {
+		return job, errors.New("detail endpoint returned 500")
+	}
+	job.Detail = &model.JobDetail{PayRanges: []model.PayRange{d.Pay}}
+	return job, nil
 }
 
 // AcceptAllFilter matches every job.
@@ -100,8 +123,8 @@ func makeJobs(ids ...string) []model.Job {
 func timePtr(t time.Time) *time.Time { return &t }
 
 // nonEmptyStore returns a store with a dummy entry so it is not treated as a first run.
-func nonEmptyStore() *InMemoryStore {
-	s := NewInMemoryStore()
+func nonEmptyStore() *store.InMemoryStore {
+	s := store.NewInMemoryStore()
 	s.MarkSeen("__seed__")
 	return s
 }
@@ -110,7 +133,7 @@ func nonEmptyStore() *InMemoryStore {
 
 func TestPoll_FilterAndDedup(t *testing.T) {
 	// 5 fetched, filter accepts all, store has seen "2" → notifier gets 4, store marks 4.
-	store := NewInMemoryStore()
+	store := store.NewInMemoryStore()
 	store.MarkSeen("2")
 
 	notifier := &RecordingNotifier{}
@@ -149,7 +172,7 @@ func TestPoll_FetchError(t *testing.T) {
 		"greenhouse",
 		&MockFetcher{Err: errors.New("network down")},
 		&AcceptAllFilter{},
-		NewInMemoryStore(),
+		store.NewInMemoryStore(),
 		notifier,
 		&NopAnalyzer{},
 		time.Hour,
@@ -167,7 +190,7 @@ func TestPoll_FetchError(t *testing.T) {
 }
 
 func TestPoll_AllAlreadySeen(t *testing.T) {
-	store := NewInMemoryStore()
+	store := store.NewInMemoryStore()
 	store.MarkSeen("1")
 	store.MarkSeen("2")
 
@@ -217,8 +240,9 @@ func TestPoll_FilterRejectsAll(t *testing.T) {
 }
 
 func TestPoll_FreshnessSkipsOldJobs(t *testing.T) {
-	twoHoursAgo := timePtr(time.Now().Add(-2 * time.Hour))
-	fiveMinAgo := timePtr(time.Now().Add(-5 * time.Minute))
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	twoHoursAgo := timePtr(now.Add(-2 * time.Hour))
+	fiveMinAgo := timePtr(now.Add(-5 * time.Minute))
 
 	jobs := []model.Job{
 		{ID: "old", Company: "testco", Title: "Software Engineer", Location: "US", PostedAt: twoHoursAgo, Source: "test"},
@@ -237,6 +261,7 @@ func TestPoll_FreshnessSkipsOldJobs(t *testing.T) {
 		time.Hour,
 		discardLogger(),
 	)
+	poller.now = func() time.Time { return now }
 
 	if err := poller.Poll(context.Background()); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -250,6 +275,35 @@ func TestPoll_FreshnessSkipsOldJobs(t *testing.T) {
 	}
 }
 
+func TestPoll_FreshnessBoundary(t *testing.T) {
+	now := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC) // midnight UTC
+	jobs := []model.Job{
+		{ID: "at-cutoff", Company: "testco", Title: "Software Engineer", Location: "US", PostedAt: timePtr(now.Add(-time.Hour)), Source: "test"},
+		{ID: "past-cutoff", Company: "testco", Title: "Software Engineer", Location: "US", PostedAt: timePtr(now.Add(-time.Hour - time.Second)), Source: "test"},
+	}
+
+	notifier := &RecordingNotifier{}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: jobs},
+		&AcceptAllFilter{},
+		nonEmptyStore(),
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.now = func() time.Time { return now }
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := jobIDs(notifier.Notified); len(got) != 1 || got[0] != "at-cutoff" {
+		t.Errorf("notified = %v, want [at-cutoff] (a job exactly max_age old is still fresh)", got)
+	}
+}
+
 func TestPoll_NilPostedAtPassesThrough(t *testing.T) {
 	jobs := []model.Job{
 		{ID: "no-ts", Company: "testco", Title: "Software Engineer", Location: "US", PostedAt: nil, Source: "test"},
@@ -280,8 +334,98 @@ func TestPoll_NilPostedAtPassesThrough(t *testing.T) {
 	}
 }
 
+func TestPoll_FreshnessBasis(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	jobs := []model.Job{
+		{ID: "old", Company: "testco", Title: "Software Engineer", Location: "US", PostedAt: timePtr(now.Add(-2 * time.Hour)), Source: "test"},
+		{ID: "fresh", Company: "testco", Title: "Software Engineer", Location: "US", PostedAt: timePtr(now.Add(-5 * time.Minute)), Source: "test"},
+		{ID: "no-ts", Company: "testco", Title: "Software Engineer", Location: "US", Source: "test"},
+	}
+
+	tests := []struct {
+		basis FreshnessBasis
+		want  []string
+	}{
+		{FreshnessPosted, []string{"fresh", "no-ts"}},
+		// Unseen jobs are first seen now, however old their posting date.
+		{FreshnessFirstSeen, []string{"old", "fresh", "no-ts"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.basis), func(t *testing.T) {
+			notifier := &RecordingNotifier{}
+			poller := NewCompanyPoller("testco", "greenhouse", &MockFetcher{Jobs: jobs}, &AcceptAllFilter{}, nonEmptyStore(), notifier, &NopAnalyzer{}, time.Hour, discardLogger())
+			poller.now = func() time.Time { return now }
+			poller.SetFreshnessBasis(tt.basis)
+
+			if err := poller.Poll(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := jobIDs(notifier.Notified); !slices.Equal(got, tt.want) {
+				t.Errorf("notified = %v, want %v", got, tt.want)
+			}
+			for _, j := range notifier.Notified {
+				if !j.FirstSeen.Equal(now) {
+					t.Errorf("job %s FirstSeen = %v, want the poll time %v", j.ID, j.FirstSeen, now)
+				}
+			}
+		})
+	}
+}
+
+// FirstSeenStore is an in-memory store reporting fixed first-seen times.
+type FirstSeenStore struct {
+	*store.InMemoryStore
+	At map[string]time.Time
+}
+
+func (s FirstSeenStore) FirstSeen(jobIDs []string) (map[string]time.Time, error) {
+	seen := make(map[string]time.Time)
+	for _, id := range jobIDs {
+		if t, ok := s.At[id]; ok {
+			seen[id] = t
+		}
+	}
+	return seen, nil
+}
+
+func TestPoll_FirstSeenBasisAgesOutSeenJobs(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	job := func(id, desc string) model.Job {
+		// Posting dates say both are brand new; only first seen differs.
+		return model.Job{ID: id, Company: "testco", Title: "Software Engineer", Location: "US", Source: "test",
+			PostedAt: timePtr(now), Detail: &model.JobDetail{Description: desc}}
+	}
+	st := FirstSeenStore{InMemoryStore: nonEmptyStore(), At: map[string]time.Time{
+		"recent": now.Add(-10 * time.Minute),
+		"old":    now.Add(-2 * time.Hour),
+	}}
+	st.MarkSeenBatch([]string{"recent", "old"})
+	fetcher := &MockFetcher{Jobs: []model.Job{job("recent", "v1"), job("old", "v1")}}
+	notifier := &RecordingNotifier{}
+	poller := NewCompanyPoller("testco", "greenhouse", fetcher, &AcceptAllFilter{}, st, notifier, &NopAnalyzer{}, time.Hour, discardLogger())
+	poller.now = func() time.Time { return now }
+	poller.SetFreshnessBasis(FreshnessFirstSeen)
+	poller.SetNotifyOnChange(true, nil)
+
+	// Baseline, then both descriptions change: only the job first seen
+	// within max_age is still fresh enough to re-notify.
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fetcher.Jobs = []model.Job{job("recent", "v2"), job("old", "v2")}
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := jobIDs(notifier.Notified); len(got) != 1 || got[0] != "recent" {
+		t.Fatalf("notified = %v, want [recent]", got)
+	}
+	if got := notifier.Notified[0].FirstSeen; !got.Equal(st.At["recent"]) {
+		t.Errorf("FirstSeen = %v, want the store's %v", got, st.At["recent"])
+	}
+}
+
 func TestPoll_FirstRunSeedsWithoutNotifying(t *testing.T) {
-	store := NewInMemoryStore() // empty = first run
+	store := store.NewInMemoryStore() // empty = first run
 
 	notifier := &RecordingNotifier{}
 	poller := NewCompanyPoller(
@@ -311,3 +455,525 @@ func TestPoll_FirstRunSeedsWithoutNotifying(t *testing.T) {
 		}
 	}
 }
+
+func TestPoll_NoSeedNotifiesOnEmptyStore(t *testing.T) {
+	store := store.NewInMemoryStore() // empty = first run
+
+	notifier := &RecordingNotifier{}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2", "3")},
+		&AcceptAllFilter{},
+		store,
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.SetSeedOnFirstRun(false)
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(notifier.Notified) != 3 {
+		t.Errorf("notified %d jobs, want 3 (seeding disabled)", len(notifier.Notified))
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if seen, _ := store.HasSeen(id); !seen {
+			t.Errorf("job %s should be marked seen after notifying", id)
+		}
+	}
+}
+
+func TestPoll_MaxNewPerPollCapsNotifications(t *testing.T) {
+	store := nonEmptyStore()
+	notifier := &RecordingNotifier{}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2", "3", "4", "5")},
+		&AcceptAllFilter{},
+		store,
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.SetMaxNewPerPoll(2)
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := len(notifier.Notified); got != 2 {
+		t.Errorf("notified = %d, want 2", got)
+	}
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		if seen, _ := store.HasSeen(id); !seen {
+			t.Errorf("job %s should be marked seen even when suppressed", id)
+		}
+	}
+}
+
+func TestPoll_PartialDeliveryMarksOnlyDelivered(t *testing.T) {
+	store := nonEmptyStore()
+	notifier := &FlakyNotifier{Fail: map[string]bool{"2": true}}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2", "3")},
+		&AcceptAllFilter{},
+		store,
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error on partial delivery: %v", err)
+	}
+	for id, want := range map[string]bool{"1": true, "2": false, "3": true} {
+		if seen, _ := store.HasSeen(id); seen != want {
+			t.Errorf("job %s seen = %v, want %v", id, seen, want)
+		}
+	}
+
+	// Next poll retries only the undelivered job.
+	notifier.Fail = nil
+	notifier.Delivered = nil
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+	if len(notifier.Delivered) != 1 || notifier.Delivered[0].ID != "2" {
+		t.Errorf("retry delivered %v, want only job 2", jobIDs(notifier.Delivered))
+	}
+	if seen, _ := store.HasSeen("2"); !seen {
+		t.Error("job 2 should be marked seen after successful retry")
+	}
+}
+
+func TestPoll_TotalDeliveryFailureMarksNothing(t *testing.T) {
+	store := nonEmptyStore()
+	notifier := &FlakyNotifier{Fail: map[string]bool{"1": true, "2": true}}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2")},
+		&AcceptAllFilter{},
+		store,
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+
+	if err := poller.Poll(context.Background()); err == nil {
+		t.Fatal("expected error when every notification fails")
+	}
+	for _, id := range []string{"1", "2"} {
+		if seen, _ := store.HasSeen(id); seen {
+			t.Errorf("job %s should not be marked seen", id)
+		}
+	}
+}
+
+func TestPoll_DetailFetcherEnrichesNotifiedJobs(t *testing.T) {
+	store := nonEmptyStore()
+	notifier := &RecordingNotifier{}
+	pay := model.PayRange{MinCents: 12000000, MaxCents: 15000000, CurrencyType: "USD"}
+	details := &DetailFetcher{Pay: pay, Fail: map[string]bool{"2": true}}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2")},
+		&AcceptAllFilter{},
+		store,
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.SetDetailFetcher(details)
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if details.Calls != 2 {
+		t.Errorf("detail calls = %d, want 2", details.Calls)
+	}
+	if len(notifier.Notified) != 2 {
+		t.Fatalf("notified = %d, want 2 (a failed detail fetch still notifies)", len(notifier.Notified))
+	}
+	if d := notifier.Notified[0].Detail; d == nil || len(d.PayRanges) != 1 || d.PayRanges[0] != pay {
+		t.Errorf("job 1 detail = %+v, want the fetched pay range", d)
+	}
+	if d := notifier.Notified[1].Detail; d != nil && len(d.PayRanges) > 0 {
+		t.Errorf("job 2 detail = %+v, want it notified without pay after the failed fetch", d)
+	}
+}
+
+// IDTagger tags every job with its own ID, recording what it saw.
+type IDTagger struct {
+	Seen []model.Job
+}
+
+func (tg *IDTagger) Tag(job model.Job) []string {
+	tg.Seen = append(tg.Seen, job)
+	return []string{"tag-" + job.ID}
+}
+
+func TestPoll_TaggerLabelsNotifiedJobs(t *testing.T) {
+	notifier := &RecordingNotifier{}
+	tagger := &IDTagger{}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2")},
+		&AcceptAllFilter{},
+		nonEmptyStore(),
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.SetDetailFetcher(&DetailFetcher{})
+	poller.SetTagger(tagger)
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(notifier.Notified) != 2 {
+		t.Fatalf("notified = %d, want 2", len(notifier.Notified))
+	}
+	for _, j := range notifier.Notified {
+		if len(j.Tags) != 1 || j.Tags[0] != "tag-"+j.ID {
+			t.Errorf("job %s tags = %v, want [tag-%s]", j.ID, j.Tags, j.ID)
+		}
+	}
+	for _, j := range tagger.Seen {
+		if j.Detail == nil {
+			t.Errorf("job %s tagged before enrichment", j.ID)
+		}
+	}
+}
+
+// LagRecorder records observed alert lags.
+type LagRecorder struct {
+	Seconds []float64
+}
+
+func (r *LagRecorder) Observe(seconds float64) { r.Seconds = append(r.Seconds, seconds) }
+
+func TestPoll_RecordsAlertLag(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	jobs := makeJobs("1", "2", "3")
+	jobs[0].PostedAt = timePtr(now.Add(-90 * time.Second))
+	jobs[1].PostedAt = timePtr(now.Add(time.Minute)) // future: clamped to no lag
+	// jobs[2] has no posting date and is not observed.
+
+	lags := &LagRecorder{}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: jobs},
+		&AcceptAllFilter{},
+		nonEmptyStore(),
+		&RecordingNotifier{},
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.now = func() time.Time { return now }
+	poller.SetAlertLagObserver(lags)
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []float64{90, 0}; !slices.Equal(lags.Seconds, want) {
+		t.Errorf("observed lags = %v, want %v", lags.Seconds, want)
+	}
+}
+
+func TestPoll_NotifyOnClose(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		listed     []model.Job
+		wantClosed []string
+	}{
+		{"vanished job notified", true, makeJobs("2"), []string{"1"}},
+		{"job listed as closed notified", true, func() []model.Job {
+			jobs := makeJobs("1", "2")
+			jobs[0].PostingStatus = model.PostingClosed
+			return jobs
+		}(), []string{"1"}},
+		{"still listed", true, makeJobs("1", "2"), nil},
+		{"empty board is not a closure", true, nil, nil},
+		{"disabled", false, makeJobs("2"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewInMemoryStore()
+			fetcher := &MockFetcher{Jobs: makeJobs("1", "2")}
+			notifier := &RecordingNotifier{}
+			poller := NewCompanyPoller(
+				"testco",
+				"greenhouse",
+				fetcher,
+				&AcceptAllFilter{},
+				s,
+				notifier,
+				&NopAnalyzer{},
+				time.Hour,
+				discardLogger(),
+			)
+			poller.SetSeedOnFirstRun(false)
+			poller.SetNotifyOnClose(tt.enabled)
+
+			// The first poll notifies both jobs, which tracks them as open.
+			if err := poller.Poll(context.Background()); err != nil {
+				t.Fatalf("first poll: %v", err)
+			}
+			fetcher.Jobs = tt.listed
+			notifier.Notified = nil
+			if err := poller.Poll(context.Background()); err != nil {
+				t.Fatalf("second poll: %v", err)
+			}
+
+			var closed []string
+			for _, j := range notifier.Notified {
+				if j.PostingStatus != model.PostingClosed {
+					t.Errorf("job %s re-notified with status %q, want closed", j.ID, j.PostingStatus)
+				}
+				closed = append(closed, j.ID)
+			}
+			if !slices.Equal(closed, tt.wantClosed) {
+				t.Fatalf("closed notifications = %v, want %v", closed, tt.wantClosed)
+			}
+
+			// A closure is reported once.
+			notifier.Notified = nil
+			if err := poller.Poll(context.Background()); err != nil {
+				t.Fatalf("third poll: %v", err)
+			}
+			if len(notifier.Notified) != 0 {
+				t.Errorf("third poll notified %d jobs, want none", len(notifier.Notified))
+			}
+		})
+	}
+}
+
+func TestPoll_JobIDLists(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       model.JobFilter
+		allow, block []string
+		want         []string
+	}{
+		{"blocked dropped despite match", &AcceptAllFilter{}, nil, []string{"2"}, []string{"1", "3"}},
+		{"allowed bypasses filter", &RejectAllFilter{}, []string{"3"}, nil, []string{"3"}},
+		{"block wins over allow", &RejectAllFilter{}, []string{"1", "3"}, []string{"3"}, []string{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &RecordingNotifier{}
+			poller := NewCompanyPoller(
+				"testco",
+				"greenhouse",
+				&MockFetcher{Jobs: makeJobs("1", "2", "3")},
+				tt.filter,
+				nonEmptyStore(),
+				notifier,
+				&NopAnalyzer{},
+				time.Hour,
+				discardLogger(),
+			)
+			poller.SetJobIDLists(tt.allow, tt.block)
+
+			if err := poller.Poll(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := jobIDs(notifier.Notified); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("notified = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoll_PostingStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		keep []string
+		want []string
+	}{
+		{"closed postings dropped by default", nil, []string{"open", "unknown"}},
+		{"configured statuses kept", []string{"Unlisted"}, []string{"open", "unlisted", "unknown"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := makeJobs("open", "closed", "draft", "unlisted", "unknown")
+			jobs[0].PostingStatus = model.PostingOpen
+			jobs[1].PostingStatus = model.PostingClosed
+			jobs[2].PostingStatus = model.PostingDraft
+			jobs[3].PostingStatus = model.PostingUnlisted
+
+			store := nonEmptyStore()
+			notifier := &RecordingNotifier{}
+			poller := NewCompanyPoller(
+				"testco",
+				"ashby",
+				&MockFetcher{Jobs: jobs},
+				&AcceptAllFilter{},
+				store,
+				notifier,
+				&NopAnalyzer{},
+				time.Hour,
+				discardLogger(),
+			)
+			poller.SetPostingStatuses(tt.keep)
+
+			if err := poller.Poll(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := jobIDs(notifier.Notified); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("notified = %v, want %v", got, tt.want)
+			}
+			if seen, _ := store.HasSeen("closed"); seen {
+				t.Error("closed posting marked seen, want it left unseen in case it reopens")
+			}
+		})
+	}
+}
+
+func TestPoll_AllowedJobStillDeduped(t *testing.T) {
+	store := nonEmptyStore()
+	store.MarkSeen("1")
+	notifier := &RecordingNotifier{}
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1")},
+		&RejectAllFilter{},
+		store,
+		notifier,
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	poller.SetJobIDLists([]string{"1"}, nil)
+
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.Notified) != 0 {
+		t.Errorf("notified = %v, want none for an already-seen allowed job", jobIDs(notifier.Notified))
+	}
+}
+
+func TestPoll_PerCompanyFreshnessWindows(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	threeHoursAgo := timePtr(now.Add(-3 * time.Hour))
+	jobs := []model.Job{{ID: "3h", Company: "testco", Title: "Software Engineer", Location: "US", PostedAt: threeHoursAgo, Source: "test"}}
+
+	tests := []struct {
+		company    string
+		maxAge     time.Duration
+		wantNotify int
+	}{
+		{"firehose", time.Hour, 0},
+		{"slowco", 24 * time.Hour, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.company, func(t *testing.T) {
+			notifier := &RecordingNotifier{}
+			poller := NewCompanyPoller(
+				tt.company,
+				"greenhouse",
+				&MockFetcher{Jobs: jobs},
+				&AcceptAllFilter{},
+				nonEmptyStore(),
+				notifier,
+				&NopAnalyzer{},
+				tt.maxAge,
+				discardLogger(),
+			)
+			poller.now = func() time.Time { return now }
+			if err := poller.Poll(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(notifier.Notified) != tt.wantNotify {
+				t.Errorf("notified = %d, want %d with max_age %v", len(notifier.Notified), tt.wantNotify, tt.maxAge)
+			}
+		})
+	}
+}
+
+func TestPoll_NotifyOnChange(t *testing.T) {
+	job := func(id, desc string) model.Job {
+		return model.Job{ID: id, Company: "testco", Title: "Software Engineer", Location: "US", Source: "test",
+			Detail: &model.JobDetail{Description: desc}}
+	}
+	st := nonEmptyStore()
+	st.MarkSeenBatch([]string{"changing", "steady"})
+	fetcher := &MockFetcher{Jobs: []model.Job{job("changing", "Pay: $100k"), job("steady", "Build things.")}}
+	notifier := &RecordingNotifier{}
+	poller := NewCompanyPoller("testco", "greenhouse", fetcher, &AcceptAllFilter{}, st, notifier, &NopAnalyzer{}, time.Hour, discardLogger())
+	poller.SetNotifyOnChange(true, nil)
+
+	// First poll only records the baseline hashes.
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.Notified) != 0 {
+		t.Fatalf("notified = %v on baseline poll, want none", jobIDs(notifier.Notified))
+	}
+
+	// The pay changes; whitespace-only edits don't count.
+	fetcher.Jobs = []model.Job{job("changing", "Pay: $150k"), job("steady", "Build   things.\n")}
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := jobIDs(notifier.Notified); len(got) != 1 || got[0] != "changing" {
+		t.Fatalf("notified = %v, want [changing]", got)
+	}
+	if got := notifier.Notified[0].Detail.Description; got != "Pay: $150k" {
+		t.Errorf("notified description = %q, want the new one", got)
+	}
+
+	// The new description is now the baseline.
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.Notified) != 1 {
+		t.Errorf("notified = %v after re-poll, want no repeat", jobIDs(notifier.Notified))
+	}
+}
+
+func TestPoll_NotifyOnChangeChecksAFewJobsPerPoll(t *testing.T) {
+	st := nonEmptyStore()
+	ids := []string{"e", "c", "a", "d", "b"}
+	st.MarkSeenBatch(ids)
+	// The listings carry no description, so each check costs a detail fetch.
+	details := &DetailFetcher{}
+	poller := NewCompanyPoller("testco", "greenhouse", &MockFetcher{Jobs: makeJobs(ids...)}, &AcceptAllFilter{}, st,
+		&RecordingNotifier{}, &NopAnalyzer{}, time.Hour, discardLogger())
+	poller.SetNotifyOnChange(true, details)
+	poller.SetChangeChecks(2)
+
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e", "a"}}
+	for i, w := range want {
+		details.IDs = nil
+		if err := poller.Poll(context.Background()); err != nil {
+			t.Fatalf("poll %d: unexpected error: %v", i+1, err)
+		}
+		if !slices.Equal(details.IDs, w) {
+			t.Errorf("poll %d fetched details of %v, want %v", i+1, details.IDs, w)
+		}
+	}
+}
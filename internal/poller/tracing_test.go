@@ -0,0 +1,72 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPoll_SpanHierarchy(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	poller := NewCompanyPoller(
+		"testco",
+		"greenhouse",
+		&MockFetcher{Jobs: makeJobs("1", "2", "3")},
+		&AcceptAllFilter{},
+		nonEmptyStore(),
+		&RecordingNotifier{},
+		&NopAnalyzer{},
+		time.Hour,
+		discardLogger(),
+	)
+	if err := poller.Poll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub)
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want poll, fetch, notify", len(spans))
+	}
+
+	poll := byName["poll"]
+	if poll.Parent.IsValid() {
+		t.Error("poll span should be a root span")
+	}
+	for _, child := range []string{"fetch", "notify"} {
+		s, ok := byName[child]
+		if !ok {
+			t.Fatalf("missing %s span", child)
+		}
+		if s.Parent.SpanID() != poll.SpanContext.SpanID() {
+			t.Errorf("%s span parent = %v, want poll span", child, s.Parent.SpanID())
+		}
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range poll.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if got := attrs["company"].AsString(); got != "testco" {
+		t.Errorf("company = %q, want testco", got)
+	}
+	if got := attrs["ats"].AsString(); got != "greenhouse" {
+		t.Errorf("ats = %q, want greenhouse", got)
+	}
+	if got := attrs["jobs.new"].AsInt64(); got != 3 {
+		t.Errorf("jobs.new = %d, want 3", got)
+	}
+}
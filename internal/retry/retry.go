@@ -6,18 +6,41 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/amishk599/firstin/internal/model"
 )
 
+// DefaultMaxRetryAfter is the longest Retry-After honoured unless configured
+// otherwise; a longer one waits this long instead.
+const DefaultMaxRetryAfter = 60 * time.Second
+
 // RetryFetcher is a decorator that retries transient failures with exponential
 // backoff and jitter before delegating to the wrapped JobFetcher.
 type RetryFetcher struct {
-	inner      model.JobFetcher
-	maxRetries int
-	baseDelay  time.Duration
-	logger     *slog.Logger
+	inner         model.JobFetcher
+	maxRetries    int
+	baseDelay     time.Duration
+	maxRetryAfter time.Duration
+	logger        *slog.Logger
+
+	attempts  atomic.Int64
+	retries   atomic.Int64
+	recovered atomic.Int64
+	giveUps   atomic.Int64
+}
+
+// Stats are cumulative counters for a RetryFetcher. A board that is "slow but
+// recovering" shows Recovered growing; one that is consistently failing shows GiveUps.
+type Stats struct {
+	Attempts  int64 // calls to the wrapped fetcher, including retries
+	Retries   int64 // attempts after the first within a FetchJobs call
+	Recovered int64 // FetchJobs calls that succeeded only after retrying
+	GiveUps   int64 // FetchJobs calls that still failed transiently after maxRetries
 }
 
 // NewRetryFetcher wraps a JobFetcher with retry logic.
@@ -25,65 +48,125 @@ type RetryFetcher struct {
 // baseDelay is the delay before the first retry (default: 5s), doubled on each subsequent retry.
 func NewRetryFetcher(inner model.JobFetcher, maxRetries int, baseDelay time.Duration, logger *slog.Logger) *RetryFetcher {
 	return &RetryFetcher{
-		inner:      inner,
-		maxRetries: maxRetries,
-		baseDelay:  baseDelay,
-		logger:     logger,
+		inner:         inner,
+		maxRetries:    maxRetries,
+		baseDelay:     baseDelay,
+		maxRetryAfter: DefaultMaxRetryAfter,
+		logger:        logger,
+	}
+}
+
+// SetMaxRetryAfter caps the Retry-After the fetcher waits for, so a server
+// asking for an hour can't stall a poll cycle. Non-positive values are
+// ignored.
+func (f *RetryFetcher) SetMaxRetryAfter(d time.Duration) {
+	if d > 0 {
+		f.maxRetryAfter = d
 	}
 }
 
 // FetchJobs attempts to fetch jobs, retrying on transient errors.
 func (f *RetryFetcher) FetchJobs(ctx context.Context) ([]model.Job, error) {
-	jobs, err := f.inner.FetchJobs(ctx)
+	var jobs []model.Job
+	calls := 0
+	err := do(ctx, f.maxRetries, f.baseDelay, f.maxRetryAfter, f.logger, func(ctx context.Context) error {
+		calls++
+		f.attempts.Add(1)
+		if calls > 1 {
+			f.retries.Add(1)
+		}
+		var err error
+		jobs, err = f.inner.FetchJobs(ctx)
+		return err
+	})
+	if err != nil {
+		if calls > f.maxRetries && isRetryable(err) {
+			f.logger.Warn("retries exhausted, giving up",
+				"attempts", calls,
+				"give_ups_total", f.giveUps.Add(1),
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+	if calls > 1 {
+		f.recovered.Add(1)
+	}
+	return jobs, nil
+}
+
+// Stats returns a snapshot of the fetcher's retry counters.
+func (f *RetryFetcher) Stats() Stats {
+	return Stats{
+		Attempts:  f.attempts.Load(),
+		Retries:   f.retries.Load(),
+		Recovered: f.recovered.Load(),
+		GiveUps:   f.giveUps.Load(),
+	}
+}
+
+// Do calls fn and retries it up to maxRetries more times on
+// *model.NetworkError, HTTP 429 and 5xx responses, with exponential backoff
+// from baseDelay and ±30% jitter; a Retry-After on a *model.HTTPError
+// overrides the delay, up to DefaultMaxRetryAfter. Context errors,
+// model.ErrResponseTooLarge, *model.DecodeError and other 4xx responses are
+// returned at once. Unclassified errors are retried too (see isRetryable).
+func Do(ctx context.Context, maxRetries int, baseDelay time.Duration, logger *slog.Logger, fn func(ctx context.Context) error) error {
+	return do(ctx, maxRetries, baseDelay, DefaultMaxRetryAfter, logger, fn)
+}
+
+func do(ctx context.Context, maxRetries int, baseDelay, maxRetryAfter time.Duration, logger *slog.Logger, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
 	if err == nil {
-		return jobs, nil
+		return nil
 	}
 
 	if !isRetryable(err) {
-		return nil, err
+		return err
 	}
 
-	var lastErr error = err
-	for attempt := 1; attempt <= f.maxRetries; attempt++ {
-		delay := f.backoffDelay(attempt, lastErr)
+	lastErr := err
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		delay := backoffDelay(baseDelay, maxRetryAfter, attempt, lastErr)
 
-		f.logger.Warn("retrying after transient error",
+		logger.Warn("retrying after transient error",
 			"attempt", attempt,
-			"max_retries", f.maxRetries,
+			"max_retries", maxRetries,
 			"delay", delay,
 			"error", lastErr,
 		)
 
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("retry cancelled: %w", ctx.Err())
+			return fmt.Errorf("retry cancelled: %w", ctx.Err())
 		case <-time.After(delay):
 		}
 
-		jobs, err = f.inner.FetchJobs(ctx)
+		err = fn(ctx)
 		if err == nil {
-			return jobs, nil
+			return nil
 		}
 
 		if !isRetryable(err) {
-			return nil, err
+			return err
 		}
 		lastErr = err
 	}
 
-	return nil, lastErr
+	return lastErr
 }
 
 // backoffDelay computes the delay for a given attempt with ±30% jitter.
-// If the error includes a Retry-After duration (HTTP 429), that takes precedence.
-func (f *RetryFetcher) backoffDelay(attempt int, err error) time.Duration {
+// If the error includes a Retry-After duration (HTTP 429), that takes
+// precedence, capped at maxRetryAfter.
+func backoffDelay(baseDelay, maxRetryAfter time.Duration, attempt int, err error) time.Duration {
 	var httpErr *model.HTTPError
 	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
-		return httpErr.RetryAfter
+		return min(httpErr.RetryAfter, maxRetryAfter)
 	}
 
 	// Exponential: baseDelay * 2^(attempt-1)
-	delay := f.baseDelay
+	delay := baseDelay
 	for i := 1; i < attempt; i++ {
 		delay *= 2
 	}
@@ -96,6 +179,9 @@ func (f *RetryFetcher) backoffDelay(attempt int, err error) time.Duration {
 }
 
 // isRetryable returns true if the error represents a transient failure worth retrying.
+// Errors no adapter has classified are retried on purpose: a fetcher or
+// callback that returns a bare transport error is retried like one wrapped
+// in *model.NetworkError rather than giving up on the first blip.
 func isRetryable(err error) bool {
 	if err == nil {
 		return false
@@ -106,6 +192,23 @@ func isRetryable(err error) bool {
 		return false
 	}
 
+	// An oversized board will be oversized again.
+	if errors.Is(err, model.ErrResponseTooLarge) {
+		return false
+	}
+
+	// A body that arrived but would not parse will not parse next time either.
+	var decodeErr *model.DecodeError
+	if errors.As(err, &decodeErr) {
+		return false
+	}
+
+	// Network failures (DNS, reset connections, truncated bodies) — retryable.
+	var netErr *model.NetworkError
+	if errors.As(err, &netErr) {
+		return true
+	}
+
 	var httpErr *model.HTTPError
 	if errors.As(err, &httpErr) {
 		// 429 Too Many Requests — retryable.
@@ -120,6 +223,30 @@ func isRetryable(err error) bool {
 		return false
 	}
 
-	// Non-HTTP errors (network, DNS, etc.) — retryable.
+	// Any other unclassified error — retryable, deliberately (see above).
 	return true
 }
+
+// ParseRetryAfter parses a Retry-After header value into a duration. It
+// accepts both forms the spec allows: delay-seconds (e.g. "120") and an
+// HTTP-date (e.g. "Wed, 21 Oct 2025 07:28:00 GMT"), which becomes the time
+// left until that date, or zero if it has passed. Returns zero if absent or
+// unparseable.
+func ParseRetryAfter(value string) time.Duration {
+	return parseRetryAfter(value, time.Now())
+}
+
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0
+	}
+	return max(at.Sub(now), 0)
+}
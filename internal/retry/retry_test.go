@@ -3,6 +3,7 @@ package retry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"testing"
@@ -86,6 +87,69 @@ func TestRetry_DoesNotRetryOn4xx(t *testing.T) {
 	}
 }
 
+func TestRetry_DoesNotRetryOversizedResponse(t *testing.T) {
+	mock := &mockFetcher{fn: func(_ int) ([]model.Job, error) {
+		return nil, fmt.Errorf("lever fetch for acme: %w", model.ErrResponseTooLarge)
+	}}
+
+	rf := NewRetryFetcher(mock, 2, 10*time.Millisecond, discardLogger())
+	if _, err := rf.FetchJobs(context.Background()); !errors.Is(err, model.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+	if mock.calls != 1 {
+		t.Fatalf("expected 1 call (no retry), got %d", mock.calls)
+	}
+}
+
+func TestRetry_ClassifiedErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int
+	}{
+		{"decode error not retried", fmt.Errorf("lever fetch for acme: %w", &model.DecodeError{Err: errors.New("invalid character '<'")}), 1},
+		{"network error retried", fmt.Errorf("lever fetch for acme: %w", &model.NetworkError{Err: errors.New("connection reset by peer")}), 3},
+		{"cancelled network error not retried", &model.NetworkError{Err: context.Canceled}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockFetcher{fn: func(_ int) ([]model.Job, error) { return nil, tt.err }}
+			rf := NewRetryFetcher(mock, 2, time.Millisecond, discardLogger())
+			if _, err := rf.FetchJobs(context.Background()); !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if mock.calls != tt.wantCalls {
+				t.Fatalf("expected %d calls, got %d", tt.wantCalls, mock.calls)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", fmt.Errorf("greenhouse fetch: %w", &model.NetworkError{Err: errors.New("no such host")}), true},
+		{"network error wrapping a cancel", &model.NetworkError{Err: context.Canceled}, false},
+		{"network error wrapping an oversized body", &model.NetworkError{Err: model.ErrResponseTooLarge}, false},
+		{"429", &model.HTTPError{StatusCode: 429}, true},
+		{"503", &model.HTTPError{StatusCode: 503}, true},
+		{"404", &model.HTTPError{StatusCode: 404}, false},
+		{"decode error", &model.DecodeError{Err: errors.New("unexpected EOF")}, false},
+		{"unclassified error", errors.New("something odd"), true},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
 	mock := &mockFetcher{fn: func(_ int) ([]model.Job, error) {
 		return nil, &model.HTTPError{StatusCode: 500, Err: errors.New("internal error")}
@@ -124,3 +188,138 @@ func TestRetry_RespectsContextCancellation(t *testing.T) {
 		t.Fatalf("expected 1 call before cancellation, got %d", mock.calls)
 	}
 }
+
+func TestRetry_CapsRetryAfter(t *testing.T) {
+	mock := &mockFetcher{fn: func(attempt int) ([]model.Job, error) {
+		if attempt == 1 {
+			return nil, &model.HTTPError{StatusCode: 429, RetryAfter: time.Hour}
+		}
+		return []model.Job{{ID: "1"}}, nil
+	}}
+
+	rf := NewRetryFetcher(mock, 2, time.Millisecond, discardLogger())
+	rf.SetMaxRetryAfter(20 * time.Millisecond)
+
+	start := time.Now()
+	if _, err := rf.FetchJobs(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > 5*time.Second {
+		t.Fatalf("backoff took %v, want the 20ms cap instead of the 1h Retry-After", elapsed)
+	}
+	if mock.calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", mock.calls)
+	}
+}
+
+func TestBackoffDelay_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{"under cap", 5 * time.Second, 5 * time.Second},
+		{"at cap", DefaultMaxRetryAfter, DefaultMaxRetryAfter},
+		{"over cap", time.Hour, DefaultMaxRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &model.HTTPError{StatusCode: 429, RetryAfter: tt.retryAfter}
+			if got := backoffDelay(time.Second, DefaultMaxRetryAfter, 1, err); got != tt.want {
+				t.Errorf("backoffDelay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetry_Stats(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(attempt int) ([]model.Job, error)
+		want Stats
+	}{
+		{
+			name: "success on first attempt",
+			fn:   func(_ int) ([]model.Job, error) { return nil, nil },
+			want: Stats{Attempts: 1},
+		},
+		{
+			name: "success on second attempt",
+			fn: func(attempt int) ([]model.Job, error) {
+				if attempt == 1 {
+					return nil, &model.HTTPError{StatusCode: 503}
+				}
+				return nil, nil
+			},
+			want: Stats{Attempts: 2, Retries: 1, Recovered: 1},
+		},
+		{
+			name: "gives up after max retries",
+			fn: func(_ int) ([]model.Job, error) {
+				return nil, &model.HTTPError{StatusCode: 500}
+			},
+			want: Stats{Attempts: 3, Retries: 2, GiveUps: 1},
+		},
+		{
+			name: "non-retryable error is not a give-up",
+			fn: func(_ int) ([]model.Job, error) {
+				return nil, &model.HTTPError{StatusCode: 404}
+			},
+			want: Stats{Attempts: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rf := NewRetryFetcher(&mockFetcher{fn: tt.fn}, 2, time.Millisecond, discardLogger())
+			rf.FetchJobs(context.Background())
+			if got := rf.Stats(); got != tt.want {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetry_StatsAccumulate(t *testing.T) {
+	mock := &mockFetcher{fn: func(attempt int) ([]model.Job, error) {
+		// Odd calls fail transiently, even calls succeed.
+		if attempt%2 == 1 {
+			return nil, &model.HTTPError{StatusCode: 502}
+		}
+		return nil, nil
+	}}
+	rf := NewRetryFetcher(mock, 2, time.Millisecond, discardLogger())
+	for i := 0; i < 3; i++ {
+		if _, err := rf.FetchJobs(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	want := Stats{Attempts: 6, Retries: 3, Recovered: 3}
+	if got := rf.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 10, 21, 7, 26, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "120", 120 * time.Second},
+		{"negative seconds", "-5", 0},
+		{"http date", "Wed, 21 Oct 2025 07:28:00 GMT", 2 * time.Minute},
+		{"rfc 850 date", "Wednesday, 21-Oct-25 07:28:00 GMT", 2 * time.Minute},
+		{"past date", "Wed, 21 Oct 2025 07:00:00 GMT", 0},
+		{"garbage", "soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+const (
+	throttleGrowth = 2.0 // delay multiplier applied on each 429
+	throttleDecay  = 0.5 // multiplier applied to the excess on each clean poll
+	throttleMax    = 8.0 // cap on the delay multiplier
+)
+
+// adaptiveLimiter scales an ATS group's delays when that ATS starts returning
+// HTTP 429: each throttled poll multiplies the delay (up to throttleMax), and
+// each clean poll decays it back toward the configured base.
+type adaptiveLimiter struct {
+	mu      sync.Mutex
+	factors map[string]float64 // per-ATS multiplier; missing means 1
+}
+
+func newAdaptiveLimiter() *adaptiveLimiter {
+	return &adaptiveLimiter{factors: make(map[string]float64)}
+}
+
+// record updates the ATS multiplier from a poll result and reports whether it
+// changed. Errors other than 429 (network, 5xx, parse) leave it untouched:
+// they say nothing about our request rate.
+func (l *adaptiveLimiter) record(ats string, err error) (factor float64, changed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	old := l.factorLocked(ats)
+	f := old
+	var httpErr *model.HTTPError
+	switch {
+	case errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests:
+		f = min(f*throttleGrowth, throttleMax)
+	case err == nil && f > 1:
+		f = 1 + (f-1)*throttleDecay
+		if f < 1.05 {
+			f = 1
+		}
+	}
+	if f == 1 {
+		delete(l.factors, ats)
+	} else {
+		l.factors[ats] = f
+	}
+	return f, f != old
+}
+
+// scale returns base stretched by the ATS's current multiplier.
+func (l *adaptiveLimiter) scale(ats string, base time.Duration) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Duration(float64(base) * l.factorLocked(ats))
+}
+
+func (l *adaptiveLimiter) factorLocked(ats string) float64 {
+	if f, ok := l.factors[ats]; ok {
+		return f
+	}
+	return 1
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestAdaptiveLimiter_ThrottleIncreasesDelay(t *testing.T) {
+	l := newAdaptiveLimiter()
+	base := time.Minute
+
+	// The poller wraps fetch errors, so the 429 arrives nested.
+	throttled := fmt.Errorf("polling acme: %w", &model.HTTPError{StatusCode: 429})
+
+	l.record("greenhouse", throttled)
+	if got := l.scale("greenhouse", base); got != 2*time.Minute {
+		t.Errorf("after one 429: delay = %v, want 2m", got)
+	}
+	l.record("greenhouse", throttled)
+	if got := l.scale("greenhouse", base); got != 4*time.Minute {
+		t.Errorf("after two 429s: delay = %v, want 4m", got)
+	}
+	if got := l.scale("lever", base); got != base {
+		t.Errorf("other ATS delay = %v, want unchanged %v", got, base)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.record("greenhouse", throttled)
+	}
+	if got := l.scale("greenhouse", base); got != time.Duration(throttleMax)*time.Minute {
+		t.Errorf("delay = %v, want capped at %vm", got, throttleMax)
+	}
+}
+
+func TestAdaptiveLimiter_RecoversAfterSuccesses(t *testing.T) {
+	l := newAdaptiveLimiter()
+	base := time.Minute
+
+	l.record("workday", &model.HTTPError{StatusCode: 429})
+	l.record("workday", &model.HTTPError{StatusCode: 429}) // factor 4
+
+	l.record("workday", nil)
+	if got := l.scale("workday", base); got != 2*time.Minute+30*time.Second {
+		t.Errorf("after one success: delay = %v, want 2m30s", got)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.record("workday", nil)
+	}
+	if got := l.scale("workday", base); got != base {
+		t.Errorf("after many successes: delay = %v, want base %v", got, base)
+	}
+}
+
+func TestAdaptiveLimiter_IgnoresNonThrottleErrors(t *testing.T) {
+	l := newAdaptiveLimiter()
+	for _, err := range []error{errors.New("dial tcp: timeout"), &model.HTTPError{StatusCode: 503}} {
+		if _, changed := l.record("ashby", err); changed {
+			t.Errorf("record(%v) changed the delay, want unchanged", err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amishk599/firstin/internal/poller"
+)
+
+// Ensure Scheduler implements poller.RequestPacer.
+var _ poller.RequestPacer = (*Scheduler)(nil)
+
+// ErrPastDeadline is returned by Wait when the next request to the ATS could
+// only start after the caller's context deadline.
+var ErrPastDeadline = errors.New("next request slot is past the deadline")
+
+// Wait implements poller.RequestPacer: the detail requests of an ATS group's
+// polls start at least the ATS min_delay after its previous request, board
+// fetch or detail, stretched while the ATS is throttling us, like the polls
+// themselves. A slot is claimed only when the request starts, so a wait that
+// ends early claims nothing. A wait that would outlast ctx's deadline fails
+// at once with ErrPastDeadline rather than spending the rest of the poll.
+func (s *Scheduler) Wait(ctx context.Context, ats string) error {
+	for {
+		at, ok := s.claim(ats)
+		if ok {
+			return nil
+		}
+		if deadline, has := ctx.Deadline(); has && at.After(deadline) {
+			return fmt.Errorf("%s at %s: %w", ats, at.Format(time.TimeOnly), ErrPastDeadline)
+		}
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+			// Another waiter may have taken the slot; check again.
+		}
+	}
+}
+
+// Record implements poller.RequestPacer. A 429 on a detail request stretches
+// the ATS's delays as a throttled poll does. Successes are left to the polls,
+// so a run of clean detail requests doesn't undo a throttle at once.
+func (s *Scheduler) Record(ats string, err error) {
+	if err != nil {
+		s.recordThrottle(ats, err)
+	}
+}
+
+// claim starts a request to ats now if its min_delay has passed since the
+// last one, reporting true. Otherwise it returns when the next request may
+// start and false.
+func (s *Scheduler) claim(ats string) (time.Time, bool) {
+	s.paceMu.Lock()
+	defer s.paceMu.Unlock()
+	now := time.Now()
+	if last, ok := s.lastRequest[ats]; ok {
+		if next := last.Add(s.minDelayFor(ats)); next.After(now) {
+			return next, false
+		}
+	}
+	s.lastRequest[ats] = now
+	return now, true
+}
+
+// markRequest records a request to ats starting now, for a board fetch the
+// loop has already spaced.
+func (s *Scheduler) markRequest(ats string) {
+	s.paceMu.Lock()
+	defer s.paceMu.Unlock()
+	s.lastRequest[ats] = time.Now()
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/poller"
+)
+
+// TimedDetailFetcher records when each detail request arrives and answers
+// with Err.
+type TimedDetailFetcher struct {
+	mu  sync.Mutex
+	At  []time.Time
+	Err error
+}
+
+func (f *TimedDetailFetcher) FetchJobDetail(_ context.Context, job model.Job) (model.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.At = append(f.At, time.Now())
+	return job, f.Err
+}
+
+func TestPoll_DetailRequestsPacedByMinDelay(t *testing.T) {
+	const minDelay = 40 * time.Millisecond
+	board := &BoardFetcher{Jobs: []model.Job{{ID: "1"}, {ID: "2"}, {ID: "3"}}}
+	details := &TimedDetailFetcher{}
+	p := makePoller("acme", "greenhouse", board)
+	p.SetDetailFetcher(details)
+	s := NewScheduler([]*poller.CompanyPoller{p}, time.Hour, minDelay, nil, discardLogger())
+	p.SetRequestPacer(s)
+
+	start := time.Now()
+	s.poll(context.Background(), p.ATS, p)
+
+	if len(details.At) != 3 {
+		t.Fatalf("detail requests = %d, want 3", len(details.At))
+	}
+	// The first follows the board fetch, each later one the one before it.
+	prev := start
+	for i, at := range details.At {
+		if gap := at.Sub(prev); gap < minDelay {
+			t.Errorf("detail request %d came %v after the previous request, want at least %v", i, gap, minDelay)
+		}
+		prev = at
+	}
+}
+
+func TestRecord_DetailThrottleStretchesDelay(t *testing.T) {
+	s := NewScheduler(nil, time.Hour, time.Second, nil, discardLogger())
+
+	s.Record("greenhouse", &model.HTTPError{StatusCode: 429})
+	if got := s.minDelayFor("greenhouse"); got != 2*time.Second {
+		t.Errorf("min delay after a throttled detail request = %v, want 2s", got)
+	}
+	s.Record("greenhouse", nil)
+	if got := s.minDelayFor("greenhouse"); got != 2*time.Second {
+		t.Errorf("min delay after a clean detail request = %v, want it left at 2s", got)
+	}
+}
+
+func TestWait_FailsFastPastDeadline(t *testing.T) {
+	s := NewScheduler(nil, time.Hour, time.Hour, nil, discardLogger())
+	if err := s.Wait(context.Background(), "workday"); err != nil {
+		t.Fatalf("first Wait = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	start := time.Now()
+	if err := s.Wait(ctx, "workday"); !errors.Is(err, ErrPastDeadline) {
+		t.Errorf("second Wait = %v, want ErrPastDeadline", err)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("second Wait took %v, want it to fail at once", waited)
+	}
+}
+
+func TestWait_CancelledWaitClaimsNothing(t *testing.T) {
+	s := NewScheduler(nil, time.Hour, time.Hour, nil, discardLogger())
+	if err := s.Wait(context.Background(), "workday"); err != nil {
+		t.Fatalf("first Wait = %v, want nil", err)
+	}
+	last := s.lastRequest["workday"]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if err := s.Wait(ctx, "workday"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("second Wait = %v, want context.Canceled", err)
+	}
+	if got := s.lastRequest["workday"]; !got.Equal(last) {
+		t.Errorf("last request moved to %v after a cancelled wait, want %v", got, last)
+	}
+}
+
+func TestPoll_PacingStaysBoundedAcrossPolls(t *testing.T) {
+	const minDelay = 10 * time.Millisecond
+	board := &BoardFetcher{Jobs: []model.Job{{ID: "1"}, {ID: "2"}}}
+	p := makePoller("acme", "greenhouse", board)
+	p.SetDetailFetcher(&TimedDetailFetcher{})
+	s := NewScheduler([]*poller.CompanyPoller{p}, time.Hour, minDelay, nil, discardLogger())
+	p.SetRequestPacer(s)
+
+	for i := range 5 {
+		s.poll(context.Background(), p.ATS, p)
+		s.paceMu.Lock()
+		next := s.lastRequest[p.ATS].Add(minDelay)
+		s.paceMu.Unlock()
+		if ahead := time.Until(next); ahead > minDelay {
+			t.Fatalf("after poll %d the next request slot is %v ahead, want at most one min_delay (%v)", i+1, ahead, minDelay)
+		}
+	}
+}
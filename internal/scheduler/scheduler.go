@@ -2,41 +2,295 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/amishk599/firstin/internal/model"
 	"github.com/amishk599/firstin/internal/poller"
 )
 
+// ErrUnknownCompany is returned by Trigger when no poller has the given name.
+var ErrUnknownCompany = errors.New("unknown company")
+
+// ErrTriggerPending is returned by Trigger when the company's ATS group already
+// has a full queue of out-of-band polls waiting.
+var ErrTriggerPending = errors.New("poll already pending")
+
+// defaultDrainTimeout bounds how long an in-flight poll may keep running after shutdown.
+const defaultDrainTimeout = 30 * time.Second
+
+// defaultEmptyBoardPolls is how many consecutive empty fetches from a board
+// that used to list jobs mark it as likely misconfigured (stale token, moved
+// ATS).
+const defaultEmptyBoardPolls = 3
+
+// defaultNotFoundPolls is how many consecutive 404/400 responses disable a
+// company as misconfigured (mistyped board token, board removed).
+const defaultNotFoundPolls = 3
+
+// defaultPollTimeout bounds a single company poll so one hung board can't
+// stall the rest of its ATS group.
+const defaultPollTimeout = 60 * time.Second
+
 // Scheduler runs one long-lived goroutine per ATS group. Each goroutine polls
 // its companies sequentially with minDelay between same-ATS requests, then
 // sleeps polling_interval before the next pass. Rate limiting is structural.
+// With SetMaxPerATSConcurrency a group may have several polls in flight, still
+// started minDelay apart.
 type Scheduler struct {
-	pollers   []*poller.CompanyPoller
-	interval  time.Duration
-	minDelay  time.Duration
-	atsDelays map[string]time.Duration
-	logger    *slog.Logger
+	pollers      []*poller.CompanyPoller
+	interval     time.Duration
+	minDelay     time.Duration
+	atsDelays    map[string]time.Duration
+	atsIntervals map[string]time.Duration              // per-ATS pass intervals; others use interval
+	triggers     map[string]chan *poller.CompanyPoller // per-ATS out-of-band poll queue
+	drainTimeout time.Duration
+	pollTimeout  time.Duration
+	stagger      time.Duration           // offset between ATS groups' first polls
+	emptyPolls   int                     // consecutive empty fetches before warning
+	notFound     int                     // consecutive 404/400 polls before disabling
+	windows      map[string]ActiveWindow // per-company active windows; others always poll
+	perATS       int                     // polls in flight per ATS group; 1 is sequential
+	pollLocks    map[*poller.CompanyPoller]*sync.Mutex
+	now          func() time.Time
+	throttle     *adaptiveLimiter
+	paceMu       sync.Mutex
+	lastRequest  map[string]time.Time // per-ATS start of the latest request, for pacing details
+	statusMu     sync.Mutex
+	status       map[string]CompanyStatus
+	logger       *slog.Logger
+}
+
+// CompanyStatus is the outcome of a company's most recent poll.
+type CompanyStatus struct {
+	ATS          string    `json:"ats"`
+	LastPolled   time.Time `json:"last_polled"`
+	LastError    string    `json:"last_error,omitempty"`
+	LastNewCount int       `json:"last_new_count"`
+
+	// LastNonzeroCount is how many jobs the board returned on its most
+	// recent non-empty fetch; EmptyPolls counts the successful empty fetches
+	// since. Warning is set once a board that used to list jobs has been
+	// empty for several polls in a row.
+	LastNonzeroCount int    `json:"last_nonzero_count"`
+	EmptyPolls       int    `json:"empty_polls"`
+	Warning          string `json:"warning,omitempty"`
+
+	// NotFoundPolls counts consecutive polls that failed with HTTP 404 or
+	// 400. Once it reaches the threshold the company is Disabled: the loop
+	// stops polling it until a triggered poll succeeds.
+	NotFoundPolls int  `json:"not_found_polls"`
+	Disabled      bool `json:"disabled"`
 }
 
 // NewScheduler creates a scheduler that groups pollers by ATS and runs one goroutine per group.
 func NewScheduler(pollers []*poller.CompanyPoller, interval, minDelay time.Duration, atsDelays map[string]time.Duration, logger *slog.Logger) *Scheduler {
-	return &Scheduler{
-		pollers:   pollers,
-		interval:  interval,
-		minDelay:  minDelay,
-		atsDelays: atsDelays,
-		logger:    logger,
+	s := &Scheduler{
+		pollers:      pollers,
+		interval:     interval,
+		minDelay:     minDelay,
+		atsDelays:    atsDelays,
+		triggers:     make(map[string]chan *poller.CompanyPoller),
+		drainTimeout: defaultDrainTimeout,
+		pollTimeout:  defaultPollTimeout,
+		emptyPolls:   defaultEmptyBoardPolls,
+		notFound:     defaultNotFoundPolls,
+		perATS:       1,
+		pollLocks:    make(map[*poller.CompanyPoller]*sync.Mutex, len(pollers)),
+		now:          time.Now,
+		throttle:     newAdaptiveLimiter(),
+		lastRequest:  make(map[string]time.Time),
+		status:       make(map[string]CompanyStatus),
+		logger:       logger,
+	}
+	for ats, group := range s.groupByATS() {
+		s.triggers[ats] = make(chan *poller.CompanyPoller, len(group))
+	}
+	for _, p := range pollers {
+		s.pollLocks[p] = &sync.Mutex{}
 	}
+	return s
 }
 
-// minDelayFor returns the per-ATS delay if configured, otherwise the global minDelay.
+// SetDrainTimeout sets how long a poll that is in flight when the run context
+// is cancelled may keep running, so it can finish notifying and marking jobs
+// seen. Zero abandons in-flight polls immediately.
+func (s *Scheduler) SetDrainTimeout(d time.Duration) {
+	s.drainTimeout = d
+}
+
+// SetPollTimeout bounds each company poll, fetch through notify. A poll that
+// exceeds it is abandoned and the ATS group moves on to the next company.
+// Zero disables the deadline.
+func (s *Scheduler) SetPollTimeout(d time.Duration) {
+	s.pollTimeout = d
+}
+
+// SetStartupStagger delays each ATS group's first pass by its index (in ATS
+// name order) times d, so startup doesn't hit every provider at once. Zero
+// starts all groups immediately.
+func (s *Scheduler) SetStartupStagger(d time.Duration) {
+	s.stagger = d
+}
+
+// SetIntervalOverrides sets per-ATS pass intervals, keyed by ATS name. Groups
+// without an entry sleep the global interval between passes.
+func (s *Scheduler) SetIntervalOverrides(intervals map[string]time.Duration) {
+	s.atsIntervals = intervals
+}
+
+// SetMaxPerATSConcurrency lets up to n companies of the same ATS group be
+// polled at once. Each poll still starts at least the ATS min_delay after the
+// previous one, so the request rate to the ATS is unchanged; only slow boards
+// stop holding up the rest of the pass. n <= 1 polls sequentially.
+func (s *Scheduler) SetMaxPerATSConcurrency(n int) {
+	s.perATS = max(n, 1)
+}
+
+// SetActiveWindows limits when companies are polled, keyed by company name.
+// A company outside its window is skipped by the regular loop until the
+// window opens; triggered polls still run. Companies without an entry are
+// always polled.
+func (s *Scheduler) SetActiveWindows(windows map[string]ActiveWindow) {
+	s.windows = windows
+}
+
+// HasCompany reports whether the scheduler polls the named company
+// (case-insensitive).
+func (s *Scheduler) HasCompany(company string) bool {
+	for _, p := range s.pollers {
+		if strings.EqualFold(p.Name, company) {
+			return true
+		}
+	}
+	return false
+}
+
+// Trigger queues an immediate out-of-band poll of the named company
+// (case-insensitive). The poll runs on the company's ATS goroutine the next
+// time it is waiting, so same-ATS requests keep their min_delay spacing; the
+// regular interval loop is not reset.
+func (s *Scheduler) Trigger(company string) error {
+	for _, p := range s.pollers {
+		if !strings.EqualFold(p.Name, company) {
+			continue
+		}
+		select {
+		case s.triggers[p.ATS] <- p:
+			s.logger.Info("poll triggered", "company", p.Name, "ats", p.ATS)
+			return nil
+		default:
+			return fmt.Errorf("%s: %w", p.Name, ErrTriggerPending)
+		}
+	}
+	return fmt.Errorf("%q: %w", company, ErrUnknownCompany)
+}
+
+// Status returns a snapshot of each polled company's last poll, keyed by
+// company name. Companies not yet polled are absent.
+func (s *Scheduler) Status() map[string]CompanyStatus {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	out := make(map[string]CompanyStatus, len(s.status))
+	for name, st := range s.status {
+		out[name] = st
+	}
+	return out
+}
+
+// recordStatus stores the outcome of p's latest poll. It also tracks empty
+// fetches: once a board that used to list jobs returns none for emptyPolls
+// polls in a row, it logs a warning and flags the status. A company whose
+// board answers 404/400 for notFound polls in a row is disabled.
+func (s *Scheduler) recordStatus(p *poller.CompanyPoller, err error) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	prev := s.status[p.Name]
+	st := CompanyStatus{
+		ATS:              p.ATS,
+		LastPolled:       s.now(),
+		LastNewCount:     p.LastNewCount(),
+		LastNonzeroCount: prev.LastNonzeroCount,
+		EmptyPolls:       prev.EmptyPolls,
+	}
+	if isNotFound(err) {
+		st.NotFoundPolls = prev.NotFoundPolls + 1
+	}
+	switch fetched := p.LastFetchedCount(); {
+	case err != nil:
+		st.LastError = err.Error()
+	case fetched > 0:
+		st.LastNonzeroCount = fetched
+		st.EmptyPolls = 0
+	default:
+		st.EmptyPolls++
+	}
+	if st.LastNonzeroCount > 0 && st.EmptyPolls >= s.emptyPolls {
+		st.Warning = fmt.Sprintf("board returned no jobs for %d polls in a row (last non-empty: %d jobs); check its board token or ATS", st.EmptyPolls, st.LastNonzeroCount)
+		if st.EmptyPolls == s.emptyPolls {
+			s.logger.Warn("board went empty, possible misconfiguration",
+				"company", p.Name,
+				"ats", p.ATS,
+				"empty_polls", st.EmptyPolls,
+				"last_nonzero_count", st.LastNonzeroCount,
+			)
+		}
+	}
+	if st.NotFoundPolls >= s.notFound {
+		st.Disabled = true
+		st.Warning = fmt.Sprintf("disabled: board not found for %d polls in a row (%s); fix its board token or ATS, then trigger a poll to re-enable", st.NotFoundPolls, st.LastError)
+		if st.NotFoundPolls == s.notFound {
+			s.logger.Error(fmt.Sprintf("disabling %s: board not found", p.Name),
+				"company", p.Name,
+				"ats", p.ATS,
+				"polls", st.NotFoundPolls,
+				"error", err,
+			)
+		}
+	}
+	s.status[p.Name] = st
+}
+
+// isNotFound reports whether err is an HTTP 404 or 400, which a board
+// returns for a token or URL that doesn't exist.
+func isNotFound(err error) bool {
+	var httpErr *model.HTTPError
+	return errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusNotFound || httpErr.StatusCode == http.StatusBadRequest)
+}
+
+// disabled reports whether the named company has been disabled as not found.
+func (s *Scheduler) disabled(name string) bool {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	return s.status[name].Disabled
+}
+
+// minDelayFor returns the per-ATS delay if configured, otherwise the global
+// minDelay, stretched while the ATS is throttling us (see adaptiveLimiter).
 func (s *Scheduler) minDelayFor(ats string) time.Duration {
+	base := s.minDelay
 	if d, ok := s.atsDelays[ats]; ok {
-		return d
+		base = d
+	}
+	return s.throttle.scale(ats, base)
+}
+
+// intervalFor returns the per-ATS pass interval if configured, otherwise the
+// global interval, stretched while the ATS is throttling us.
+func (s *Scheduler) intervalFor(ats string) time.Duration {
+	base := s.interval
+	if d, ok := s.atsIntervals[ats]; ok {
+		base = d
 	}
-	return s.minDelay
+	return s.throttle.scale(ats, base)
 }
 
 // groupByATS returns pollers grouped by ATS name. Order within each group preserves config order.
@@ -57,17 +311,27 @@ func (s *Scheduler) Run(ctx context.Context) error {
 		"interval", s.interval.String(),
 		"min_delay", s.minDelay.String(),
 		"ats_overrides", len(s.atsDelays),
+		"interval_overrides", len(s.atsIntervals),
+		"startup_stagger", s.stagger.String(),
+		"max_per_ats_concurrency", s.perATS,
 		"companies", len(s.pollers),
 		"ats_groups", len(groups),
 	)
 
+	atsNames := make([]string, 0, len(groups))
+	for ats := range groups {
+		atsNames = append(atsNames, ats)
+	}
+	sort.Strings(atsNames)
+
 	var wg sync.WaitGroup
-	for ats, pollers := range groups {
-		ats, pollers := ats, pollers
+	for i, ats := range atsNames {
+		pollers := groups[ats]
+		startDelay := time.Duration(i) * s.stagger
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			s.runATSLoop(ctx, ats, pollers)
+			s.runATSLoop(ctx, ats, pollers, startDelay)
 		}()
 	}
 
@@ -77,34 +341,178 @@ func (s *Scheduler) Run(ctx context.Context) error {
 }
 
 // runATSLoop runs the poll loop for one ATS group: poll each company sequentially
-// with minDelay between them, then sleep interval before the next full pass.
-func (s *Scheduler) runATSLoop(ctx context.Context, ats string, pollers []*poller.CompanyPoller) {
+// with minDelay between them, then sleep the group's interval before the next
+// full pass. Disabled companies and those outside their active window are
+// skipped, along with the min_delay after them.
+// With perATS above 1, each poll runs in its own goroutine while the loop
+// waits min_delay and starts the next, up to perATS at once; the pass ends
+// when all of them have finished.
+// Triggered polls are served while the loop is sleeping, including during
+// the startDelay before the first pass.
+func (s *Scheduler) runATSLoop(ctx context.Context, ats string, pollers []*poller.CompanyPoller, startDelay time.Duration) {
+	trigger := s.triggers[ats]
+	slots := make(chan struct{}, s.perATS)
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+
+	if startDelay > 0 && !s.sleep(ctx, ats, startDelay, trigger, slots) {
+		return
+	}
 	for {
 		for i, p := range pollers {
 			if ctx.Err() != nil {
 				return
 			}
-			if err := p.Poll(ctx); err != nil {
-				s.logger.Error("poll failed",
-					"company", p.Name,
-					"ats", ats,
-					"error", err,
-				)
+			if s.disabled(p.Name) {
+				continue
+			}
+			if w, ok := s.windows[p.Name]; ok && !w.Contains(s.now()) {
+				s.logger.Debug("outside active window, skipping", "company", p.Name, "ats", ats)
+				continue
+			}
+			if s.perATS == 1 {
+				s.poll(ctx, ats, p)
+			} else {
+				if !acquire(ctx, slots) {
+					return
+				}
+				inflight.Add(1)
+				go func() {
+					defer inflight.Done()
+					defer func() { <-slots }()
+					s.poll(ctx, ats, p)
+				}()
 			}
 			// Sleep min_delay between same-ATS companies not after the last
 			if i < len(pollers)-1 {
-				select {
-				case <-ctx.Done():
+				if !s.sleep(ctx, ats, s.minDelayFor(ats), trigger, slots) {
 					return
-				case <-time.After(s.minDelayFor(ats)):
 				}
 			}
 		}
-		// Sleep polling_interval before next full pass
+		inflight.Wait()
+		// Sleep the group's polling interval before next full pass
+		if !s.sleep(ctx, ats, s.intervalFor(ats), trigger, slots) {
+			return
+		}
+	}
+}
+
+// acquire takes a slot from slots, waiting for one to free up. Returns false
+// if ctx was cancelled first.
+func acquire(ctx context.Context, slots chan struct{}) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// poll runs one poll of p and logs any error. The poll gets its own context that
+// outlives ctx by up to drainTimeout, so a shutdown mid-pipeline still lets the
+// company finish and mark its jobs seen instead of re-notifying on next start.
+// It is also bounded by pollTimeout. Polls of the same company never overlap:
+// a triggered poll waits for one already in flight.
+func (s *Scheduler) poll(ctx context.Context, ats string, p *poller.CompanyPoller) {
+	if mu := s.pollLocks[p]; mu != nil {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+	pollCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	if s.pollTimeout > 0 {
+		var cancelTimeout context.CancelFunc
+		pollCtx, cancelTimeout = context.WithTimeout(pollCtx, s.pollTimeout)
+		defer cancelTimeout()
+	}
+	stop := context.AfterFunc(ctx, func() {
+		s.logger.Info("shutdown requested, draining in-flight poll",
+			"company", p.Name,
+			"ats", ats,
+			"timeout", s.drainTimeout.String(),
+		)
+		timer := time.AfterFunc(s.drainTimeout, cancel)
+		context.AfterFunc(pollCtx, func() { timer.Stop() })
+	})
+	defer stop()
+
+	s.markRequest(ats) // detail requests made by the poll follow its board fetch
+	err := s.safePoll(pollCtx, ats, p)
+	s.recordStatus(p, err)
+	if err != nil && errors.Is(pollCtx.Err(), context.DeadlineExceeded) {
+		s.logger.Warn("poll timed out, moving on",
+			"company", p.Name,
+			"ats", ats,
+			"timeout", s.pollTimeout.String(),
+			"error", err,
+		)
+	} else if err != nil {
+		s.logger.Error("poll failed",
+			"company", p.Name,
+			"ats", ats,
+			"error", err,
+		)
+	}
+	s.recordThrottle(ats, err)
+}
+
+// recordThrottle updates the ATS's adaptive delay from a request outcome,
+// logging any change.
+func (s *Scheduler) recordThrottle(ats string, err error) {
+	if factor, changed := s.throttle.record(ats, err); changed {
+		s.logger.Warn("adjusted ATS delay after rate limiting",
+			"ats", ats,
+			"factor", factor,
+			"min_delay", s.minDelayFor(ats).String(),
+			"interval", s.intervalFor(ats).String(),
+		)
+	}
+}
+
+// safePoll runs p.Poll, recovering a panic (say, an adapter dereferencing a
+// malformed payload) so it fails this poll instead of killing the ATS group's
+// goroutine. The panic is logged with its stack and returned as an error.
+func (s *Scheduler) safePoll(ctx context.Context, ats string, p *poller.CompanyPoller) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("poll panicked, continuing",
+				"company", p.Name,
+				"ats", ats,
+				"panic", r,
+				"stack", string(debug.Stack()),
+			)
+			err = fmt.Errorf("poll panicked: %v", r)
+		}
+	}()
+	return p.Poll(ctx)
+}
+
+// sleep waits for d, running any triggered polls that arrive in the meantime.
+// A triggered poll never shortens the wait, and is followed by at least the
+// ATS min_delay before the loop resumes. It holds one of the group's slots, so
+// it counts toward max_per_ats_concurrency. Returns false if ctx was cancelled.
+func (s *Scheduler) sleep(ctx context.Context, ats string, d time.Duration, trigger <-chan *poller.CompanyPoller, slots chan struct{}) bool {
+	deadline := time.Now().Add(d)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return true
+		}
 		select {
 		case <-ctx.Done():
-			return
-		case <-time.After(s.interval):
+			return false
+		case <-time.After(remaining):
+			return true
+		case p := <-trigger:
+			if !acquire(ctx, slots) {
+				return false
+			}
+			s.poll(ctx, ats, p)
+			<-slots
+			if next := time.Now().Add(s.minDelayFor(ats)); next.After(deadline) {
+				deadline = next
+			}
 		}
 	}
 }
@@ -1,10 +1,13 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -57,12 +60,19 @@ type NoOpStore struct{}
 
 func (s *NoOpStore) HasSeen(_ string) (bool, error) { return false, nil }
 func (s *NoOpStore) MarkSeen(_ string) error         { return nil }
+func (s *NoOpStore) MarkSeenBatch(_ []string) error   { return nil }
 func (s *NoOpStore) Cleanup(_ time.Duration) error   { return nil }
 func (s *NoOpStore) IsEmpty() (bool, error)          { return false, nil }
 
 type NoOpNotifier struct{}
 
-func (n *NoOpNotifier) Notify(_ []model.Job) error { return nil }
+func (n *NoOpNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	delivered := make([]bool, len(jobs))
+	for i := range delivered {
+		delivered[i] = true
+	}
+	return delivered, nil
+}
 
 type AcceptAllFilter struct{}
 
@@ -222,6 +232,162 @@ func TestRun_MinDelayBetweenSameATSCompanies(t *testing.T) {
 	}
 }
 
+func TestRun_IntervalOverridesPerATS(t *testing.T) {
+	fastFetcher := &CountingFetcher{}
+	slowFetcher := &CountingFetcher{}
+	pollers := []*poller.CompanyPoller{
+		makePoller("gh", "greenhouse", fastFetcher),
+		makePoller("wd", "workday", slowFetcher),
+	}
+
+	// Global interval 1h; greenhouse overridden to 20ms, workday to 1h.
+	s := NewScheduler(pollers, time.Hour, 0, nil, discardLogger())
+	s.SetIntervalOverrides(map[string]time.Duration{"greenhouse": 20 * time.Millisecond, "workday": time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	time.Sleep(150 * time.Millisecond)
+	cancel()
+	<-done
+
+	fast, slow := fastFetcher.calls.Load(), slowFetcher.calls.Load()
+	if slow != 1 {
+		t.Errorf("workday polls = %d, want 1 (long interval)", slow)
+	}
+	if fast < 3 {
+		t.Errorf("greenhouse polls = %d, want >= 3 (short interval)", fast)
+	}
+}
+
+func TestRun_StartupStaggerOffsetsGroups(t *testing.T) {
+	fetchers := map[string]*CountingFetcher{"ashby": {}, "greenhouse": {}, "workday": {}}
+	var pollers []*poller.CompanyPoller
+	for ats, f := range fetchers {
+		pollers = append(pollers, makePoller(ats+"-co", ats, f))
+	}
+
+	s := NewScheduler(pollers, time.Hour, 0, nil, discardLogger())
+	s.SetStartupStagger(100 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Groups start in ATS name order: ashby at 0, greenhouse at 100ms,
+	// workday at 200ms.
+	time.Sleep(50 * time.Millisecond)
+	if got := fetchers["ashby"].calls.Load(); got != 1 {
+		t.Errorf("ashby polls at 50ms = %d, want 1", got)
+	}
+	if got := fetchers["greenhouse"].calls.Load() + fetchers["workday"].calls.Load(); got != 0 {
+		t.Errorf("staggered groups polled %d times in the first 50ms, want 0", got)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	for ats, f := range fetchers {
+		if got := f.calls.Load(); got != 1 {
+			t.Errorf("%s polls after stagger = %d, want 1", ats, got)
+		}
+	}
+}
+
+// PanickingFetcher panics on every FetchJobs call, like an adapter hitting a
+// nil pointer in a malformed payload.
+type PanickingFetcher struct {
+	calls atomic.Int32
+}
+
+func (f *PanickingFetcher) FetchJobs(_ context.Context) ([]model.Job, error) {
+	f.calls.Add(1)
+	var detail *model.JobDetail
+	_ = detail.Description
+	return nil, nil
+}
+
+func TestRun_ActiveWindowSkipsCompanyOutsideWindow(t *testing.T) {
+	window := ActiveWindow{Days: []time.Weekday{time.Monday}, Start: 8 * time.Hour, End: 12 * time.Hour, Location: time.UTC}
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool // whether the windowed company is polled
+	}{
+		{"inside", time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), true},          // Monday morning
+		{"outside hours", time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC), false}, // Monday night
+		{"outside days", time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC), false},   // Tuesday morning
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windowed, always := &CountingFetcher{}, &CountingFetcher{}
+			pollers := []*poller.CompanyPoller{
+				makePoller("mornings", "greenhouse", windowed),
+				makePoller("always", "greenhouse", always),
+			}
+			s := NewScheduler(pollers, time.Hour, 0, nil, discardLogger())
+			s.SetActiveWindows(map[string]ActiveWindow{"mornings": window})
+			s.now = func() time.Time { return tt.now }
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- s.Run(ctx)
+			}()
+			deadline := time.Now().Add(2 * time.Second)
+			for always.calls.Load() == 0 && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			cancel()
+			<-done
+
+			if got := always.calls.Load(); got != 1 {
+				t.Errorf("unwindowed company polls = %d, want 1", got)
+			}
+			if got := windowed.calls.Load() == 1; got != tt.want {
+				t.Errorf("windowed company polled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_PanickingPollerDoesNotStopGroup(t *testing.T) {
+	panicky := &PanickingFetcher{}
+	ok := &CountingFetcher{}
+	pollers := []*poller.CompanyPoller{
+		makePoller("broken", "greenhouse", panicky),
+		makePoller("fine", "greenhouse", ok),
+	}
+
+	s := NewScheduler(pollers, 20*time.Millisecond, 0, nil, discardLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	time.Sleep(150 * time.Millisecond)
+	cancel()
+	<-done
+
+	if got := panicky.calls.Load(); got < 2 {
+		t.Errorf("panicking company polled %d times, want >= 2 (group kept cycling)", got)
+	}
+	if got := ok.calls.Load(); got < 2 {
+		t.Errorf("company after the panicking one polled %d times, want >= 2", got)
+	}
+	if st := s.Status()["broken"]; !strings.Contains(st.LastError, "panicked") {
+		t.Errorf("status LastError = %q, want the panic recorded", st.LastError)
+	}
+}
+
 func TestRun_OnePollerErrorSameATSGroupContinues(t *testing.T) {
 	errFetcher := &ErrorFetcher{}
 	okFetcher := &CountingFetcher{}
@@ -318,3 +484,401 @@ func TestRun_OrderWithinGroupPreserved(t *testing.T) {
 		}
 	}
 }
+
+func TestTrigger_PollsCompanyPromptly(t *testing.T) {
+	fetcher := &CountingFetcher{}
+	other := &CountingFetcher{}
+	pollers := []*poller.CompanyPoller{
+		makePoller("co1", "greenhouse", fetcher),
+		makePoller("co2", "greenhouse", other),
+	}
+
+	// Long interval: without a trigger each company is polled exactly once.
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScheduler(pollers, 1*time.Hour, 0, nil, discardLogger())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := s.Trigger("CO1"); err != nil {
+		t.Fatalf("Trigger: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	if got := fetcher.calls.Load(); got != 2 {
+		t.Errorf("triggered fetcher calls = %d, want 2 (scheduled + triggered)", got)
+	}
+	if got := other.calls.Load(); got != 1 {
+		t.Errorf("other fetcher calls = %d, want 1 (trigger should not affect other companies)", got)
+	}
+}
+
+func TestTrigger_UnknownCompany(t *testing.T) {
+	s := NewScheduler([]*poller.CompanyPoller{makePoller("co1", "greenhouse", &CountingFetcher{})}, time.Hour, 0, nil, discardLogger())
+	if err := s.Trigger("nope"); !errors.Is(err, ErrUnknownCompany) {
+		t.Errorf("Trigger(unknown) = %v, want ErrUnknownCompany", err)
+	}
+}
+
+// SlowFetcher signals started, then takes delay to return one job unless its
+// context is cancelled first.
+type SlowFetcher struct {
+	started chan struct{}
+	delay   time.Duration
+}
+
+func (f *SlowFetcher) FetchJobs(ctx context.Context) ([]model.Job, error) {
+	close(f.started)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(f.delay):
+		return []model.Job{{ID: "slow-1", Company: "slowco", Title: "Engineer"}}, nil
+	}
+}
+
+type RecordingStore struct {
+	mu   sync.Mutex
+	seen []string
+}
+
+func (s *RecordingStore) HasSeen(_ string) (bool, error) { return false, nil }
+func (s *RecordingStore) MarkSeen(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen = append(s.seen, id)
+	return nil
+}
+func (s *RecordingStore) MarkSeenBatch(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen = append(s.seen, ids...)
+	return nil
+}
+func (s *RecordingStore) Cleanup(_ time.Duration) error { return nil }
+func (s *RecordingStore) IsEmpty() (bool, error)        { return false, nil }
+
+func TestRun_CancelDrainsInFlightPoll(t *testing.T) {
+	fetcher := &SlowFetcher{started: make(chan struct{}), delay: 100 * time.Millisecond}
+	st := &RecordingStore{}
+	p := poller.NewCompanyPoller("slowco", "greenhouse", fetcher, &AcceptAllFilter{}, st, &NoOpNotifier{}, &NopAnalyzer{}, time.Hour, discardLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScheduler([]*poller.CompanyPoller{p}, time.Hour, 0, nil, discardLogger())
+	s.SetDrainTimeout(2 * time.Second)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	<-fetcher.started
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("scheduler did not return after draining")
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if len(st.seen) != 1 || st.seen[0] != "slow-1" {
+		t.Errorf("marked seen = %v, want [slow-1] (in-flight poll should finish)", st.seen)
+	}
+}
+
+func TestRun_DrainTimeoutBoundsInFlightPoll(t *testing.T) {
+	fetcher := &SlowFetcher{started: make(chan struct{}), delay: time.Hour}
+	st := &RecordingStore{}
+	p := poller.NewCompanyPoller("slowco", "greenhouse", fetcher, &AcceptAllFilter{}, st, &NoOpNotifier{}, &NopAnalyzer{}, time.Hour, discardLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScheduler([]*poller.CompanyPoller{p}, time.Hour, 0, nil, discardLogger())
+	s.SetDrainTimeout(50 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	<-fetcher.started
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("scheduler did not return within 2s despite 50ms drain timeout")
+	}
+}
+
+func TestRun_PollTimeoutAbandonsHungCompany(t *testing.T) {
+	hung := &SlowFetcher{started: make(chan struct{}), delay: time.Hour}
+	healthy := &CountingFetcher{}
+	pollers := []*poller.CompanyPoller{
+		makePoller("hung", "workday", hung),
+		makePoller("healthy", "workday", healthy),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewScheduler(pollers, time.Hour, 0, nil, discardLogger())
+	s.SetPollTimeout(50 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for healthy.calls.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("healthy company was not polled; the hung fetch stalled its ATS group")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestStatus_RecordsLastPoll(t *testing.T) {
+	pollers := []*poller.CompanyPoller{
+		makePoller("healthy", "greenhouse", &SlowFetcher{started: make(chan struct{})}), // returns one job
+		makePoller("failing", "lever", &ErrorFetcher{}),
+	}
+	s := NewScheduler(pollers, time.Hour, 0, nil, discardLogger())
+
+	if got := s.Status(); len(got) != 0 {
+		t.Fatalf("Status before any poll = %v, want empty", got)
+	}
+
+	before := time.Now()
+	for _, p := range pollers {
+		s.poll(context.Background(), p.ATS, p)
+	}
+
+	status := s.Status()
+	healthy, ok := status["healthy"]
+	if !ok {
+		t.Fatal("no status for healthy")
+	}
+	if healthy.LastPolled.Before(before) || healthy.LastError != "" || healthy.ATS != "greenhouse" {
+		t.Errorf("healthy = %+v, want a fresh error-free poll", healthy)
+	}
+	if healthy.LastNewCount != 1 {
+		t.Errorf("healthy LastNewCount = %d, want 1", healthy.LastNewCount)
+	}
+	if failing := status["failing"]; failing.LastError == "" || failing.LastPolled.Before(before) {
+		t.Errorf("failing = %+v, want last_error recorded", failing)
+	}
+}
+
+// BoardFetcher returns whatever Jobs currently holds, like a board that can
+// go empty.
+type BoardFetcher struct {
+	Jobs []model.Job
+}
+
+func (f *BoardFetcher) FetchJobs(_ context.Context) ([]model.Job, error) {
+	return f.Jobs, nil
+}
+
+func TestStatus_WarnsWhenBoardGoesEmpty(t *testing.T) {
+	board := &BoardFetcher{Jobs: []model.Job{{ID: "1", Title: "Engineer"}, {ID: "2", Title: "Engineer"}}}
+	p := makePoller("stale", "greenhouse", board)
+	var logs bytes.Buffer
+	s := NewScheduler([]*poller.CompanyPoller{p}, time.Hour, 0, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	s.poll(context.Background(), p.ATS, p)
+	if st := s.Status()["stale"]; st.LastNonzeroCount != 2 || st.EmptyPolls != 0 {
+		t.Fatalf("after non-empty poll: %+v, want last_nonzero_count 2", st)
+	}
+
+	board.Jobs = nil
+	for i := 1; i < defaultEmptyBoardPolls; i++ {
+		s.poll(context.Background(), p.ATS, p)
+	}
+	if st := s.Status()["stale"]; st.Warning != "" || strings.Contains(logs.String(), "board went empty") {
+		t.Fatalf("warned after %d empty polls, want only at %d: %+v", defaultEmptyBoardPolls-1, defaultEmptyBoardPolls, st)
+	}
+
+	s.poll(context.Background(), p.ATS, p)
+	st := s.Status()["stale"]
+	if st.EmptyPolls != defaultEmptyBoardPolls || st.LastNonzeroCount != 2 || st.Warning == "" {
+		t.Errorf("status = %+v, want a warning after %d empty polls", st, defaultEmptyBoardPolls)
+	}
+	if got := strings.Count(logs.String(), "board went empty"); got != 1 {
+		t.Errorf("logged %d warnings, want 1", got)
+	}
+
+	// Further empty polls keep the status flag but don't log again.
+	s.poll(context.Background(), p.ATS, p)
+	if got := strings.Count(logs.String(), "board went empty"); got != 1 {
+		t.Errorf("logged %d warnings after another empty poll, want 1", got)
+	}
+
+	board.Jobs = []model.Job{{ID: "3", Title: "Engineer"}}
+	s.poll(context.Background(), p.ATS, p)
+	if st := s.Status()["stale"]; st.Warning != "" || st.EmptyPolls != 0 || st.LastNonzeroCount != 1 {
+		t.Errorf("status after board recovered = %+v, want warning cleared", st)
+	}
+}
+
+func TestStatus_NoWarningForBoardThatWasNeverNonEmpty(t *testing.T) {
+	p := makePoller("quiet", "greenhouse", &BoardFetcher{})
+	s := NewScheduler([]*poller.CompanyPoller{p}, time.Hour, 0, nil, discardLogger())
+	for i := 0; i < defaultEmptyBoardPolls+1; i++ {
+		s.poll(context.Background(), p.ATS, p)
+	}
+	if st := s.Status()["quiet"]; st.Warning != "" {
+		t.Errorf("Warning = %q, want none for a board that never listed jobs", st.Warning)
+	}
+}
+
+// ConcurrencyTrackingFetcher records the peak number of concurrent FetchJobs calls.
+type ConcurrencyTrackingFetcher struct {
+	current *atomic.Int32
+	peak    *atomic.Int32
+	hold    time.Duration
+}
+
+func (f *ConcurrencyTrackingFetcher) FetchJobs(_ context.Context) ([]model.Job, error) {
+	n := f.current.Add(1)
+	defer f.current.Add(-1)
+	for {
+		p := f.peak.Load()
+		if n <= p || f.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(f.hold)
+	return nil, nil
+}
+
+func TestRun_FetchSemaphoreCapsConcurrency(t *testing.T) {
+	var current, peak atomic.Int32
+	const limit = 2
+	sem := make(chan struct{}, limit)
+
+	var pollers []*poller.CompanyPoller
+	for _, ats := range []string{"greenhouse", "ashby", "lever", "workday", "gem", "microsoft"} {
+		p := makePoller(ats+"-co", ats, &ConcurrencyTrackingFetcher{current: &current, peak: &peak, hold: 30 * time.Millisecond})
+		p.SetFetchSemaphore(sem)
+		pollers = append(pollers, p)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScheduler(pollers, time.Hour, 0, nil, discardLogger())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	// 6 fetches × 30ms at 2 wide ≈ 90ms; leave headroom.
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	<-done
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("peak concurrent fetches = %d, want <= %d", got, limit)
+	}
+	if got := peak.Load(); got < 1 {
+		t.Errorf("peak concurrent fetches = %d, expected fetches to run", got)
+	}
+}
+
+func TestRun_MaxPerATSConcurrencyOverlapsGroupPolls(t *testing.T) {
+	var current, peak atomic.Int32
+	const limit = 3
+
+	var pollers []*poller.CompanyPoller
+	for i := range 6 {
+		f := &ConcurrencyTrackingFetcher{current: &current, peak: &peak, hold: 100 * time.Millisecond}
+		pollers = append(pollers, makePoller(fmt.Sprintf("co%d", i), "greenhouse", f))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScheduler(pollers, time.Hour, 5*time.Millisecond, nil, discardLogger())
+	s.SetMaxPerATSConcurrency(limit)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	// 6 polls × 100ms at 3 wide ≈ 200ms; sequentially it would take 600ms.
+	time.Sleep(350 * time.Millisecond)
+	cancel()
+	<-done
+
+	if got := peak.Load(); got != limit {
+		t.Errorf("peak concurrent polls = %d, want %d", got, limit)
+	}
+	if got := len(s.Status()); got != len(pollers) {
+		t.Errorf("polled %d companies, want all %d", got, len(pollers))
+	}
+}
+
+// NotFoundFetcher fails with HTTP 404 until found is set, like a board with a
+// mistyped token that gets fixed.
+type NotFoundFetcher struct {
+	calls atomic.Int32
+	found atomic.Bool
+}
+
+func (f *NotFoundFetcher) FetchJobs(_ context.Context) ([]model.Job, error) {
+	f.calls.Add(1)
+	if f.found.Load() {
+		return nil, nil
+	}
+	return nil, &model.HTTPError{StatusCode: 404}
+}
+
+func TestRun_DisablesCompanyAfterRepeatedNotFound(t *testing.T) {
+	missing := &NotFoundFetcher{}
+	healthy := &CountingFetcher{}
+	typo := makePoller("typo", "greenhouse", missing)
+	pollers := []*poller.CompanyPoller{typo, makePoller("fine", "lever", healthy)}
+	var logs bytes.Buffer
+	s := NewScheduler(pollers, 10*time.Millisecond, 0, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	// Run well past the threshold: the healthy company keeps being polled
+	// while the missing board stops at defaultNotFoundPolls.
+	deadline := time.Now().Add(2 * time.Second)
+	for healthy.calls.Load() < 2*defaultNotFoundPolls+2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if got := missing.calls.Load(); got != defaultNotFoundPolls {
+		t.Errorf("404 company polled %d times, want %d", got, defaultNotFoundPolls)
+	}
+	st := s.Status()["typo"]
+	if !st.Disabled || st.NotFoundPolls != defaultNotFoundPolls || !strings.Contains(st.Warning, "board not found") {
+		t.Errorf("status = %+v, want disabled with a board-not-found warning", st)
+	}
+	if got := strings.Count(logs.String(), "disabling typo: board not found"); got != 1 {
+		t.Errorf("logged %d disable messages, want 1", got)
+	}
+
+	// Once the board is fixed, a successful (triggered) poll re-enables it.
+	missing.found.Store(true)
+	s.poll(context.Background(), typo.ATS, typo)
+	if st := s.Status()["typo"]; st.Disabled || st.NotFoundPolls != 0 || st.Warning != "" {
+		t.Errorf("status after successful poll = %+v, want re-enabled", st)
+	}
+}
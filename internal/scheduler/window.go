@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"slices"
+	"time"
+)
+
+// ActiveWindow is when a company may be polled: on Days, between Start and
+// End (offsets from midnight in Location). No Days means every day; Start ==
+// End means the whole day. End before Start wraps past midnight, and the
+// early-morning part counts toward the day the window started.
+type ActiveWindow struct {
+	Days     []time.Weekday
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+// Contains reports whether t falls inside the window.
+func (w ActiveWindow) Contains(t time.Time) bool {
+	t = t.In(w.Location)
+	y, m, d := t.Date()
+	offset := t.Sub(time.Date(y, m, d, 0, 0, 0, 0, w.Location))
+	day := t.Weekday()
+
+	switch {
+	case w.Start == w.End:
+		return w.onDay(day)
+	case w.Start < w.End:
+		return offset >= w.Start && offset < w.End && w.onDay(day)
+	case offset >= w.Start:
+		return w.onDay(day)
+	case offset < w.End:
+		return w.onDay((day + 6) % 7) // started yesterday
+	}
+	return false
+}
+
+func (w ActiveWindow) onDay(d time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, d)
+}
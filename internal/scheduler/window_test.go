@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActiveWindow_Contains(t *testing.T) {
+	// 2025-03-10 is a Monday.
+	at := func(day, h, m int) time.Time { return time.Date(2025, 3, 10+day, h, m, 0, 0, time.UTC) }
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	mornings := ActiveWindow{Days: weekdays, Start: 8 * time.Hour, End: 12 * time.Hour, Location: time.UTC}
+	overnight := ActiveWindow{Days: []time.Weekday{time.Friday}, Start: 22 * time.Hour, End: 2 * time.Hour, Location: time.UTC}
+	allDay := ActiveWindow{Days: weekdays, Location: time.UTC}
+
+	tests := []struct {
+		name   string
+		window ActiveWindow
+		t      time.Time
+		want   bool
+	}{
+		{"weekday morning", mornings, at(0, 9, 0), true},
+		{"at start", mornings, at(0, 8, 0), true},
+		{"at end", mornings, at(0, 12, 0), false},
+		{"weekday night", mornings, at(0, 23, 0), false},
+		{"weekend morning", mornings, at(5, 9, 0), false},
+		{"overnight start day", overnight, at(4, 23, 0), true},
+		{"overnight after midnight", overnight, at(5, 1, 0), true},
+		{"overnight after midnight wrong day", overnight, at(4, 1, 0), false},
+		{"all day weekday", allDay, at(2, 3, 0), true},
+		{"all day weekend", allDay, at(6, 3, 0), false},
+		{"no days", ActiveWindow{Start: 8 * time.Hour, End: 9 * time.Hour, Location: time.UTC}, at(6, 8, 30), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.t.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActiveWindow_ContainsUsesLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata unavailable:", err)
+	}
+	w := ActiveWindow{Start: 9 * time.Hour, End: 17 * time.Hour, Location: ny}
+	// 14:00 UTC is 10:00 in New York (EDT) on 2025-06-02.
+	if !w.Contains(time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)) {
+		t.Error("Contains(14:00 UTC) = false, want true (10:00 in New York)")
+	}
+	if w.Contains(time.Date(2025, 6, 2, 22, 0, 0, 0, time.UTC)) {
+		t.Error("Contains(22:00 UTC) = true, want false (18:00 in New York)")
+	}
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/scheduler"
+)
+
+// Controller is the scheduler surface the control API drives: HasCompany
+// reports whether a company is polled, Trigger queues an immediate poll of a
+// company by name, Status reports each company's last poll.
+type Controller interface {
+	HasCompany(company string) bool
+	Trigger(company string) error
+	Status() map[string]scheduler.CompanyStatus
+}
+
+// BuildInfo identifies the running binary, as reported by GET /version.
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// resetResponse is the body of a successful POST /reset.
+type resetResponse struct {
+	Company string `json:"company"`
+	Deleted int    `json:"deleted"`
+}
+
+// Server is the optional control HTTP server run alongside the daemon.
+type Server struct {
+	srv    *http.Server
+	logger *slog.Logger
+}
+
+// NewServer creates a control server listening on addr. store serves
+// POST /reset and metrics GET /metrics; nil disables either.
+func NewServer(addr string, ctl Controller, store model.CompanyDeleter, metrics io.WriterTo, info BuildInfo, logger *slog.Logger) *Server {
+	return &Server{
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           NewHandler(ctl, store, metrics, info, logger),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// NewHandler returns the control API routes:
+//
+//	POST /poll?company=<name>   queue an immediate poll of a company
+//	POST /reset?company=<name>  forget a company's seen jobs, matches and dedup keys and queue a poll (JSON)
+//	GET  /status                last poll time, error and new-job count per company (JSON)
+//	GET  /version               version, commit and build date of the binary (JSON)
+//	GET  /metrics               metrics in the Prometheus text format
+//
+// A nil store answers POST /reset with 501 Not Implemented; nil metrics
+// leaves GET /metrics unregistered.
+func NewHandler(ctl Controller, store model.CompanyDeleter, metrics io.WriterTo, info BuildInfo, logger *slog.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /poll", func(w http.ResponseWriter, r *http.Request) {
+		company := r.URL.Query().Get("company")
+		if company == "" {
+			http.Error(w, "company query parameter is required", http.StatusBadRequest)
+			return
+		}
+		err := ctl.Trigger(company)
+		switch {
+		case err == nil:
+			w.WriteHeader(http.StatusAccepted)
+		case errors.Is(err, scheduler.ErrUnknownCompany):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, scheduler.ErrTriggerPending):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			logger.Error("poll trigger failed", "company", company, "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("POST /reset", func(w http.ResponseWriter, r *http.Request) {
+		company := r.URL.Query().Get("company")
+		if company == "" {
+			http.Error(w, "company query parameter is required", http.StatusBadRequest)
+			return
+		}
+		if store == nil {
+			http.Error(w, "the store cannot delete by company", http.StatusNotImplemented)
+			return
+		}
+		// A name no poller has may still have rows from an earlier config;
+		// they are left alone, since nothing would re-poll the company.
+		if !ctl.HasCompany(company) {
+			http.Error(w, fmt.Errorf("%q: %w", company, scheduler.ErrUnknownCompany).Error(), http.StatusNotFound)
+			return
+		}
+		deleted, err := store.DeleteByCompany(company)
+		if err != nil {
+			logger.Error("reset failed", "company", company, "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// An already pending poll will see the reset as well.
+		err = ctl.Trigger(company)
+		switch {
+		case err == nil, errors.Is(err, scheduler.ErrTriggerPending):
+			logger.Info("company reset", "company", company, "deleted", deleted)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			if err := json.NewEncoder(w).Encode(resetResponse{Company: company, Deleted: deleted}); err != nil {
+				logger.Error("writing reset response failed", "error", err)
+			}
+		default:
+			logger.Error("poll trigger failed", "company", company, "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(ctl.Status()); err != nil {
+			logger.Error("writing status failed", "error", err)
+		}
+	})
+	mux.HandleFunc("GET /version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(info); err != nil {
+			logger.Error("writing version failed", "error", err)
+		}
+	})
+	if metrics != nil {
+		mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+			if _, err := metrics.WriteTo(w); err != nil {
+				logger.Error("writing metrics failed", "error", err)
+			}
+		})
+	}
+	return mux
+}
+
+// Run serves until ctx is cancelled, then shuts the server down.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info("control server listening", "addr", s.srv.Addr)
+		errCh <- s.srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.srv.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amishk599/firstin/internal/ai"
+	"github.com/amishk599/firstin/internal/model"
+	"github.com/amishk599/firstin/internal/poller"
+	"github.com/amishk599/firstin/internal/scheduler"
+	"github.com/amishk599/firstin/internal/store"
+)
+
+type stubTrigger struct {
+	known     map[string]bool
+	triggered []string
+	status    map[string]scheduler.CompanyStatus
+}
+
+func (s *stubTrigger) Status() map[string]scheduler.CompanyStatus {
+	return s.status
+}
+
+func (s *stubTrigger) HasCompany(company string) bool {
+	return s.known[company]
+}
+
+func (s *stubTrigger) Trigger(company string) error {
+	if !s.known[company] {
+		return fmt.Errorf("%q: %w", company, scheduler.ErrUnknownCompany)
+	}
+	s.triggered = append(s.triggered, company)
+	return nil
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestPollHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+	}{
+		{"known company", http.MethodPost, "/poll?company=stripe", http.StatusAccepted},
+		{"unknown company", http.MethodPost, "/poll?company=nope", http.StatusNotFound},
+		{"missing company", http.MethodPost, "/poll", http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "/poll?company=stripe", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trig := &stubTrigger{known: map[string]bool{"stripe": true}}
+			h := NewHandler(trig, nil, nil, BuildInfo{}, discardLogger())
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusAccepted && (len(trig.triggered) != 1 || trig.triggered[0] != "stripe") {
+				t.Errorf("triggered = %v, want [stripe]", trig.triggered)
+			}
+		})
+	}
+}
+
+func TestResetHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		want          int
+		wantDeleted   int
+		wantTriggered bool
+	}{
+		{"known company", "/reset?company=stripe", http.StatusAccepted, 2, true},
+		{"unknown company", "/reset?company=nope", http.StatusNotFound, 0, false},
+		{"missing company", "/reset", http.StatusBadRequest, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewInMemoryStore()
+			if err := s.MarkSeenJobs([]model.Job{
+				{ID: "s1", Company: "stripe"},
+				{ID: "s2", Company: "stripe"},
+				{ID: "a1", Company: "acme"},
+			}); err != nil {
+				t.Fatalf("MarkSeenJobs: %v", err)
+			}
+			trig := &stubTrigger{known: map[string]bool{"stripe": true, "acme": true}}
+			h := NewHandler(trig, s, nil, BuildInfo{}, discardLogger())
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.target, nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if got := len(trig.triggered) == 1; got != tt.wantTriggered {
+				t.Errorf("triggered = %v, want triggered %v", trig.triggered, tt.wantTriggered)
+			}
+			for id, want := range map[string]bool{"s1": tt.wantDeleted == 0, "s2": tt.wantDeleted == 0, "a1": true} {
+				if seen, _ := s.HasSeen(id); seen != want {
+					t.Errorf("HasSeen(%s) = %v, want %v", id, seen, want)
+				}
+			}
+			if tt.want == http.StatusAccepted {
+				var got resetResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+					t.Fatalf("decode body: %v", err)
+				}
+				if got.Deleted != tt.wantDeleted {
+					t.Errorf("deleted = %d, want %d", got.Deleted, tt.wantDeleted)
+				}
+			}
+		})
+	}
+}
+
+func TestResetHandler_NoStore(t *testing.T) {
+	h := NewHandler(&stubTrigger{known: map[string]bool{"stripe": true}}, nil, nil, BuildInfo{}, discardLogger())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reset?company=stripe", nil))
+
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("status = %d, want 501", rec.Code)
+	}
+}
+
+func TestStatusHandler(t *testing.T) {
+	polled := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	trig := &stubTrigger{status: map[string]scheduler.CompanyStatus{
+		"stripe": {ATS: "greenhouse", LastPolled: polled, LastNewCount: 2},
+		"acme":   {ATS: "lever", LastPolled: polled, LastError: "returned 503"},
+	}}
+	h := NewHandler(trig, nil, nil, BuildInfo{}, discardLogger())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var got map[string]scheduler.CompanyStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if got["stripe"].LastNewCount != 2 || !got["stripe"].LastPolled.Equal(polled) {
+		t.Errorf("stripe = %+v", got["stripe"])
+	}
+	if got["acme"].LastError != "returned 503" {
+		t.Errorf("acme = %+v, want last_error set", got["acme"])
+	}
+}
+
+func TestVersionHandler(t *testing.T) {
+	info := BuildInfo{Version: "v1.2.3", Commit: "abc123", Date: "2026-03-01T09:30:00Z"}
+	h := NewHandler(&stubTrigger{}, nil, nil, info, discardLogger())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var got BuildInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if got != info {
+		t.Errorf("version = %+v, want %+v", got, info)
+	}
+}
+
+// pollingController polls its one company synchronously when triggered.
+type pollingController struct {
+	p *poller.CompanyPoller
+}
+
+func (c *pollingController) HasCompany(company string) bool {
+	return strings.EqualFold(company, c.p.Name)
+}
+
+func (c *pollingController) Trigger(company string) error {
+	return c.p.Poll(context.Background())
+}
+
+func (c *pollingController) Status() map[string]scheduler.CompanyStatus { return nil }
+
+type staticFetcher []model.Job
+
+func (f staticFetcher) FetchJobs(context.Context) ([]model.Job, error) { return f, nil }
+
+type acceptAll struct{}
+
+func (acceptAll) Match(model.Job) bool { return true }
+
+type countingNotifier struct{ jobs []model.Job }
+
+func (n *countingNotifier) Notify(jobs []model.Job) ([]bool, error) {
+	n.jobs = append(n.jobs, jobs...)
+	delivered := make([]bool, len(jobs))
+	for i := range delivered {
+		delivered[i] = true
+	}
+	return delivered, nil
+}
+
+func TestResetHandler_RenotifiesWithPersistedDedup(t *testing.T) {
+	s := store.NewInMemoryStore()
+	s.MarkSeen("__seed__") // not a first run, so matches notify
+	dedup := poller.NewCrossCompanyDedup(0)
+	dedup.SetStore(s)
+
+	now := time.Now()
+	job := model.Job{ID: "s1", Company: "stripe", Title: "Backend Engineer", Location: "Remote", URL: "https://stripe.test/1", PostedAt: &now}
+	n := &countingNotifier{}
+	p := poller.NewCompanyPoller("stripe", "greenhouse", staticFetcher{job}, acceptAll{}, s, n, ai.NewNopJobAnalyzer(), 24*time.Hour, discardLogger())
+	p.SetCrossCompanyDedup(dedup)
+
+	for range 2 {
+		if err := p.Poll(context.Background()); err != nil {
+			t.Fatalf("Poll: %v", err)
+		}
+	}
+	if len(n.jobs) != 1 {
+		t.Fatalf("notified %d jobs before reset, want 1", len(n.jobs))
+	}
+
+	h := NewHandler(&pollingController{p: p}, s, nil, BuildInfo{}, discardLogger())
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reset?company=Stripe", nil))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202: %s", rec.Code, rec.Body)
+	}
+
+	if len(n.jobs) != 2 {
+		t.Fatalf("notified %d jobs after reset, want the role notified again", len(n.jobs))
+	}
+	if open, _ := s.OpenJobs("stripe"); len(open) != 1 {
+		t.Errorf("OpenJobs = %d, want the re-notified match recorded once", len(open))
+	}
+	// Another company's copy of the role is still suppressed by the new claim.
+	if claimant, _ := s.DedupClaimant("backend engineer|remote"); claimant != "stripe" {
+		t.Errorf("DedupClaimant = %q, want stripe", claimant)
+	}
+}
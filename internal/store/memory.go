@@ -0,0 +1,273 @@
+package store
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Ensure InMemoryStore implements model.JobStore and the optional store interfaces.
+var (
+	_ model.JobStore             = (*InMemoryStore)(nil)
+	_ model.DescriptionHashStore = (*InMemoryStore)(nil)
+	_ model.FirstSeenStore       = (*InMemoryStore)(nil)
+	_ model.SourceRecorder       = (*InMemoryStore)(nil)
+	_ model.CompanyDeleter       = (*InMemoryStore)(nil)
+	_ model.MatchRecorder        = (*InMemoryStore)(nil)
+	_ model.OpenJobStore         = (*InMemoryStore)(nil)
+	_ model.DedupKeyStore        = (*InMemoryStore)(nil)
+)
+
+// InMemoryStore tracks seen job IDs in a map for the life of the process.
+// Unlike NopStore it dedups within a run, but nothing survives a restart;
+// it suits ephemeral runs (CI, demos) that should not leave a jobs.db behind.
+type InMemoryStore struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time // job ID -> first seen
+	hashes    map[string]string    // job ID -> description hash
+	companies map[string]string    // job ID -> company
+	open      map[string]model.Job // job ID -> recorded match not yet closed
+	closed    map[string]string    // job ID -> company of a match marked closed
+	dedup     map[string]dedupKey  // cross-company dedup key -> claim
+	now       func() time.Time
+}
+
+// dedupKey is the company that claimed a cross-company dedup key, and when.
+type dedupKey struct {
+	company string
+	at      time.Time
+}
+
+// NewInMemoryStore returns an empty in-memory store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		seen:      make(map[string]time.Time),
+		hashes:    make(map[string]string),
+		companies: make(map[string]string),
+		open:      make(map[string]model.Job),
+		closed:    make(map[string]string),
+		dedup:     make(map[string]dedupKey),
+		now:       time.Now,
+	}
+}
+
+// HasSeen returns true if the given job ID has already been recorded.
+func (s *InMemoryStore) HasSeen(jobID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.seen[jobID]
+	return ok, nil
+}
+
+// MarkSeen records a job ID as seen. If it already exists the call is a no-op.
+func (s *InMemoryStore) MarkSeen(jobID string) error {
+	return s.MarkSeenBatch([]string{jobID})
+}
+
+// MarkSeenBatch records many job IDs as seen. Already-seen IDs keep their
+// original first-seen time.
+func (s *InMemoryStore) MarkSeenBatch(jobIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for _, id := range jobIDs {
+		if _, ok := s.seen[id]; !ok {
+			s.seen[id] = now
+		}
+	}
+	return nil
+}
+
+// MarkSeenJobs is MarkSeenBatch that also records each job's Company, so
+// DeleteByCompany can find it. Sources are not kept.
+func (s *InMemoryStore) MarkSeenJobs(jobs []model.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for _, j := range jobs {
+		if _, ok := s.seen[j.ID]; ok {
+			continue
+		}
+		s.seen[j.ID] = now
+		if j.Company != "" {
+			s.companies[j.ID] = j.Company
+		}
+	}
+	return nil
+}
+
+// DeleteByCompany forgets the seen jobs, recorded matches and cross-company
+// dedup keys of company (case-insensitive). It returns how many seen jobs
+// were removed.
+func (s *InMemoryStore) DeleteByCompany(company string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := 0
+	for id, c := range s.companies {
+		if strings.EqualFold(c, company) {
+			delete(s.seen, id)
+			delete(s.hashes, id)
+			delete(s.companies, id)
+			deleted++
+		}
+	}
+	for id, j := range s.open {
+		if strings.EqualFold(j.Company, company) {
+			delete(s.open, id)
+		}
+	}
+	for id, c := range s.closed {
+		if strings.EqualFold(c, company) {
+			delete(s.closed, id)
+		}
+	}
+	for key, c := range s.dedup {
+		if strings.EqualFold(c.company, company) {
+			delete(s.dedup, key)
+		}
+	}
+	return deleted, nil
+}
+
+// DedupClaimant returns the company that recorded the cross-company dedup
+// key, or "" if none has.
+func (s *InMemoryStore) DedupClaimant(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dedup[key].company, nil
+}
+
+// RecordDedupKeys records cross-company dedup keys as claimed by company.
+// Keys already recorded keep their first company.
+func (s *InMemoryStore) RecordDedupKeys(company string, keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for _, key := range keys {
+		if _, ok := s.dedup[key]; !ok {
+			s.dedup[key] = dedupKey{company: company, at: now}
+		}
+	}
+	return nil
+}
+
+// RecordMatches tracks the notified jobs as open for OpenJobs. Jobs already
+// recorded, open or closed, are left alone. Matches are not kept for export.
+func (s *InMemoryStore) RecordMatches(jobs []model.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range jobs {
+		if _, ok := s.open[j.ID]; ok {
+			continue
+		}
+		if _, ok := s.closed[j.ID]; ok {
+			continue
+		}
+		j.FirstSeen = s.now()
+		s.open[j.ID] = j
+	}
+	return nil
+}
+
+// OpenJobs returns company's recorded matches (case-insensitive) that have
+// not been marked closed.
+func (s *InMemoryStore) OpenJobs(company string) ([]model.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobs []model.Job
+	for _, j := range s.open {
+		if strings.EqualFold(j.Company, company) {
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs, nil
+}
+
+// MarkClosed stops tracking the given matches as open.
+func (s *InMemoryStore) MarkClosed(jobIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range jobIDs {
+		if j, ok := s.open[id]; ok {
+			delete(s.open, id)
+			s.closed[id] = j.Company
+		}
+	}
+	return nil
+}
+
+// Cleanup forgets job IDs and dedup keys first seen more than olderThan ago.
+func (s *InMemoryStore) Cleanup(olderThan time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := s.now().Add(-olderThan)
+	for id, firstSeen := range s.seen {
+		if firstSeen.Before(cutoff) {
+			delete(s.seen, id)
+			delete(s.hashes, id)
+			delete(s.companies, id)
+			delete(s.closed, id)
+		}
+	}
+	for id, j := range s.open {
+		if j.FirstSeen.Before(cutoff) {
+			delete(s.open, id)
+		}
+	}
+	for key, c := range s.dedup {
+		if c.at.Before(cutoff) {
+			delete(s.dedup, key)
+		}
+	}
+	return nil
+}
+
+// FirstSeen returns the first-seen time of each given job ID that has been
+// seen.
+func (s *InMemoryStore) FirstSeen(jobIDs []string) (map[string]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[string]time.Time)
+	for _, id := range jobIDs {
+		if t, ok := s.seen[id]; ok {
+			seen[id] = t
+		}
+	}
+	return seen, nil
+}
+
+// DescriptionHashes returns the stored description hash of each given job ID
+// that has one.
+func (s *InMemoryStore) DescriptionHashes(jobIDs []string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hashes := make(map[string]string)
+	for _, id := range jobIDs {
+		if h, ok := s.hashes[id]; ok {
+			hashes[id] = h
+		}
+	}
+	return hashes, nil
+}
+
+// SetDescriptionHashes stores a description hash per job ID. IDs that are not
+// seen are ignored.
+func (s *InMemoryStore) SetDescriptionHashes(hashes map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, h := range hashes {
+		if _, ok := s.seen[id]; ok {
+			s.hashes[id] = h
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true if no job IDs have been recorded.
+func (s *InMemoryStore) IsEmpty() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.seen) == 0, nil
+}
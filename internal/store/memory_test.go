@@ -0,0 +1,91 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInMemoryStore_MarkSeenThenHasSeen(t *testing.T) {
+	s := NewInMemoryStore()
+
+	empty, err := s.IsEmpty()
+	if err != nil {
+		t.Fatalf("IsEmpty: %v", err)
+	}
+	if !empty {
+		t.Error("expected new store to be empty")
+	}
+
+	if err := s.MarkSeen("job-1"); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	if err := s.MarkSeenBatch([]string{"job-2", "job-3", "job-1"}); err != nil {
+		t.Fatalf("MarkSeenBatch: %v", err)
+	}
+
+	for _, id := range []string{"job-1", "job-2", "job-3"} {
+		seen, err := s.HasSeen(id)
+		if err != nil {
+			t.Fatalf("HasSeen(%s): %v", id, err)
+		}
+		if !seen {
+			t.Errorf("HasSeen(%s) = false, want true", id)
+		}
+	}
+	if seen, _ := s.HasSeen("job-4"); seen {
+		t.Error("HasSeen(job-4) = true, want false for unknown job ID")
+	}
+
+	empty, err = s.IsEmpty()
+	if err != nil {
+		t.Fatalf("IsEmpty: %v", err)
+	}
+	if empty {
+		t.Error("expected store to be non-empty after MarkSeen")
+	}
+}
+
+func TestInMemoryStore_CleanupUsesFirstSeen(t *testing.T) {
+	s := NewInMemoryStore()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	if err := s.MarkSeen("old"); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	now = now.Add(48 * time.Hour)
+	if err := s.MarkSeen("recent"); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	// Re-marking must not refresh the first-seen time.
+	if err := s.MarkSeen("old"); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+
+	if err := s.Cleanup(24 * time.Hour); err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+
+	if seen, _ := s.HasSeen("old"); seen {
+		t.Error("expected job first seen 48h ago to be cleaned up")
+	}
+	if seen, _ := s.HasSeen("recent"); !seen {
+		t.Error("expected recent job to survive cleanup")
+	}
+}
+
+func TestInMemoryStore_DescriptionHashes(t *testing.T) {
+	s := NewInMemoryStore()
+	s.MarkSeen("a")
+
+	if err := s.SetDescriptionHashes(map[string]string{"a": "h1", "unseen": "h2"}); err != nil {
+		t.Fatalf("SetDescriptionHashes: %v", err)
+	}
+	got, err := s.DescriptionHashes([]string{"a", "unseen"})
+	if err != nil {
+		t.Fatalf("DescriptionHashes: %v", err)
+	}
+	if len(got) != 1 || got["a"] != "h1" {
+		t.Errorf("DescriptionHashes = %v, want only a=h1", got)
+	}
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations upgrade the schema one version at a time: migrations[i] takes a
+// database from version i to i+1. Append new steps; never edit or reorder
+// released ones, since existing jobs.db files have already applied them.
+var migrations = []func(tx *sql.Tx) error{
+	execStmt(`CREATE TABLE IF NOT EXISTS seen_jobs (
+		job_id     TEXT PRIMARY KEY,
+		first_seen DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`),
+	addColumn("seen_jobs", "source", "TEXT"),
+	execStmt(`CREATE TABLE IF NOT EXISTS matched_jobs (
+		job_id     TEXT PRIMARY KEY,
+		company    TEXT NOT NULL,
+		title      TEXT NOT NULL,
+		location   TEXT NOT NULL,
+		url        TEXT NOT NULL,
+		posted_at  DATETIME,
+		first_seen DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`),
+	addColumn("seen_jobs", "description_hash", "TEXT"),
+	addColumn("seen_jobs", "company", "TEXT"),
+	// Jobs seen before company was recorded get it back from their match, if
+	// they were notified.
+	execStmt(`UPDATE seen_jobs SET company = (
+		SELECT company FROM matched_jobs WHERE matched_jobs.job_id = seen_jobs.job_id
+	) WHERE company IS NULL`),
+	addColumn("matched_jobs", "closed_at", "DATETIME"),
+	execStmt(`CREATE TABLE IF NOT EXISTS dedup_keys (
+		dedup_key  TEXT PRIMARY KEY,
+		company    TEXT NOT NULL,
+		first_seen DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`),
+}
+
+// migrate applies every migration newer than the version recorded in
+// schema_version, each in its own transaction alongside the version bump, so
+// an interrupted upgrade resumes where it stopped. Databases created before
+// versioning start at 0; the early steps are written to be no-ops on them.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)"); err != nil {
+		return fmt.Errorf("creating schema_version table: %w", err)
+	}
+
+	var version int
+	err := db.QueryRow("SELECT version FROM schema_version").Scan(&version)
+	if err == sql.ErrNoRows {
+		if _, err := db.Exec("INSERT INTO schema_version (version) VALUES (0)"); err != nil {
+			return fmt.Errorf("initializing schema_version: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+
+	for v := version; v < len(migrations); v++ {
+		if err := applyMigration(db, v); err != nil {
+			return fmt.Errorf("migrating schema to version %d: %w", v+1, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(db *sql.DB, v int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := migrations[v](tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE schema_version SET version = ?", v+1); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// execStmt returns a migration that runs a single statement.
+func execStmt(stmt string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(stmt)
+		return err
+	}
+}
+
+// addColumn returns a migration that adds a column unless the table already
+// has it. Existing rows get NULL.
+func addColumn(table, column, typ string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		exists, err := columnExists(tx, table, column)
+		if err != nil || exists {
+			return err
+		}
+		_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, typ))
+		return err
+	}
+}
+
+// columnExists reports whether table has a column named column.
+func columnExists(tx *sql.Tx, table, column string) (bool, error) {
+	rows, err := tx.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return false, fmt.Errorf("inspecting %s columns: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, fmt.Errorf("inspecting %s columns: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func schemaVersion(t *testing.T, s *SQLiteStore) int {
+	t.Helper()
+	var v int
+	if err := s.db.QueryRow("SELECT version FROM schema_version").Scan(&v); err != nil {
+		t.Fatalf("reading schema_version: %v", err)
+	}
+	return v
+}
+
+func TestMigrate_UpgradesUnversionedDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "jobs.db")
+
+	// A jobs.db from before schema_version existed: seen_jobs only, no source.
+	old, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.Exec(`CREATE TABLE seen_jobs (
+		job_id     TEXT PRIMARY KEY,
+		first_seen DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		t.Fatalf("create old table: %v", err)
+	}
+	if _, err := old.Exec("INSERT INTO seen_jobs (job_id) VALUES ('kept')"); err != nil {
+		t.Fatalf("insert old row: %v", err)
+	}
+	old.Close()
+
+	s, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	defer s.Close()
+
+	if v := schemaVersion(t, s); v != len(migrations) {
+		t.Errorf("schema version = %d, want %d", v, len(migrations))
+	}
+	if seen, err := s.HasSeen("kept"); err != nil || !seen {
+		t.Errorf("HasSeen(kept) = %v, %v; want row preserved", seen, err)
+	}
+	if _, err := s.Matches(); err != nil {
+		t.Errorf("matched_jobs not created: %v", err)
+	}
+}
+
+func TestMigrate_ReopenIsIdempotent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "jobs.db")
+
+	s, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("first open: %v", err)
+	}
+	if err := s.MarkSeen("job-1"); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	s.Close()
+
+	s, err = NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+
+	if v := schemaVersion(t, s); v != len(migrations) {
+		t.Errorf("schema version = %d, want %d", v, len(migrations))
+	}
+	var rows int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM schema_version").Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 1 {
+		t.Errorf("schema_version has %d rows, want 1", rows)
+	}
+	if total, err := s.TotalSeen(); err != nil || total != 1 {
+		t.Errorf("TotalSeen = %d, %v; want 1", total, err)
+	}
+}
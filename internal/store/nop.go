@@ -10,5 +10,6 @@ func NewNopStore() *NopStore { return &NopStore{} }
 
 func (s *NopStore) HasSeen(jobID string) (bool, error) { return false, nil }
 func (s *NopStore) MarkSeen(jobID string) error        { return nil }
+func (s *NopStore) MarkSeenBatch(jobIDs []string) error { return nil }
 func (s *NopStore) Cleanup(olderThan time.Duration) error { return nil }
 func (s *NopStore) IsEmpty() (bool, error)             { return false, nil }
@@ -5,21 +5,44 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/amishk599/firstin/internal/model"
 	_ "modernc.org/sqlite"
 )
 
-// SQLiteStore tracks seen job IDs in a SQLite database for deduplication.
+// Ensure SQLiteStore implements model.JobStore and the optional store interfaces.
+var (
+	_ model.JobStore             = (*SQLiteStore)(nil)
+	_ model.SourceRecorder       = (*SQLiteStore)(nil)
+	_ model.MatchRecorder        = (*SQLiteStore)(nil)
+	_ model.DescriptionHashStore = (*SQLiteStore)(nil)
+	_ model.FirstSeenStore       = (*SQLiteStore)(nil)
+	_ model.CompanyDeleter       = (*SQLiteStore)(nil)
+	_ model.OpenJobStore         = (*SQLiteStore)(nil)
+	_ model.DedupKeyStore        = (*SQLiteStore)(nil)
+)
+
+// SQLiteStore tracks seen job IDs in a SQLite database for deduplication,
+// plus the full record of each notified match for export.
 type SQLiteStore struct {
 	db *sql.DB
 }
 
-// NewSQLiteStore opens (or creates) a SQLite database at dbPath and ensures the
-// seen_jobs table exists.
+// sqlitePragmas are applied to every pooled connection via the DSN:
+// WAL lets readers proceed alongside a writer, busy_timeout makes a locked
+// writer wait instead of failing with "database is locked", and
+// synchronous=NORMAL is the recommended durability level under WAL.
+const sqlitePragmas = "_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=synchronous(NORMAL)"
+
+// NewSQLiteStore opens (or creates) a SQLite database at dbPath and migrates
+// it to the current schema.
 func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?"+sqlitePragmas)
 	if err != nil {
 		return nil, fmt.Errorf("opening sqlite db: %w", err)
 	}
+	// SQLite allows a single writer; one pooled connection serializes the ATS
+	// goroutines' writes in-process rather than contending on the file lock.
+	db.SetMaxOpenConns(1)
 
 	// Verify the connection is alive.
 	if err := db.Ping(); err != nil {
@@ -27,13 +50,9 @@ func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
 		return nil, fmt.Errorf("pinging sqlite db: %w", err)
 	}
 
-	createTable := `CREATE TABLE IF NOT EXISTS seen_jobs (
-		job_id     TEXT PRIMARY KEY,
-		first_seen DATETIME DEFAULT CURRENT_TIMESTAMP
-	)`
-	if _, err := db.Exec(createTable); err != nil {
+	if err := migrate(db); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("creating seen_jobs table: %w", err)
+		return nil, err
 	}
 
 	return &SQLiteStore{db: db}, nil
@@ -61,16 +80,439 @@ func (s *SQLiteStore) MarkSeen(jobID string) error {
 	return nil
 }
 
-// Cleanup deletes seen-job entries older than the given duration.
+// MarkSeenBatch records many job IDs as seen in a single transaction, so a
+// large first-run seed costs one commit instead of one write per job. Either
+// every ID is recorded or none are. Already-seen IDs are ignored.
+func (s *SQLiteStore) MarkSeenBatch(jobIDs []string) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("marking %d jobs as seen: begin: %w", len(jobIDs), err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("INSERT OR IGNORE INTO seen_jobs (job_id) VALUES (?)")
+	if err != nil {
+		return fmt.Errorf("marking %d jobs as seen: prepare: %w", len(jobIDs), err)
+	}
+	defer stmt.Close()
+
+	for _, id := range jobIDs {
+		if _, err := stmt.Exec(id); err != nil {
+			return fmt.Errorf("marking job %s as seen: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("marking %d jobs as seen: commit: %w", len(jobIDs), err)
+	}
+	return nil
+}
+
+// MarkSeenJobs is MarkSeenBatch that also records each job's Source and
+// Company, so stats can break the seen jobs down by ATS and DeleteByCompany
+// can find a company's jobs.
+func (s *SQLiteStore) MarkSeenJobs(jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("marking %d jobs as seen: begin: %w", len(jobs), err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("INSERT OR IGNORE INTO seen_jobs (job_id, source, company) VALUES (?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("marking %d jobs as seen: prepare: %w", len(jobs), err)
+	}
+	defer stmt.Close()
+
+	for _, j := range jobs {
+		var source, company any
+		if j.Source != "" {
+			source = j.Source
+		}
+		if j.Company != "" {
+			company = j.Company
+		}
+		if _, err := stmt.Exec(j.ID, source, company); err != nil {
+			return fmt.Errorf("marking job %s as seen: %w", j.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("marking %d jobs as seen: commit: %w", len(jobs), err)
+	}
+	return nil
+}
+
+// RecordMatches stores the notified jobs so they can be exported later. Jobs
+// already recorded keep their original row.
+func (s *SQLiteStore) RecordMatches(jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("recording %d matches: begin: %w", len(jobs), err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO matched_jobs
+		(job_id, company, title, location, url, posted_at) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("recording %d matches: prepare: %w", len(jobs), err)
+	}
+	defer stmt.Close()
+
+	for _, j := range jobs {
+		var postedAt any
+		if j.PostedAt != nil {
+			postedAt = j.PostedAt.UTC()
+		}
+		if _, err := stmt.Exec(j.ID, j.Company, j.Title, j.Location, j.URL, postedAt); err != nil {
+			return fmt.Errorf("recording match %s: %w", j.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("recording %d matches: commit: %w", len(jobs), err)
+	}
+	return nil
+}
+
+// Matches returns every recorded match, most recently seen first. FirstSeen
+// is the time the match was recorded.
+func (s *SQLiteStore) Matches() ([]model.Job, error) {
+	rows, err := s.db.Query(`SELECT job_id, company, title, location, url, posted_at, first_seen
+		FROM matched_jobs ORDER BY first_seen DESC, rowid DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("listing matches: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []model.Job
+	for rows.Next() {
+		var j model.Job
+		var postedAt sql.NullTime
+		if err := rows.Scan(&j.ID, &j.Company, &j.Title, &j.Location, &j.URL, &postedAt, &j.FirstSeen); err != nil {
+			return nil, fmt.Errorf("listing matches: %w", err)
+		}
+		if postedAt.Valid {
+			t := postedAt.Time
+			j.PostedAt = &t
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing matches: %w", err)
+	}
+	return jobs, nil
+}
+
+// OpenJobs returns company's recorded matches (case-insensitive) that have
+// not been marked closed.
+func (s *SQLiteStore) OpenJobs(company string) ([]model.Job, error) {
+	rows, err := s.db.Query(`SELECT job_id, company, title, location, url, posted_at, first_seen
+		FROM matched_jobs WHERE company = ? COLLATE NOCASE AND closed_at IS NULL`, company)
+	if err != nil {
+		return nil, fmt.Errorf("listing open jobs of %s: %w", company, err)
+	}
+	defer rows.Close()
+
+	var jobs []model.Job
+	for rows.Next() {
+		var j model.Job
+		var postedAt sql.NullTime
+		if err := rows.Scan(&j.ID, &j.Company, &j.Title, &j.Location, &j.URL, &postedAt, &j.FirstSeen); err != nil {
+			return nil, fmt.Errorf("listing open jobs of %s: %w", company, err)
+		}
+		if postedAt.Valid {
+			t := postedAt.Time
+			j.PostedAt = &t
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing open jobs of %s: %w", company, err)
+	}
+	return jobs, nil
+}
+
+// MarkClosed records the given matches as closed, so OpenJobs no longer
+// returns them. They are kept for export.
+func (s *SQLiteStore) MarkClosed(jobIDs []string) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("closing %d jobs: begin: %w", len(jobIDs), err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("UPDATE matched_jobs SET closed_at = CURRENT_TIMESTAMP WHERE job_id = ? AND closed_at IS NULL")
+	if err != nil {
+		return fmt.Errorf("closing %d jobs: prepare: %w", len(jobIDs), err)
+	}
+	defer stmt.Close()
+
+	for _, id := range jobIDs {
+		if _, err := stmt.Exec(id); err != nil {
+			return fmt.Errorf("closing job %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("closing %d jobs: commit: %w", len(jobIDs), err)
+	}
+	return nil
+}
+
+// DedupClaimant returns the company that recorded the cross-company dedup
+// key, or "" if none has.
+func (s *SQLiteStore) DedupClaimant(key string) (string, error) {
+	var company string
+	err := s.db.QueryRow("SELECT company FROM dedup_keys WHERE dedup_key = ?", key).Scan(&company)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading dedup key %s: %w", key, err)
+	}
+	return company, nil
+}
+
+// RecordDedupKeys records cross-company dedup keys as claimed by company in
+// a single transaction. Keys already recorded keep their first company.
+func (s *SQLiteStore) RecordDedupKeys(company string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("recording %d dedup keys: begin: %w", len(keys), err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("INSERT OR IGNORE INTO dedup_keys (dedup_key, company) VALUES (?, ?)")
+	if err != nil {
+		return fmt.Errorf("recording %d dedup keys: prepare: %w", len(keys), err)
+	}
+	defer stmt.Close()
+
+	for _, key := range keys {
+		if _, err := stmt.Exec(key, company); err != nil {
+			return fmt.Errorf("recording dedup key %s: %w", key, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("recording %d dedup keys: commit: %w", len(keys), err)
+	}
+	return nil
+}
+
+// DescriptionHashes returns the stored description hash of each given job ID
+// that has one.
+func (s *SQLiteStore) DescriptionHashes(jobIDs []string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	if len(jobIDs) == 0 {
+		return hashes, nil
+	}
+	stmt, err := s.db.Prepare("SELECT description_hash FROM seen_jobs WHERE job_id = ? AND description_hash IS NOT NULL")
+	if err != nil {
+		return nil, fmt.Errorf("reading description hashes: prepare: %w", err)
+	}
+	defer stmt.Close()
+	for _, id := range jobIDs {
+		var hash string
+		err := stmt.QueryRow(id).Scan(&hash)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading description hash of %s: %w", id, err)
+		}
+		hashes[id] = hash
+	}
+	return hashes, nil
+}
+
+// SetDescriptionHashes stores a description hash per job ID. IDs that are not
+// seen are ignored.
+func (s *SQLiteStore) SetDescriptionHashes(hashes map[string]string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("storing %d description hashes: begin: %w", len(hashes), err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("UPDATE seen_jobs SET description_hash = ? WHERE job_id = ?")
+	if err != nil {
+		return fmt.Errorf("storing %d description hashes: prepare: %w", len(hashes), err)
+	}
+	defer stmt.Close()
+
+	for id, hash := range hashes {
+		if _, err := stmt.Exec(hash, id); err != nil {
+			return fmt.Errorf("storing description hash of %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("storing %d description hashes: commit: %w", len(hashes), err)
+	}
+	return nil
+}
+
+// Cleanup deletes seen-job, recorded-match and dedup-key entries older than
+// the given duration.
 func (s *SQLiteStore) Cleanup(olderThan time.Duration) error {
 	cutoff := time.Now().Add(-olderThan)
 	_, err := s.db.Exec("DELETE FROM seen_jobs WHERE first_seen < ?", cutoff)
 	if err != nil {
 		return fmt.Errorf("cleaning up seen jobs older than %v: %w", olderThan, err)
 	}
+	_, err = s.db.Exec("DELETE FROM matched_jobs WHERE first_seen < ?", cutoff)
+	if err != nil {
+		return fmt.Errorf("cleaning up matches older than %v: %w", olderThan, err)
+	}
+	_, err = s.db.Exec("DELETE FROM dedup_keys WHERE first_seen < ?", cutoff)
+	if err != nil {
+		return fmt.Errorf("cleaning up dedup keys older than %v: %w", olderThan, err)
+	}
 	return nil
 }
 
+// DeleteByCompany forgets the seen jobs, recorded matches and cross-company
+// dedup keys of company (case-insensitive) in one transaction, so the next
+// poll treats its current listings as new and notifies them again. Jobs seen
+// before the company was recorded, and never notified, cannot be matched and
+// are kept. It returns how many seen jobs were removed.
+func (s *SQLiteStore) DeleteByCompany(company string) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("deleting entries of %s: begin: %w", company, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("DELETE FROM seen_jobs WHERE company = ? COLLATE NOCASE", company)
+	if err != nil {
+		return 0, fmt.Errorf("deleting seen jobs of %s: %w", company, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("deleting seen jobs of %s: %w", company, err)
+	}
+	if _, err := tx.Exec("DELETE FROM matched_jobs WHERE company = ? COLLATE NOCASE", company); err != nil {
+		return 0, fmt.Errorf("deleting matches of %s: %w", company, err)
+	}
+	if _, err := tx.Exec("DELETE FROM dedup_keys WHERE company = ? COLLATE NOCASE", company); err != nil {
+		return 0, fmt.Errorf("deleting dedup keys of %s: %w", company, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("deleting entries of %s: commit: %w", company, err)
+	}
+	return int(n), nil
+}
+
+// TotalSeen returns the number of seen jobs.
+func (s *SQLiteStore) TotalSeen() (int, error) {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM seen_jobs").Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting seen jobs: %w", err)
+	}
+	return count, nil
+}
+
+// CountBySource returns the number of seen jobs per source. Jobs recorded
+// before sources were tracked are counted under "".
+func (s *SQLiteStore) CountBySource() (map[string]int, error) {
+	rows, err := s.db.Query("SELECT COALESCE(source, ''), COUNT(*) FROM seen_jobs GROUP BY 1")
+	if err != nil {
+		return nil, fmt.Errorf("counting seen jobs by source: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var source string
+		var n int
+		if err := rows.Scan(&source, &n); err != nil {
+			return nil, fmt.Errorf("counting seen jobs by source: %w", err)
+		}
+		counts[source] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("counting seen jobs by source: %w", err)
+	}
+	return counts, nil
+}
+
+// FirstSeen returns the first_seen time of each given job ID that has been
+// seen.
+func (s *SQLiteStore) FirstSeen(jobIDs []string) (map[string]time.Time, error) {
+	seen := make(map[string]time.Time)
+	if len(jobIDs) == 0 {
+		return seen, nil
+	}
+	stmt, err := s.db.Prepare("SELECT first_seen FROM seen_jobs WHERE job_id = ?")
+	if err != nil {
+		return nil, fmt.Errorf("reading first_seen: prepare: %w", err)
+	}
+	defer stmt.Close()
+	for _, id := range jobIDs {
+		var t time.Time
+		err := stmt.QueryRow(id).Scan(&t)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading first_seen of %s: %w", id, err)
+		}
+		seen[id] = t
+	}
+	return seen, nil
+}
+
+// OldestSeen returns the earliest first_seen, or the zero time if the store is empty.
+func (s *SQLiteStore) OldestSeen() (time.Time, error) {
+	return s.seenBound("ASC")
+}
+
+// NewestSeen returns the latest first_seen, or the zero time if the store is empty.
+func (s *SQLiteStore) NewestSeen() (time.Time, error) {
+	return s.seenBound("DESC")
+}
+
+// seenBound reads one end of the first_seen range. It selects the column
+// itself rather than MIN/MAX so the driver still scans it as a DATETIME.
+func (s *SQLiteStore) seenBound(order string) (time.Time, error) {
+	var t time.Time
+	err := s.db.QueryRow("SELECT first_seen FROM seen_jobs ORDER BY first_seen " + order + " LIMIT 1").Scan(&t)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading first_seen range: %w", err)
+	}
+	return t, nil
+}
+
 // IsEmpty returns true if the seen_jobs table has no entries.
 func (s *SQLiteStore) IsEmpty() (bool, error) {
 	var count int
@@ -1,9 +1,14 @@
 package store
 
 import (
+	"database/sql"
+	"fmt"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/amishk599/firstin/internal/model"
 )
 
 func newTestStore(t *testing.T) *SQLiteStore {
@@ -104,3 +109,382 @@ func TestCleanupRemovesOldKeepsFresh(t *testing.T) {
 		t.Error("expected fresh job to survive cleanup")
 	}
 }
+
+func TestMarkSeenBatch(t *testing.T) {
+	s := newTestStore(t)
+
+	ids := []string{"a", "b", "c", "b"}
+	if err := s.MarkSeenBatch(ids); err != nil {
+		t.Fatalf("MarkSeenBatch: %v", err)
+	}
+	// Idempotent: re-marking the same IDs (plus a new one) succeeds.
+	if err := s.MarkSeenBatch([]string{"a", "b", "c", "d"}); err != nil {
+		t.Fatalf("second MarkSeenBatch: %v", err)
+	}
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		seen, err := s.HasSeen(id)
+		if err != nil {
+			t.Fatalf("HasSeen(%s): %v", id, err)
+		}
+		if !seen {
+			t.Errorf("expected %s to be seen after batch", id)
+		}
+	}
+
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM seen_jobs").Scan(&count); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("row count = %d, want 4 (no duplicates)", count)
+	}
+}
+
+func TestMarkSeenBatchIsAtomic(t *testing.T) {
+	s := newTestStore(t)
+
+	// Force the insert of "bad" to fail mid-batch.
+	if _, err := s.db.Exec(`CREATE TRIGGER reject_bad BEFORE INSERT ON seen_jobs
+		WHEN NEW.job_id = 'bad' BEGIN SELECT RAISE(ABORT, 'rejected'); END`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	if err := s.MarkSeenBatch([]string{"first", "bad", "last"}); err == nil {
+		t.Fatal("expected MarkSeenBatch to fail")
+	}
+
+	empty, err := s.IsEmpty()
+	if err != nil {
+		t.Fatalf("IsEmpty: %v", err)
+	}
+	if !empty {
+		t.Error("expected no IDs recorded after a failed batch")
+	}
+}
+
+func TestPragmas(t *testing.T) {
+	s := newTestStore(t)
+
+	var mode string
+	if err := s.db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatalf("journal_mode: %v", err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode = %q, want wal", mode)
+	}
+	var timeout int
+	if err := s.db.QueryRow("PRAGMA busy_timeout").Scan(&timeout); err != nil {
+		t.Fatalf("busy_timeout: %v", err)
+	}
+	if timeout != 5000 {
+		t.Errorf("busy_timeout = %d, want 5000", timeout)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	s := newTestStore(t)
+
+	const workers, perWorker = 8, 50
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker*2)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id := fmt.Sprintf("w%d-job%d", w, i)
+				if err := s.MarkSeen(id); err != nil {
+					errs <- err
+				}
+				if _, err := s.HasSeen(id); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent access error: %v", err)
+	}
+
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM seen_jobs").Scan(&count); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != workers*perWorker {
+		t.Errorf("row count = %d, want %d", count, workers*perWorker)
+	}
+}
+
+func TestRecordMatchesThenMatches(t *testing.T) {
+	s := newTestStore(t)
+	posted := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	jobs := []model.Job{
+		{ID: "gh-1", Company: "acme", Title: "Backend Engineer", Location: "Remote", URL: "https://acme.test/1", PostedAt: &posted},
+		{ID: "gh-2", Company: "acme", Title: "SRE", Location: "NYC", URL: "https://acme.test/2"},
+	}
+
+	if err := s.RecordMatches(jobs); err != nil {
+		t.Fatalf("RecordMatches: %v", err)
+	}
+	// Re-recording is a no-op.
+	if err := s.RecordMatches(jobs[:1]); err != nil {
+		t.Fatalf("RecordMatches (duplicate): %v", err)
+	}
+
+	got, err := s.Matches()
+	if err != nil {
+		t.Fatalf("Matches: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d matches, want 2", len(got))
+	}
+	byID := map[string]model.Job{}
+	for _, j := range got {
+		byID[j.ID] = j
+	}
+	if j := byID["gh-1"]; j.Title != "Backend Engineer" || j.PostedAt == nil || !j.PostedAt.Equal(posted) {
+		t.Errorf("gh-1 = %+v", j)
+	}
+	if j := byID["gh-2"]; j.PostedAt != nil || j.FirstSeen.IsZero() {
+		t.Errorf("gh-2 PostedAt = %v, FirstSeen = %v; want nil and set", j.PostedAt, j.FirstSeen)
+	}
+}
+
+func TestSeenStats(t *testing.T) {
+	s := newTestStore(t)
+
+	if oldest, err := s.OldestSeen(); err != nil || !oldest.IsZero() {
+		t.Fatalf("OldestSeen on empty store = %v, %v; want zero time", oldest, err)
+	}
+
+	if err := s.MarkSeenJobs([]model.Job{
+		{ID: "gh-1", Source: "greenhouse"},
+		{ID: "gh-2", Source: "greenhouse"},
+		{ID: "lv-1", Source: "lever"},
+	}); err != nil {
+		t.Fatalf("MarkSeenJobs: %v", err)
+	}
+	if err := s.MarkSeen("legacy"); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+	old := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if _, err := s.db.Exec("UPDATE seen_jobs SET first_seen = ? WHERE job_id = 'legacy'", old.Format(time.DateTime)); err != nil {
+		t.Fatalf("backdate: %v", err)
+	}
+
+	total, err := s.TotalSeen()
+	if err != nil {
+		t.Fatalf("TotalSeen: %v", err)
+	}
+	if total != 4 {
+		t.Errorf("TotalSeen = %d, want 4", total)
+	}
+
+	bySource, err := s.CountBySource()
+	if err != nil {
+		t.Fatalf("CountBySource: %v", err)
+	}
+	want := map[string]int{"greenhouse": 2, "lever": 1, "": 1}
+	if fmt.Sprint(bySource) != fmt.Sprint(want) {
+		t.Errorf("CountBySource = %v, want %v", bySource, want)
+	}
+
+	oldest, err := s.OldestSeen()
+	if err != nil {
+		t.Fatalf("OldestSeen: %v", err)
+	}
+	if !oldest.Equal(old) {
+		t.Errorf("OldestSeen = %v, want %v", oldest, old)
+	}
+	newest, err := s.NewestSeen()
+	if err != nil {
+		t.Fatalf("NewestSeen: %v", err)
+	}
+	if !newest.After(old) {
+		t.Errorf("NewestSeen = %v, want after %v", newest, old)
+	}
+}
+
+func TestNewSQLiteStoreAddsSourceColumn(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "old.db")
+
+	// Create the seen_jobs shape from before source was tracked.
+	old, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.Exec(`CREATE TABLE seen_jobs (
+		job_id     TEXT PRIMARY KEY,
+		first_seen DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		t.Fatalf("create old table: %v", err)
+	}
+	if _, err := old.Exec("INSERT INTO seen_jobs (job_id) VALUES ('old-1')"); err != nil {
+		t.Fatalf("insert old row: %v", err)
+	}
+	old.Close()
+
+	s, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore on old schema: %v", err)
+	}
+	defer s.Close()
+
+	if seen, err := s.HasSeen("old-1"); err != nil || !seen {
+		t.Errorf("HasSeen(old-1) = %v, %v; want existing row kept", seen, err)
+	}
+	if err := s.MarkSeenJobs([]model.Job{{ID: "new-1", Source: "ashby"}}); err != nil {
+		t.Fatalf("MarkSeenJobs after migration: %v", err)
+	}
+	bySource, err := s.CountBySource()
+	if err != nil {
+		t.Fatalf("CountBySource: %v", err)
+	}
+	if bySource[""] != 1 || bySource["ashby"] != 1 {
+		t.Errorf("CountBySource = %v, want old row unknown and new row ashby", bySource)
+	}
+}
+
+func TestDescriptionHashes(t *testing.T) {
+	s := newTestStore(t)
+	if err := s.MarkSeenBatch([]string{"a", "b"}); err != nil {
+		t.Fatalf("MarkSeenBatch: %v", err)
+	}
+
+	if err := s.SetDescriptionHashes(map[string]string{"a": "h1", "unseen": "h2"}); err != nil {
+		t.Fatalf("SetDescriptionHashes: %v", err)
+	}
+	got, err := s.DescriptionHashes([]string{"a", "b", "unseen"})
+	if err != nil {
+		t.Fatalf("DescriptionHashes: %v", err)
+	}
+	if len(got) != 1 || got["a"] != "h1" {
+		t.Errorf("DescriptionHashes = %v, want only a=h1 (b has none, unseen is not stored)", got)
+	}
+
+	if err := s.SetDescriptionHashes(map[string]string{"a": "h3"}); err != nil {
+		t.Fatalf("SetDescriptionHashes: %v", err)
+	}
+	if got, _ := s.DescriptionHashes([]string{"a"}); got["a"] != "h3" {
+		t.Errorf("DescriptionHashes after update = %v, want a=h3", got)
+	}
+}
+
+func TestFirstSeen(t *testing.T) {
+	s := newTestStore(t)
+	old := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	if _, err := s.db.Exec("INSERT INTO seen_jobs (job_id, first_seen) VALUES (?, ?)", "old", old.Format(time.DateTime)); err != nil {
+		t.Fatalf("inserting old job: %v", err)
+	}
+	before := time.Now().UTC().Add(-time.Minute)
+	if err := s.MarkSeen("new"); err != nil {
+		t.Fatalf("MarkSeen: %v", err)
+	}
+
+	got, err := s.FirstSeen([]string{"old", "new", "unseen"})
+	if err != nil {
+		t.Fatalf("FirstSeen: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("FirstSeen = %v, want old and new only", got)
+	}
+	if !got["old"].Equal(old) {
+		t.Errorf("old first seen = %v, want %v", got["old"], old)
+	}
+	if got["new"].Before(before) {
+		t.Errorf("new first seen = %v, want about now", got["new"])
+	}
+}
+
+func TestDeleteByCompany(t *testing.T) {
+	s := newTestStore(t)
+	if err := s.MarkSeenJobs([]model.Job{
+		{ID: "a1", Company: "Acme"},
+		{ID: "a2", Company: "Acme"},
+		{ID: "s1", Company: "Stripe"},
+	}); err != nil {
+		t.Fatalf("MarkSeenJobs: %v", err)
+	}
+	if err := s.RecordMatches([]model.Job{
+		{ID: "a1", Company: "Acme", Title: "SRE", Location: "NYC", URL: "https://acme.test/1"},
+		{ID: "s1", Company: "Stripe", Title: "SRE", Location: "NYC", URL: "https://stripe.test/1"},
+	}); err != nil {
+		t.Fatalf("RecordMatches: %v", err)
+	}
+	if err := s.RecordDedupKeys("Acme", []string{"sre|nyc"}); err != nil {
+		t.Fatalf("RecordDedupKeys: %v", err)
+	}
+
+	n, err := s.DeleteByCompany("acme")
+	if err != nil {
+		t.Fatalf("DeleteByCompany: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("deleted = %d, want 2", n)
+	}
+	for id, want := range map[string]bool{"a1": false, "a2": false, "s1": true} {
+		if seen, err := s.HasSeen(id); err != nil || seen != want {
+			t.Errorf("HasSeen(%s) = %v, %v; want %v", id, seen, err, want)
+		}
+	}
+	if all, err := s.Matches(); err != nil || len(all) != 1 || all[0].ID != "s1" {
+		t.Errorf("Matches() = %+v, %v; want only Stripe's", all, err)
+	}
+	if claimant, err := s.DedupClaimant("sre|nyc"); err != nil || claimant != "" {
+		t.Errorf("DedupClaimant = %q, %v; want the key forgotten", claimant, err)
+	}
+}
+
+func TestOpenJobsAndMarkClosed(t *testing.T) {
+	s := newTestStore(t)
+	if err := s.RecordMatches([]model.Job{
+		{ID: "a1", Company: "Acme", Title: "Backend Engineer", Location: "Remote", URL: "https://acme.test/1"},
+		{ID: "a2", Company: "Acme", Title: "SRE", Location: "NYC", URL: "https://acme.test/2"},
+		{ID: "s1", Company: "Stripe", Title: "SRE", Location: "NYC", URL: "https://stripe.test/1"},
+	}); err != nil {
+		t.Fatalf("RecordMatches: %v", err)
+	}
+	if err := s.MarkClosed([]string{"a1"}); err != nil {
+		t.Fatalf("MarkClosed: %v", err)
+	}
+
+	open, err := s.OpenJobs("acme")
+	if err != nil {
+		t.Fatalf("OpenJobs: %v", err)
+	}
+	if len(open) != 1 || open[0].ID != "a2" || open[0].Title != "SRE" {
+		t.Errorf("OpenJobs(acme) = %+v, want only a2", open)
+	}
+	// Closed matches are still exported.
+	if all, err := s.Matches(); err != nil || len(all) != 3 {
+		t.Errorf("Matches() = %d jobs, %v; want 3", len(all), err)
+	}
+}
+
+func TestDedupKeys(t *testing.T) {
+	s := newTestStore(t)
+	if err := s.RecordDedupKeys("Acme", []string{"backend engineer|remote"}); err != nil {
+		t.Fatalf("RecordDedupKeys: %v", err)
+	}
+	// A later claim does not take the key over.
+	if err := s.RecordDedupKeys("Beta", []string{"backend engineer|remote", "sre|nyc"}); err != nil {
+		t.Fatalf("RecordDedupKeys: %v", err)
+	}
+
+	for key, want := range map[string]string{"backend engineer|remote": "Acme", "sre|nyc": "Beta", "unknown|": ""} {
+		if got, err := s.DedupClaimant(key); err != nil || got != want {
+			t.Errorf("DedupClaimant(%q) = %q, %v; want %q", key, got, err, want)
+		}
+	}
+	// Dedup keys are not seen jobs.
+	if empty, err := s.IsEmpty(); err != nil || !empty {
+		t.Errorf("IsEmpty() = %v, %v; want true with only dedup keys stored", empty, err)
+	}
+	if n, err := s.TotalSeen(); err != nil || n != 0 {
+		t.Errorf("TotalSeen() = %d, %v; want 0", n, err)
+	}
+}
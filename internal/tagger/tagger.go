@@ -0,0 +1,96 @@
+// Package tagger labels jobs with short tags ("Go", "Remote", "Senior") from
+// keyword rules, for quick scanning without the LLM.
+package tagger
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+// Rule adds Tag to a job whose title or description mentions any of Keywords.
+type Rule struct {
+	Tag      string
+	Keywords []string
+}
+
+// Tagger applies rules to jobs. Keywords match case-insensitively as whole
+// words, so "go" tags "Senior Go Engineer" but not "Google". A nil *Tagger
+// tags nothing.
+type Tagger struct {
+	rules []Rule // keywords lowercased
+}
+
+// New returns a tagger for rules. Rules without a tag or keywords are
+// ignored.
+func New(rules []Rule) *Tagger {
+	t := &Tagger{}
+	for _, r := range rules {
+		var keywords []string
+		for _, kw := range r.Keywords {
+			if kw = strings.ToLower(strings.TrimSpace(kw)); kw != "" {
+				keywords = append(keywords, kw)
+			}
+		}
+		if r.Tag == "" || len(keywords) == 0 {
+			continue
+		}
+		t.rules = append(t.rules, Rule{Tag: r.Tag, Keywords: keywords})
+	}
+	return t
+}
+
+// Tag returns the tags whose rules match job, in rule order and without
+// duplicates; nil when none match. The description is searched only when
+// the job carries detail.
+func (t *Tagger) Tag(job model.Job) []string {
+	if t == nil || len(t.rules) == 0 {
+		return nil
+	}
+	text := strings.ToLower(job.Title)
+	if job.Detail != nil && job.Detail.Description != "" {
+		text += "\n" + strings.ToLower(job.Detail.Description)
+	}
+
+	var tags []string
+	seen := make(map[string]bool)
+	for _, r := range t.rules {
+		if seen[r.Tag] {
+			continue
+		}
+		for _, kw := range r.Keywords {
+			if containsWord(text, kw) {
+				tags = append(tags, r.Tag)
+				seen[r.Tag] = true
+				break
+			}
+		}
+	}
+	return tags
+}
+
+// containsWord reports whether word occurs in text with no letter or digit
+// directly before or after it.
+func containsWord(text, word string) bool {
+	for i := 0; i+len(word) <= len(text); {
+		j := strings.Index(text[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		i = start + size
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,62 @@
+package tagger
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/amishk599/firstin/internal/model"
+)
+
+func TestTagger_Tag(t *testing.T) {
+	tg := New([]Rule{
+		{Tag: "Go", Keywords: []string{"go", "golang"}},
+		{Tag: "Remote", Keywords: []string{"remote"}},
+		{Tag: "Senior", Keywords: []string{"Senior", "Sr."}},
+		{Tag: "C++", Keywords: []string{"c++"}},
+	})
+
+	tests := []struct {
+		name string
+		job  model.Job
+		want []string
+	}{
+		{"several rules in rule order", model.Job{Title: "Senior Backend Engineer (Remote) - Go"}, []string{"Go", "Remote", "Senior"}},
+		{"keyword case-insensitive", model.Job{Title: "GOLANG developer"}, []string{"Go"}},
+		{"description searched", model.Job{Title: "Engineer", Detail: &model.JobDetail{Description: "You will write Go and C++ daily."}}, []string{"Go", "C++"}},
+		{"whole words only", model.Job{Title: "Google Ads Engineer, Gopher Team"}, nil},
+		{"punctuation keyword", model.Job{Title: "Sr. Platform Engineer"}, []string{"Senior"}},
+		{"no match", model.Job{Title: "Account Executive"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tg.Tag(tt.job); !slices.Equal(got, tt.want) {
+				t.Errorf("Tag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagger_SameTagFromSeveralRules(t *testing.T) {
+	tg := New([]Rule{
+		{Tag: "Infra", Keywords: []string{"kubernetes"}},
+		{Tag: "Infra", Keywords: []string{"terraform"}},
+	})
+	job := model.Job{Title: "Kubernetes and Terraform Engineer"}
+	if got := tg.Tag(job); !slices.Equal(got, []string{"Infra"}) {
+		t.Errorf("Tag() = %v, want [Infra] once", got)
+	}
+}
+
+func TestTagger_NoRules(t *testing.T) {
+	job := model.Job{Title: "Senior Go Engineer"}
+	var nilTagger *Tagger
+	for name, tg := range map[string]*Tagger{
+		"nil":         nilTagger,
+		"empty":       New(nil),
+		"blank rules": New([]Rule{{Tag: "", Keywords: []string{"go"}}, {Tag: "Go", Keywords: []string{" "}}}),
+	} {
+		if got := tg.Tag(job); got != nil {
+			t.Errorf("%s tagger: Tag() = %v, want nil", name, got)
+		}
+	}
+}